@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"errors"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+var _ ports.EmbedderPort = (*CachedEmbedderAdapter)(nil)
+
+// ErrMissingModel is returned by NewCachedEmbedderAdapter without a model:
+// keys of different models would collide and return vectors of the wrong
+// model.
+var ErrMissingModel = errors.New("cache: CachedEmbedderConfig.Model is required")
+
+type CachedEmbedderConfig struct {
+	// Model identifies the embedding model of the wrapped embedder. It is part
+	// of every cache key, so switching models never returns stale vectors.
+	// Required.
+	Model string
+}
+
+// CachedEmbedderAdapter is a ports.EmbedderPort decorator that only sends
+// texts to the wrapped embedder when their embedding is not cached yet.
+type CachedEmbedderAdapter struct {
+	Config *CachedEmbedderConfig
+
+	embedderAdapter       ports.EmbedderPort
+	embeddingCacheAdapter ports.EmbeddingCachePort
+}
+
+func NewCachedEmbedderAdapter(
+	config *CachedEmbedderConfig,
+
+	embedderAdapter ports.EmbedderPort,
+	embeddingCacheAdapter ports.EmbeddingCachePort,
+) (*CachedEmbedderAdapter, error) {
+	if config.model() == "" {
+		return nil, ErrMissingModel
+	}
+	return &CachedEmbedderAdapter{
+		Config: config,
+
+		embedderAdapter:       embedderAdapter,
+		embeddingCacheAdapter: embeddingCacheAdapter,
+	}, nil
+}
+
+func (c *CachedEmbedderConfig) model() string {
+	if c == nil {
+		return ""
+	}
+	return c.Model
+}
@@ -0,0 +1,11 @@
+package cache
+
+import "context"
+
+func (a *CachedEmbedderAdapter) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := a.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
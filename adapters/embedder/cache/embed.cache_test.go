@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedEmbedderAdapter_Embed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	embedder := mocks.NewMockEmbedderPort(ctrl)
+	cache := mocks.NewMockEmbeddingCachePort(ctrl)
+	adapter, err := NewCachedEmbedderAdapter(&CachedEmbedderConfig{Model: "model-a"}, embedder, cache)
+	require.NoError(t, err)
+
+	key := Key("model-a", "how many users?")
+	cache.EXPECT().
+		GetMany(gomock.Any(), []string{key}).
+		Return(map[string][]float32{key: {0.5, 0.5}}, nil)
+
+	embedding, err := adapter.Embed(context.Background(), "how many users?")
+	require.NoError(t, err)
+	require.Equal(t, []float32{0.5, 0.5}, embedding)
+}
+
+func TestNewCachedEmbedderAdapter_MissingModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	embedder := mocks.NewMockEmbedderPort(ctrl)
+	cache := mocks.NewMockEmbeddingCachePort(ctrl)
+
+	for _, config := range []*CachedEmbedderConfig{nil, {}} {
+		adapter, err := NewCachedEmbedderAdapter(config, embedder, cache)
+		require.ErrorIs(t, err, ErrMissingModel)
+		require.Nil(t, adapter)
+	}
+}
+
+func TestKey(t *testing.T) {
+	require.Equal(t, Key("model-a", "text"), Key("model-a", "text"))
+	require.NotEqual(t, Key("model-a", "text"), Key("model-b", "text"))
+	require.NotEqual(t, Key("model-a", "text"), Key("model-a", "other text"))
+}
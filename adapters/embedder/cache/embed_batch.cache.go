@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+)
+
+// EmbedBatch serves cached embeddings and sends only the distinct misses to
+// the wrapped embedder. The cache only saves cost: when it cannot be read
+// every text is treated as a miss, and failing to store new embeddings does
+// not fail the call.
+func (a *CachedEmbedderAdapter) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	// Step 1: Build the cache key of every text
+	model := a.Config.model()
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = Key(model, text)
+	}
+
+	// Step 2: Look the keys up in the cache
+	cached, err := a.embeddingCacheAdapter.GetMany(ctx, keys)
+	if err != nil {
+		cached = nil
+	}
+
+	// Step 3: Embed each missing text once, even if it is repeated
+	var (
+		missTexts []string
+		missKeys  []string
+		queued    = map[string]bool{}
+	)
+	for i, key := range keys {
+		if _, ok := cached[key]; ok || queued[key] {
+			continue
+		}
+		queued[key] = true
+		missTexts = append(missTexts, texts[i])
+		missKeys = append(missKeys, key)
+	}
+
+	if len(missTexts) > 0 {
+		embedded, err := a.embedderAdapter.EmbedBatch(ctx, missTexts)
+		if err != nil {
+			return nil, err
+		}
+		if len(embedded) != len(missTexts) {
+			return nil, fmt.Errorf("embedder returned %d embeddings for %d texts", len(embedded), len(missTexts))
+		}
+
+		fresh := make(map[string][]float32, len(missKeys))
+		for i, key := range missKeys {
+			fresh[key] = embedded[i]
+		}
+		_ = a.embeddingCacheAdapter.SetMany(ctx, fresh)
+
+		if cached == nil {
+			cached = make(map[string][]float32, len(fresh))
+		}
+		for key, embedding := range fresh {
+			cached[key] = embedding
+		}
+	}
+
+	// Step 4: Return the embeddings in the order of the texts
+	embeddings := make([][]float32, len(keys))
+	for i, key := range keys {
+		embeddings[i] = cached[key]
+	}
+	return embeddings, nil
+}
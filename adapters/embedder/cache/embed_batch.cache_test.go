@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedEmbedderAdapter_EmbedBatch(t *testing.T) {
+	model := "text-embedding-3-small"
+	keyA, keyB, keyC := Key(model, "a"), Key(model, "b"), Key(model, "c")
+	vecA, vecB, vecC := []float32{0.1}, []float32{0.2}, []float32{0.3}
+
+	tests := []struct {
+		name         string
+		texts        []string
+		prepareMock  func(embedder *mocks.MockEmbedderPort, cache *mocks.MockEmbeddingCachePort)
+		expectResult [][]float32
+		expectError  error
+	}{
+		{
+			name:  "all cached",
+			texts: []string{"a", "b"},
+			prepareMock: func(embedder *mocks.MockEmbedderPort, cache *mocks.MockEmbeddingCachePort) {
+				cache.EXPECT().
+					GetMany(gomock.Any(), []string{keyA, keyB}).
+					Return(map[string][]float32{keyA: vecA, keyB: vecB}, nil)
+			},
+			expectResult: [][]float32{vecA, vecB},
+		},
+		{
+			name:  "partial hit only embeds distinct misses",
+			texts: []string{"a", "b", "c", "b"},
+			prepareMock: func(embedder *mocks.MockEmbedderPort, cache *mocks.MockEmbeddingCachePort) {
+				cache.EXPECT().
+					GetMany(gomock.Any(), []string{keyA, keyB, keyC, keyB}).
+					Return(map[string][]float32{keyA: vecA}, nil)
+				embedder.EXPECT().
+					EmbedBatch(gomock.Any(), []string{"b", "c"}).
+					Return([][]float32{vecB, vecC}, nil)
+				cache.EXPECT().
+					SetMany(gomock.Any(), map[string][]float32{keyB: vecB, keyC: vecC}).
+					Return(nil)
+			},
+			expectResult: [][]float32{vecA, vecB, vecC, vecB},
+		},
+		{
+			name:  "cache read failure falls back to the embedder",
+			texts: []string{"a"},
+			prepareMock: func(embedder *mocks.MockEmbedderPort, cache *mocks.MockEmbeddingCachePort) {
+				cache.EXPECT().
+					GetMany(gomock.Any(), []string{keyA}).
+					Return(nil, errors.New("cache error"))
+				embedder.EXPECT().
+					EmbedBatch(gomock.Any(), []string{"a"}).
+					Return([][]float32{vecA}, nil)
+				cache.EXPECT().
+					SetMany(gomock.Any(), map[string][]float32{keyA: vecA}).
+					Return(errors.New("cache error"))
+			},
+			expectResult: [][]float32{vecA},
+		},
+		{
+			name:         "empty batch",
+			texts:        []string{},
+			expectResult: [][]float32{},
+		},
+		{
+			name:  "error embed misses",
+			texts: []string{"a"},
+			prepareMock: func(embedder *mocks.MockEmbedderPort, cache *mocks.MockEmbeddingCachePort) {
+				cache.EXPECT().
+					GetMany(gomock.Any(), []string{keyA}).
+					Return(map[string][]float32{}, nil)
+				embedder.EXPECT().
+					EmbedBatch(gomock.Any(), []string{"a"}).
+					Return(nil, errors.New("embedder error"))
+			},
+			expectError: errors.New("embedder error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			embedder := mocks.NewMockEmbedderPort(ctrl)
+			cache := mocks.NewMockEmbeddingCachePort(ctrl)
+			if tt.prepareMock != nil {
+				tt.prepareMock(embedder, cache)
+			}
+
+			adapter, err := NewCachedEmbedderAdapter(&CachedEmbedderConfig{Model: model}, embedder, cache)
+			require.NoError(t, err)
+
+			result, err := adapter.EmbedBatch(context.Background(), tt.texts)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectResult, result)
+			}
+		})
+	}
+}
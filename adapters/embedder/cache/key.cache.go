@@ -0,0 +1,13 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Key returns the cache key of text embedded by model: the model name and the
+// SHA-256 of the text.
+func Key(model, text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return model + ":" + hex.EncodeToString(sum[:])
+}
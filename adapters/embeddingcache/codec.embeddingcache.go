@@ -0,0 +1,30 @@
+// Package embeddingcache holds what the persistent ports.EmbeddingCachePort
+// backends share.
+package embeddingcache
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// EncodeEmbedding packs an embedding as little-endian float32 values.
+func EncodeEmbedding(embedding []float32) []byte {
+	encoded := make([]byte, 4*len(embedding))
+	for i, value := range embedding {
+		binary.LittleEndian.PutUint32(encoded[4*i:], math.Float32bits(value))
+	}
+	return encoded
+}
+
+// DecodeEmbedding reverses EncodeEmbedding.
+func DecodeEmbedding(encoded []byte) ([]float32, error) {
+	if len(encoded)%4 != 0 {
+		return nil, fmt.Errorf("embedding of %d bytes is not a float32 array", len(encoded))
+	}
+	embedding := make([]float32, len(encoded)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(encoded[4*i:]))
+	}
+	return embedding, nil
+}
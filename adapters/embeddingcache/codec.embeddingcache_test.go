@@ -0,0 +1,22 @@
+package embeddingcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddingCodec(t *testing.T) {
+	embedding := []float32{0.1, -2.5, 0, 3.4028235e38}
+
+	decoded, err := DecodeEmbedding(EncodeEmbedding(embedding))
+	require.NoError(t, err)
+	require.Equal(t, embedding, decoded)
+
+	decoded, err = DecodeEmbedding(EncodeEmbedding(nil))
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+
+	_, err = DecodeEmbedding([]byte{1, 2, 3})
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+var _ ports.EmbeddingCachePort = (*LRUEmbeddingCacheAdapter)(nil)
+
+// DefaultCapacity is the number of embeddings kept when no capacity is configured.
+const DefaultCapacity = 10_000
+
+type LRUEmbeddingCacheConfig struct {
+	// Capacity is the maximum number of embeddings kept in memory. Zero means
+	// DefaultCapacity.
+	Capacity int
+}
+
+// LRUEmbeddingCacheAdapter is an in-memory ports.EmbeddingCachePort that
+// evicts the least recently used embedding once it is full. It is safe for
+// concurrent use.
+type LRUEmbeddingCacheAdapter struct {
+	Config *LRUEmbeddingCacheConfig
+
+	mu    sync.Mutex
+	order *list.List // front is the most recently used
+	items map[string]*list.Element
+}
+
+type entry struct {
+	key       string
+	embedding []float32
+}
+
+func NewLRUEmbeddingCacheAdapter(config *LRUEmbeddingCacheConfig) *LRUEmbeddingCacheAdapter {
+	return &LRUEmbeddingCacheAdapter{
+		Config: config,
+		order:  list.New(),
+		items:  map[string]*list.Element{},
+	}
+}
+
+func (c *LRUEmbeddingCacheConfig) capacity() int {
+	if c == nil || c.Capacity <= 0 {
+		return DefaultCapacity
+	}
+	return c.Capacity
+}
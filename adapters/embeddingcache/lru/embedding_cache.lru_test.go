@@ -0,0 +1,33 @@
+package lru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	embeddingCacheTest "github.com/kamil5b/go-nl2query-lib/testsuites/embeddingcache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUEmbeddingCacheAdapter(t *testing.T) {
+	embeddingCacheTest.ContractTestEmbeddingCache(t, func(t *testing.T) ports.EmbeddingCachePort {
+		return NewLRUEmbeddingCacheAdapter(nil)
+	})
+}
+
+func TestLRUEmbeddingCacheAdapter_Eviction(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUEmbeddingCacheAdapter(&LRUEmbeddingCacheConfig{Capacity: 2})
+
+	require.NoError(t, cache.SetMany(ctx, map[string][]float32{"a": {1}}))
+	require.NoError(t, cache.SetMany(ctx, map[string][]float32{"b": {2}}))
+
+	// Reading "a" makes "b" the least recently used
+	_, err := cache.GetMany(ctx, []string{"a"})
+	require.NoError(t, err)
+	require.NoError(t, cache.SetMany(ctx, map[string][]float32{"c": {3}}))
+
+	result, err := cache.GetMany(ctx, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]float32{"a": {1}, "c": {3}}, result)
+}
@@ -0,0 +1,22 @@
+package lru
+
+import (
+	"context"
+	"slices"
+)
+
+func (a *LRUEmbeddingCacheAdapter) GetMany(ctx context.Context, keys []string) (map[string][]float32, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make(map[string][]float32, len(keys))
+	for _, key := range keys {
+		element, ok := a.items[key]
+		if !ok {
+			continue
+		}
+		a.order.MoveToFront(element)
+		result[key] = slices.Clone(element.Value.(*entry).embedding)
+	}
+	return result, nil
+}
@@ -0,0 +1,28 @@
+package lru
+
+import (
+	"context"
+	"slices"
+)
+
+func (a *LRUEmbeddingCacheAdapter) SetMany(ctx context.Context, embeddings map[string][]float32) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for key, embedding := range embeddings {
+		if element, ok := a.items[key]; ok {
+			element.Value.(*entry).embedding = slices.Clone(embedding)
+			a.order.MoveToFront(element)
+			continue
+		}
+		a.items[key] = a.order.PushFront(&entry{key: key, embedding: slices.Clone(embedding)})
+	}
+
+	// Evict the least recently used embeddings beyond capacity
+	for a.order.Len() > a.Config.capacity() {
+		oldest := a.order.Back()
+		a.order.Remove(oldest)
+		delete(a.items, oldest.Value.(*entry).key)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var _ ports.EmbeddingCachePort = (*RedisEmbeddingCacheAdapter)(nil)
+
+// DefaultKeyPrefix namespaces the cache keys when no prefix is configured.
+const DefaultKeyPrefix = "nl2query:embedding:"
+
+type RedisEmbeddingCacheConfig struct {
+	// KeyPrefix is prepended to every cache key. Defaults to DefaultKeyPrefix.
+	KeyPrefix string
+	// TTL expires cached embeddings. Zero keeps them until Redis evicts them.
+	TTL time.Duration
+}
+
+// RedisEmbeddingCacheAdapter is a ports.EmbeddingCachePort stored in Redis, so
+// the cache is shared by every instance of a multi-node deployment.
+type RedisEmbeddingCacheAdapter struct {
+	Config *RedisEmbeddingCacheConfig
+
+	client goredis.UniversalClient
+}
+
+func NewRedisEmbeddingCacheAdapter(
+	config *RedisEmbeddingCacheConfig,
+
+	client goredis.UniversalClient,
+) *RedisEmbeddingCacheAdapter {
+	return &RedisEmbeddingCacheAdapter{
+		Config: config,
+
+		client: client,
+	}
+}
+
+func (c *RedisEmbeddingCacheConfig) key(key string) string {
+	if c == nil || c.KeyPrefix == "" {
+		return DefaultKeyPrefix + key
+	}
+	return c.KeyPrefix + key
+}
+
+func (c *RedisEmbeddingCacheConfig) ttl() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.TTL
+}
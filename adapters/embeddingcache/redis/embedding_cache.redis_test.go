@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	embeddingCacheTest "github.com/kamil5b/go-nl2query-lib/testsuites/embeddingcache"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, goredis.UniversalClient) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+	return server, client
+}
+
+func TestRedisEmbeddingCacheAdapter(t *testing.T) {
+	embeddingCacheTest.ContractTestEmbeddingCache(t, func(t *testing.T) ports.EmbeddingCachePort {
+		_, client := newTestClient(t)
+		return NewRedisEmbeddingCacheAdapter(nil, client)
+	})
+}
+
+func TestRedisEmbeddingCacheAdapter_PrefixAndTTL(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	adapter := NewRedisEmbeddingCacheAdapter(&RedisEmbeddingCacheConfig{KeyPrefix: "test:", TTL: time.Hour}, client)
+
+	require.NoError(t, adapter.SetMany(ctx, map[string][]float32{"model:a": {1}}))
+	require.True(t, server.Exists("test:model:a"))
+	require.Equal(t, time.Hour, server.TTL("test:model:a"))
+
+	server.FastForward(2 * time.Hour)
+	result, err := adapter.GetMany(ctx, []string{"model:a"})
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestRedisEmbeddingCacheAdapter_Unreachable(t *testing.T) {
+	server, client := newTestClient(t)
+	adapter := NewRedisEmbeddingCacheAdapter(nil, client)
+	server.Close()
+
+	_, err := adapter.GetMany(context.Background(), []string{"model:a"})
+	require.Error(t, err)
+	require.Error(t, adapter.SetMany(context.Background(), map[string][]float32{"model:a": {1}}))
+}
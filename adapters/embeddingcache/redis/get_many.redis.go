@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/embeddingcache"
+)
+
+func (a *RedisEmbeddingCacheAdapter) GetMany(ctx context.Context, keys []string) (map[string][]float32, error) {
+	result := make(map[string][]float32, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = a.Config.key(key)
+	}
+
+	values, err := a.client.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		encoded, ok := value.(string)
+		if !ok {
+			continue // missing key
+		}
+		embedding, err := embeddingcache.DecodeEmbedding([]byte(encoded))
+		if err != nil {
+			return nil, err
+		}
+		result[keys[i]] = embedding
+	}
+	return result, nil
+}
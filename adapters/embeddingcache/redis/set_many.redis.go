@@ -0,0 +1,20 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/embeddingcache"
+)
+
+func (a *RedisEmbeddingCacheAdapter) SetMany(ctx context.Context, embeddings map[string][]float32) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+
+	pipe := a.client.Pipeline()
+	for key, embedding := range embeddings {
+		pipe.Set(ctx, a.Config.key(key), embeddingcache.EncodeEmbedding(embedding), a.Config.ttl())
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
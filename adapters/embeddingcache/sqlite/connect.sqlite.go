@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const defaultBusyTimeout = 5 * time.Second
+
+// Connect opens the cache file in WAL mode and creates the cache table and
+// the created_at index used to purge it.
+// dbURL is a file path or a "file:" URI.
+func (a *SQLiteEmbeddingCacheAdapter) Connect(ctx context.Context, dbURL string) error {
+	if a.db != nil {
+		return a.db.PingContext(ctx)
+	}
+
+	db, err := sql.Open("sqlite", a.dsn(dbURL))
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS embedding_cache (
+			key        TEXT PRIMARY KEY,
+			embedding  BLOB      NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		)`); err != nil {
+		_ = db.Close()
+		return err
+	}
+	if _, err := db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS embedding_cache_created_at
+			ON embedding_cache (created_at)`); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	a.db = db
+	return nil
+}
+
+func (a *SQLiteEmbeddingCacheAdapter) Close() error {
+	if a.db == nil {
+		return nil
+	}
+	err := a.db.Close()
+	a.db = nil
+	return err
+}
+
+func (a *SQLiteEmbeddingCacheAdapter) dsn(dbURL string) string {
+	busyTimeout := defaultBusyTimeout
+	if a.Config != nil && a.Config.BusyTimeout > 0 {
+		busyTimeout = a.Config.BusyTimeout
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout("+strconv.FormatInt(busyTimeout.Milliseconds(), 10)+")")
+	params.Add("_txlock", "immediate")
+
+	separator := "?"
+	if strings.Contains(dbURL, "?") {
+		separator = "&"
+	}
+	return dbURL + separator + params.Encode()
+}
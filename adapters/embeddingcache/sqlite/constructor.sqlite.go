@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+var _ ports.EmbeddingCachePort = (*SQLiteEmbeddingCacheAdapter)(nil)
+
+var ErrNotConnected = errors.New("embedding cache is not connected")
+
+type SQLiteEmbeddingCacheConfig struct {
+	// BusyTimeout is how long a connection waits for a lock held by another
+	// process before failing. Defaults to 5 seconds.
+	BusyTimeout time.Duration
+	// TTL expires cached embeddings: expired entries are no longer returned and
+	// are deleted on the next write. Zero keeps them forever.
+	TTL time.Duration
+	// MaxEntries bounds the cache size: every write deletes the oldest entries
+	// beyond it. Zero means unbounded.
+	MaxEntries int
+}
+
+// SQLiteEmbeddingCacheAdapter is a ports.EmbeddingCachePort persisted in a
+// SQLite file, so embeddings survive restarts of a single-node deployment.
+type SQLiteEmbeddingCacheAdapter struct {
+	Config *SQLiteEmbeddingCacheConfig
+
+	db *sql.DB
+}
+
+func (c *SQLiteEmbeddingCacheConfig) ttl() time.Duration {
+	if c == nil || c.TTL < 0 {
+		return 0
+	}
+	return c.TTL
+}
+
+func (c *SQLiteEmbeddingCacheConfig) maxEntries() int {
+	if c == nil || c.MaxEntries < 0 {
+		return 0
+	}
+	return c.MaxEntries
+}
+
+func NewSQLiteEmbeddingCacheAdapter(config *SQLiteEmbeddingCacheConfig) *SQLiteEmbeddingCacheAdapter {
+	return &SQLiteEmbeddingCacheAdapter{
+		Config: config,
+	}
+}
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/embeddingcache"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	embeddingCacheTest "github.com/kamil5b/go-nl2query-lib/testsuites/embeddingcache"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdapter(t *testing.T, path string) *SQLiteEmbeddingCacheAdapter {
+	t.Helper()
+	return newTestAdapterWithConfig(t, path, nil)
+}
+
+func newTestAdapterWithConfig(t *testing.T, path string, config *SQLiteEmbeddingCacheConfig) *SQLiteEmbeddingCacheAdapter {
+	t.Helper()
+
+	adapter := NewSQLiteEmbeddingCacheAdapter(config)
+	require.NoError(t, adapter.Connect(context.Background(), path))
+	t.Cleanup(func() {
+		require.NoError(t, adapter.Close())
+	})
+	return adapter
+}
+
+func TestSQLiteEmbeddingCacheAdapter(t *testing.T) {
+	embeddingCacheTest.ContractTestEmbeddingCache(t, func(t *testing.T) ports.EmbeddingCachePort {
+		return newTestAdapter(t, filepath.Join(t.TempDir(), "cache.db"))
+	})
+}
+
+func TestSQLiteEmbeddingCacheAdapter_Persistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	adapter := NewSQLiteEmbeddingCacheAdapter(nil)
+	require.NoError(t, adapter.Connect(ctx, path))
+
+	embeddings := map[string][]float32{}
+	keys := []string{}
+	for i := range 1200 { // more keys than one lookup query takes
+		key := fmt.Sprintf("model:%d", i)
+		embeddings[key] = []float32{float32(i)}
+		keys = append(keys, key)
+	}
+	require.NoError(t, adapter.SetMany(ctx, embeddings))
+	require.NoError(t, adapter.Close())
+
+	reopened := newTestAdapter(t, path)
+	result, err := reopened.GetMany(ctx, keys)
+	require.NoError(t, err)
+	require.Equal(t, embeddings, result)
+}
+
+func TestSQLiteEmbeddingCacheAdapter_TTL(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapterWithConfig(t, filepath.Join(t.TempDir(), "cache.db"), &SQLiteEmbeddingCacheConfig{TTL: time.Hour})
+
+	_, err := adapter.db.ExecContext(ctx, `INSERT INTO embedding_cache (key, embedding, created_at) VALUES (?, ?, ?)`,
+		"model:expired", embeddingcache.EncodeEmbedding([]float32{1}), time.Now().UTC().Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	result, err := adapter.GetMany(ctx, []string{"model:expired"})
+	require.NoError(t, err)
+	require.Empty(t, result)
+
+	require.NoError(t, adapter.SetMany(ctx, map[string][]float32{"model:fresh": {2}}))
+
+	var count int
+	require.NoError(t, adapter.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM embedding_cache`).Scan(&count))
+	require.Equal(t, 1, count)
+
+	result, err = adapter.GetMany(ctx, []string{"model:expired", "model:fresh"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]float32{"model:fresh": {2}}, result)
+}
+
+func TestSQLiteEmbeddingCacheAdapter_MaxEntries(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapterWithConfig(t, filepath.Join(t.TempDir(), "cache.db"), &SQLiteEmbeddingCacheConfig{MaxEntries: 2})
+
+	require.NoError(t, adapter.SetMany(ctx, map[string][]float32{"model:a": {1}}))
+	require.NoError(t, adapter.SetMany(ctx, map[string][]float32{"model:b": {2}}))
+	require.NoError(t, adapter.SetMany(ctx, map[string][]float32{"model:c": {3}}))
+
+	result, err := adapter.GetMany(ctx, []string{"model:a", "model:b", "model:c"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]float32{"model:b": {2}, "model:c": {3}}, result)
+}
+
+func TestSQLiteEmbeddingCacheAdapter_NotConnected(t *testing.T) {
+	adapter := NewSQLiteEmbeddingCacheAdapter(nil)
+
+	_, err := adapter.GetMany(context.Background(), []string{"model:a"})
+	require.ErrorIs(t, err, ErrNotConnected)
+	require.ErrorIs(t, adapter.SetMany(context.Background(), map[string][]float32{"model:a": {1}}), ErrNotConnected)
+	require.NoError(t, adapter.Close())
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/embeddingcache"
+)
+
+// maxKeysPerQuery keeps lookups well below SQLite's bound parameter limit.
+const maxKeysPerQuery = 500
+
+func (a *SQLiteEmbeddingCacheAdapter) GetMany(ctx context.Context, keys []string) (map[string][]float32, error) {
+	if a.db == nil {
+		return nil, ErrNotConnected
+	}
+
+	result := make(map[string][]float32, len(keys))
+	for start := 0; start < len(keys); start += maxKeysPerQuery {
+		chunk := keys[start:min(start+maxKeysPerQuery, len(keys))]
+
+		args := make([]any, len(chunk))
+		for i, key := range chunk {
+			args[i] = key
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+
+		query := `SELECT key, embedding FROM embedding_cache WHERE key IN (` + placeholders + `)`
+		if ttl := a.Config.ttl(); ttl > 0 {
+			query += ` AND created_at >= ?`
+			args = append(args, time.Now().UTC().Add(-ttl))
+		}
+
+		rows, err := a.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var (
+				key     string
+				encoded []byte
+			)
+			if err := rows.Scan(&key, &encoded); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			embedding, err := embeddingcache.DecodeEmbedding(encoded)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			result[key] = embedding
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
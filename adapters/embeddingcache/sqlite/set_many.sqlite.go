@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/embeddingcache"
+)
+
+func (a *SQLiteEmbeddingCacheAdapter) SetMany(ctx context.Context, embeddings map[string][]float32) error {
+	if a.db == nil {
+		return ErrNotConnected
+	}
+	if len(embeddings) == 0 {
+		return nil
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO embedding_cache (key, embedding, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			embedding  = excluded.embedding,
+			created_at = excluded.created_at`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now().UTC()
+	for key, embedding := range embeddings {
+		if _, err := stmt.ExecContext(ctx, key, embeddingcache.EncodeEmbedding(embedding), now); err != nil {
+			return err
+		}
+	}
+	if err := a.purge(ctx, tx, now); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// purge deletes the entries older than the TTL and the oldest entries beyond
+// MaxEntries.
+func (a *SQLiteEmbeddingCacheAdapter) purge(ctx context.Context, tx *sql.Tx, now time.Time) error {
+	if ttl := a.Config.ttl(); ttl > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM embedding_cache WHERE created_at < ?`, now.Add(-ttl)); err != nil {
+			return err
+		}
+	}
+	if maxEntries := a.Config.maxEntries(); maxEntries > 0 {
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM embedding_cache WHERE key IN (
+				SELECT key FROM embedding_cache
+				ORDER BY created_at DESC, rowid DESC
+				LIMIT -1 OFFSET ?
+			)`, maxEntries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+var ErrMissingDSN = errors.New("postgres: PostgresConfig.DSN is required")
+
+// Connect opens the pool to Config.DSN and applies pending migrations. The
+// argument is ignored: services call Connect on every request with a tenant
+// ID, while every tenant lives in the same internal database. Once connected
+// it only verifies the pool is healthy.
+func (a *PostgresAdapter) Connect(ctx context.Context, _ string) error {
+	if a.Connected() {
+		return a.Ping(ctx)
+	}
+	if a.Config == nil || a.Config.DSN == "" {
+		return ErrMissingDSN
+	}
+	return a.Open(ctx, a.open)
+}
+
+func (a *PostgresAdapter) open(ctx context.Context) (*sql.DB, error) {
+	db, err := sql.Open("pgx", a.Config.DSN)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(a.Config.MaxOpenConns)
+	db.SetMaxIdleConns(a.Config.MaxIdleConns)
+	db.SetConnMaxLifetime(a.Config.ConnMaxLifetime)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
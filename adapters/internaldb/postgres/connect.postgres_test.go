@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/adapters/internaldb/sqlstore"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockAdapter(t *testing.T) (*PostgresAdapter, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	adapter := NewPostgresAdapter(nil)
+	adapter.Attach(db)
+	return adapter, mock
+}
+
+func TestPostgresAdapter_Connect(t *testing.T) {
+	t.Run("already connected only pings", func(t *testing.T) {
+		adapter, mock := newMockAdapter(t)
+		mock.ExpectPing()
+
+		require.NoError(t, adapter.Connect(context.Background(), "tenant_123"))
+	})
+
+	t.Run("requires a DSN rather than the tenant ID it is given", func(t *testing.T) {
+		adapter := NewPostgresAdapter(&PostgresConfig{})
+
+		require.ErrorIs(t, adapter.Connect(context.Background(), "tenant_123"), ErrMissingDSN)
+		require.False(t, adapter.Connected())
+	})
+
+	t.Run("close releases the pool", func(t *testing.T) {
+		adapter, mock := newMockAdapter(t)
+		mock.ExpectClose()
+
+		require.NoError(t, adapter.Close())
+		require.False(t, adapter.Connected())
+	})
+
+	t.Run("not connected", func(t *testing.T) {
+		adapter := NewPostgresAdapter(nil)
+
+		_, err := adapter.ListAllWorkspaces(context.Background(), nil)
+		require.ErrorIs(t, err, sqlstore.ErrNotConnected)
+	})
+}
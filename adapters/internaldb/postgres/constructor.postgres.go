@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/internaldb/sqlstore"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+var _ ports.InternalDatabasePort = (*PostgresAdapter)(nil)
+
+type PostgresConfig struct {
+	// DSN is the connection string of the internal database, such as
+	// "postgres://nl2query:secret@db:5432/nl2query". Required.
+	DSN string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// PostgresAdapter is a ports.InternalDatabasePort backed by PostgreSQL. The
+// queries live in sqlstore; this adapter owns the pool and the migrations.
+type PostgresAdapter struct {
+	Config *PostgresConfig
+
+	*sqlstore.Store
+}
+
+func NewPostgresAdapter(config *PostgresConfig) *PostgresAdapter {
+	return &PostgresAdapter{
+		Config: config,
+
+		Store: sqlstore.New(sqlstore.Options{}),
+	}
+}
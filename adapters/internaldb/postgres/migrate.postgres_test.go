@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		require.Equal(t, i+1, m.Version, "migrations must be numbered without gaps")
+		require.NotEmpty(t, m.SQL)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	migrations, err := loadMigrations()
+	require.NoError(t, err)
+
+	t.Run("each migration holds the advisory lock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		for _, m := range migrations {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)).
+				WithArgs(migrationLockID).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`)).
+				WithArgs(m.Version).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			mock.ExpectCommit()
+		}
+
+		require.NoError(t, migrate(context.Background(), db))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
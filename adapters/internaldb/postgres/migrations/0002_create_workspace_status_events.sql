@@ -0,0 +1,12 @@
+-- Append-only: rows are never updated, and they outlive the workspace row so
+-- the audit trail survives a failed first ingestion.
+CREATE TABLE IF NOT EXISTS workspace_status_events (
+    id         BIGSERIAL PRIMARY KEY,
+    tenant_id  TEXT        NOT NULL,
+    type       TEXT        NOT NULL,
+    message    TEXT        NOT NULL DEFAULT '',
+    progress   JSONB,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS workspace_status_events_tenant_idx ON workspace_status_events (tenant_id, id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS query_history (
+    id         BIGSERIAL PRIMARY KEY,
+    tenant_id  TEXT        NOT NULL,
+    prompt     TEXT        NOT NULL,
+    query      TEXT        NOT NULL,
+    warning    TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS query_history_tenant_idx ON query_history (tenant_id, id);
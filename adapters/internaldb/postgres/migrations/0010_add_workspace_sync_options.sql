@@ -0,0 +1,3 @@
+-- The include/exclude patterns choosing the part of the client database a
+-- workspace syncs and its queries may read.
+ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS sync_options JSONB;
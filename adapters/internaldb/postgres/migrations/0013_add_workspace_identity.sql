@@ -0,0 +1,4 @@
+-- The display name, labels and data sources of named workspaces.
+ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS name TEXT NOT NULL DEFAULT '';
+ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS labels JSONB;
+ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS data_sources JSONB;
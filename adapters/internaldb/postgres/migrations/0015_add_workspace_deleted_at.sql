@@ -0,0 +1,5 @@
+-- Set on soft-deleted workspaces, which are purged once their retention
+-- window ends.
+ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS workspaces_deleted_at_idx ON workspaces (deleted_at);
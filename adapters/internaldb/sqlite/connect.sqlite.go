@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const defaultBusyTimeout = 5 * time.Second
+
+var ErrMissingPath = errors.New("sqlite: SQLiteConfig.Path is required")
+
+// Connect opens Config.Path in WAL mode and applies pending migrations. The
+// argument is ignored: services call Connect on every request with a tenant
+// ID, while every tenant lives in the same file. Once connected it only
+// verifies the database is reachable.
+func (a *SQLiteAdapter) Connect(ctx context.Context, _ string) error {
+	if a.Connected() {
+		return a.Ping(ctx)
+	}
+	if a.Config == nil || a.Config.Path == "" {
+		return ErrMissingPath
+	}
+	return a.Open(ctx, a.open)
+}
+
+func (a *SQLiteAdapter) open(ctx context.Context) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", a.dsn(a.Config.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// dsn adds the pragmas every pooled connection needs: WAL so readers do not
+// block the writer, a busy timeout so other processes wait instead of failing,
+// and immediate transactions so a write never has to upgrade a read lock.
+func (a *SQLiteAdapter) dsn(dbURL string) string {
+	busyTimeout := defaultBusyTimeout
+	if a.Config != nil && a.Config.BusyTimeout > 0 {
+		busyTimeout = a.Config.BusyTimeout
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout("+strconv.FormatInt(busyTimeout.Milliseconds(), 10)+")")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "synchronous(NORMAL)")
+	params.Add("_txlock", "immediate")
+
+	separator := "?"
+	if strings.Contains(dbURL, "?") {
+		separator = "&"
+	}
+	return dbURL + separator + params.Encode()
+}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdapter(t *testing.T, path string) *SQLiteAdapter {
+	t.Helper()
+
+	adapter := NewSQLiteAdapter(&SQLiteConfig{Path: path})
+	require.NoError(t, adapter.Connect(context.Background(), "tenant_123"))
+	t.Cleanup(func() {
+		require.NoError(t, adapter.Close())
+	})
+	return adapter
+}
+
+// openRaw opens a second, plain connection to inspect the file directly.
+func openRaw(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func TestSQLiteAdapter_Connect(t *testing.T) {
+	t.Run("migrates a new file in WAL mode", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nl2query.db")
+		newTestAdapter(t, path)
+		raw := openRaw(t, path)
+
+		migrations, err := loadMigrations()
+		require.NoError(t, err)
+
+		var applied int
+		require.NoError(t, raw.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied))
+		require.Equal(t, len(migrations), applied)
+
+		var journalMode string
+		require.NoError(t, raw.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode))
+		require.Equal(t, "wal", journalMode)
+	})
+
+	t.Run("reopening an existing file is idempotent", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nl2query.db")
+		first := NewSQLiteAdapter(&SQLiteConfig{Path: path})
+		require.NoError(t, first.Connect(context.Background(), "tenant_123"))
+		require.NoError(t, first.Close())
+
+		second := newTestAdapter(t, path)
+		require.NoError(t, second.Connect(context.Background(), "ignored once connected"))
+	})
+
+	t.Run("fails on an unreachable path", func(t *testing.T) {
+		adapter := NewSQLiteAdapter(&SQLiteConfig{Path: filepath.Join(t.TempDir(), "missing", "nl2query.db")})
+
+		err := adapter.Connect(context.Background(), "tenant_123")
+		require.Error(t, err)
+		require.False(t, adapter.Connected())
+	})
+
+	t.Run("opens the configured path rather than the tenant ID it is given", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nl2query.db")
+		newTestAdapter(t, path)
+
+		require.FileExists(t, path)
+		require.NoFileExists(t, "tenant_123")
+	})
+
+	t.Run("concurrent callers share a single pool", func(t *testing.T) {
+		adapter := NewSQLiteAdapter(&SQLiteConfig{Path: filepath.Join(t.TempDir(), "nl2query.db")})
+		t.Cleanup(func() {
+			require.NoError(t, adapter.Close())
+		})
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, adapter.Connect(context.Background(), "tenant_123"))
+			}()
+		}
+		wg.Wait()
+
+		_, err := adapter.ListAllWorkspaces(context.Background(), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("requires a path", func(t *testing.T) {
+		adapter := NewSQLiteAdapter(nil)
+
+		require.ErrorIs(t, adapter.Connect(context.Background(), "tenant_123"), ErrMissingPath)
+		require.False(t, adapter.Connected())
+	})
+}
+
+func TestSQLiteAdapter_DSN(t *testing.T) {
+	adapter := NewSQLiteAdapter(&SQLiteConfig{BusyTimeout: 2 * time.Second})
+
+	require.Equal(t,
+		"/data/nl2query.db?_pragma=journal_mode%28WAL%29&_pragma=busy_timeout%282000%29&_pragma=foreign_keys%281%29&_pragma=synchronous%28NORMAL%29&_txlock=immediate",
+		adapter.dsn("/data/nl2query.db"))
+	require.Equal(t,
+		"file:nl2query.db?mode=rwc&_pragma=journal_mode%28WAL%29&_pragma=busy_timeout%282000%29&_pragma=foreign_keys%281%29&_pragma=synchronous%28NORMAL%29&_txlock=immediate",
+		adapter.dsn("file:nl2query.db?mode=rwc"))
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/internaldb/sqlstore"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+var _ ports.InternalDatabasePort = (*SQLiteAdapter)(nil)
+
+type SQLiteConfig struct {
+	// Path is the database file, as a file path or a "file:" URI such as
+	// "file:/data/nl2query.db?mode=rwc". Required.
+	Path string
+
+	// BusyTimeout is how long a connection waits for a lock held by another
+	// process before failing. Defaults to 5 seconds.
+	BusyTimeout time.Duration
+}
+
+// SQLiteAdapter is a ports.InternalDatabasePort backed by a single SQLite file,
+// for single-node deployments and local development. It uses the pure-Go
+// modernc.org/sqlite driver, so no cgo toolchain is needed.
+type SQLiteAdapter struct {
+	Config *SQLiteConfig
+
+	*sqlstore.Store
+}
+
+func NewSQLiteAdapter(config *SQLiteConfig) *SQLiteAdapter {
+	return &SQLiteAdapter{
+		Config: config,
+
+		// SQLite allows a single writer; queueing writes in-process avoids
+		// SQLITE_BUSY between the pool's own connections.
+		Store: sqlstore.New(sqlstore.Options{SerializeWrites: true}),
+	}
+}
@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_Descriptions(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	descriptions := []*domains.Description{
+		{TenantID: "tenant_123", Table: "customers", Column: "city", Text: "City", Source: domains.DescriptionSourceInferred},
+		{TenantID: "tenant_123", Table: "customers", Text: "Customers", Source: domains.DescriptionSourceInferred},
+		{TenantID: "tenant_other", Table: "orders", Text: "Orders", Source: domains.DescriptionSourceInferred},
+	}
+	for _, description := range descriptions {
+		require.NoError(t, adapter.UpsertDescription(ctx, description))
+		require.False(t, description.CreatedAt.IsZero())
+	}
+
+	override := &domains.Description{TenantID: "tenant_123", Table: "customers", Column: "city", Text: "City of residence", Source: domains.DescriptionSourceUser}
+	require.NoError(t, adapter.UpsertDescription(ctx, override))
+	require.Equal(t, descriptions[0].CreatedAt, override.CreatedAt)
+
+	stored, err := adapter.ListDescriptionsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	require.Equal(t, "Customers", stored[0].Text)
+	require.Equal(t, "City of residence", stored[1].Text)
+	require.Equal(t, domains.DescriptionSourceUser, stored[1].Source)
+
+	require.NoError(t, adapter.DeleteDescription(ctx, "tenant_123", "customers", "city"))
+	stored, err = adapter.ListDescriptionsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+
+	require.NoError(t, adapter.DeleteDescriptionsByTenantID(ctx, "tenant_123"))
+	stored, err = adapter.ListDescriptionsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	stored, err = adapter.ListDescriptionsByTenantID(ctx, "tenant_other")
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_Examples(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	orders := &domains.Example{TenantID: "tenant_123", Question: "How many orders?", Query: "SELECT count(*) FROM orders"}
+	clients := &domains.Example{TenantID: "tenant_123", Question: "Top clients", Query: "SELECT name FROM customers LIMIT 10", Notes: "by revenue"}
+	other := &domains.Example{TenantID: "tenant_other", Question: "How many orders?", Query: "SELECT count(*) FROM sales"}
+	for _, example := range []*domains.Example{orders, clients, other} {
+		require.NoError(t, adapter.UpsertExample(ctx, example))
+		require.NotZero(t, example.ID)
+	}
+
+	orders.Query = "SELECT count(id) FROM orders"
+	require.NoError(t, adapter.UpsertExample(ctx, orders))
+
+	stored, err := adapter.GetExample(ctx, "tenant_123", orders.ID)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT count(id) FROM orders", stored.Query)
+
+	// Examples of another tenant are neither visible nor writable
+	stored, err = adapter.GetExample(ctx, "tenant_123", other.ID)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+	require.ErrorIs(t, adapter.UpsertExample(ctx, &domains.Example{ID: other.ID, TenantID: "tenant_123", Question: "x", Query: "y"}), domains.ErrExampleNotFound)
+	require.ErrorIs(t, adapter.DeleteExample(ctx, "tenant_123", other.ID), domains.ErrExampleNotFound)
+
+	examples, err := adapter.ListExamplesByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Len(t, examples, 2)
+	require.Equal(t, "by revenue", examples[1].Notes)
+
+	require.NoError(t, adapter.DeleteExample(ctx, "tenant_123", clients.ID))
+	require.NoError(t, adapter.DeleteExamplesByTenantID(ctx, "tenant_123"))
+	examples, err = adapter.ListExamplesByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Empty(t, examples)
+
+	examples, err = adapter.ListExamplesByTenantID(ctx, "tenant_other")
+	require.NoError(t, err)
+	require.Len(t, examples, 1)
+}
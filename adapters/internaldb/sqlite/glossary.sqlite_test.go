@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_GlossaryTerms(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	gmv := &domains.GlossaryTerm{TenantID: "tenant_123", Term: "GMV", Definition: "sum(order_items.price*qty)", Synonyms: []string{"gross merchandise value"}}
+	client := &domains.GlossaryTerm{TenantID: "tenant_123", Term: "client", Definition: "customers table"}
+	other := &domains.GlossaryTerm{TenantID: "tenant_other", Term: "client", Definition: "accounts table"}
+	for _, term := range []*domains.GlossaryTerm{gmv, client, other} {
+		require.NoError(t, adapter.UpsertGlossaryTerm(ctx, term))
+		require.NotZero(t, term.ID)
+	}
+
+	gmv.Definition = "sum(order_items.price*qty) excluding refunds"
+	require.NoError(t, adapter.UpsertGlossaryTerm(ctx, gmv))
+
+	stored, err := adapter.GetGlossaryTerm(ctx, "tenant_123", gmv.ID)
+	require.NoError(t, err)
+	require.Equal(t, "sum(order_items.price*qty) excluding refunds", stored.Definition)
+	require.Equal(t, []string{"gross merchandise value"}, stored.Synonyms)
+
+	// Terms of another tenant are neither visible nor writable
+	stored, err = adapter.GetGlossaryTerm(ctx, "tenant_123", other.ID)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+	require.ErrorIs(t, adapter.UpsertGlossaryTerm(ctx, &domains.GlossaryTerm{ID: other.ID, TenantID: "tenant_123", Term: "x", Definition: "y"}), domains.ErrGlossaryTermNotFound)
+	require.ErrorIs(t, adapter.DeleteGlossaryTerm(ctx, "tenant_123", other.ID), domains.ErrGlossaryTermNotFound)
+
+	terms, err := adapter.ListGlossaryTermsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Len(t, terms, 2)
+	require.Equal(t, []string{}, terms[1].Synonyms)
+
+	require.NoError(t, adapter.DeleteGlossaryTerm(ctx, "tenant_123", client.ID))
+	require.NoError(t, adapter.DeleteGlossaryTermsByTenantID(ctx, "tenant_123"))
+	terms, err = adapter.ListGlossaryTermsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Empty(t, terms)
+
+	terms, err = adapter.ListGlossaryTermsByTenantID(ctx, "tenant_other")
+	require.NoError(t, err)
+	require.Len(t, terms, 1)
+}
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+
+	"github.com/kamil5b/go-nl2query-lib/adapters/internaldb/sqlstore"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+func loadMigrations() ([]sqlstore.Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return sqlstore.LoadMigrations(files)
+}
+
+// migrate needs no explicit lock: with _txlock=immediate every migration
+// transaction already holds the database write lock.
+func migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	return sqlstore.Migrate(ctx, db, migrations, nil)
+}
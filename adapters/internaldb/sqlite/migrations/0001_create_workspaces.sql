@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS workspaces (
+    tenant_id        TEXT PRIMARY KEY,
+    encrypted_db_url TEXT      NOT NULL DEFAULT '',
+    status           TEXT      NOT NULL DEFAULT '',
+    checksum         TEXT      NOT NULL DEFAULT '',
+    created_at       TIMESTAMP NOT NULL,
+    updated_at       TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS workspaces_created_at_idx ON workspaces (created_at, tenant_id);
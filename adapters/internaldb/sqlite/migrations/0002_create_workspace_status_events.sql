@@ -0,0 +1,12 @@
+-- Append-only: rows are never updated, and they outlive the workspace row so
+-- the audit trail survives a failed first ingestion.
+CREATE TABLE IF NOT EXISTS workspace_status_events (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id  TEXT      NOT NULL,
+    type       TEXT      NOT NULL,
+    message    TEXT      NOT NULL DEFAULT '',
+    progress   TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS workspace_status_events_tenant_idx ON workspace_status_events (tenant_id, id);
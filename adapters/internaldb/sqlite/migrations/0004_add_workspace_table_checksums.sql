@@ -0,0 +1,3 @@
+-- Per-table checksums of the last successful ingestion, used to only
+-- re-embed changed tables.
+ALTER TABLE workspaces ADD COLUMN table_checksums TEXT;
@@ -0,0 +1,12 @@
+-- Inferred and user-reviewed descriptions of tables and columns. A table's
+-- own description has an empty column_name.
+CREATE TABLE IF NOT EXISTS schema_descriptions (
+    tenant_id   TEXT      NOT NULL,
+    table_name  TEXT      NOT NULL,
+    column_name TEXT      NOT NULL DEFAULT '',
+    description TEXT      NOT NULL,
+    source      TEXT      NOT NULL,
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (tenant_id, table_name, column_name)
+);
@@ -0,0 +1,12 @@
+-- Business vocabulary of a workspace. Synonyms are a JSON array of strings.
+CREATE TABLE IF NOT EXISTS glossary_terms (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id  TEXT      NOT NULL,
+    term       TEXT      NOT NULL,
+    definition TEXT      NOT NULL,
+    synonyms   TEXT      NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS glossary_terms_tenant_idx ON glossary_terms (tenant_id, id);
@@ -0,0 +1,12 @@
+-- Curated question/query pairs of a workspace, used as few-shot examples.
+CREATE TABLE IF NOT EXISTS examples (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id  TEXT      NOT NULL,
+    question   TEXT      NOT NULL,
+    query      TEXT      NOT NULL,
+    notes      TEXT      NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS examples_tenant_idx ON examples (tenant_id, id);
@@ -0,0 +1,6 @@
+-- Semantic model of a workspace, stored as its JSON encoding.
+CREATE TABLE IF NOT EXISTS semantic_models (
+    tenant_id  TEXT      PRIMARY KEY,
+    definition TEXT      NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,16 @@
+-- Immutable schema versions of a workspace, one per ingested checksum, stored
+-- as the JSON encoding of the metadata. Sync reports diff the client schema
+-- against the active version.
+CREATE TABLE IF NOT EXISTS schema_versions (
+    tenant_id  TEXT      NOT NULL,
+    version    INTEGER   NOT NULL,
+    checksum   TEXT      NOT NULL,
+    metadata   TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tenant_id, version),
+    UNIQUE (tenant_id, checksum)
+);
+
+-- The version the vectors were embedded from, and the one a rollback pinned.
+ALTER TABLE workspaces ADD COLUMN active_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workspaces ADD COLUMN pinned_version INTEGER NOT NULL DEFAULT 0;
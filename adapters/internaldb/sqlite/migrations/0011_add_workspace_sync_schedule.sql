@@ -0,0 +1,3 @@
+-- The cron expression of the scheduled syncs of a workspace, empty for the
+-- scheduler's default.
+ALTER TABLE workspaces ADD COLUMN sync_schedule TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- Tenant IDs generated from database URLs a workspace was rotated to, so a
+-- sync with the new URL finds the workspace of the original one.
+CREATE TABLE IF NOT EXISTS tenant_aliases (
+    alias      TEXT      PRIMARY KEY,
+    tenant_id  TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tenant_aliases_tenant_id_idx ON tenant_aliases (tenant_id);
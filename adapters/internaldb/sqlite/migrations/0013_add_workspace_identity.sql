@@ -0,0 +1,4 @@
+-- The display name, labels and data sources of named workspaces.
+ALTER TABLE workspaces ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE workspaces ADD COLUMN labels TEXT;
+ALTER TABLE workspaces ADD COLUMN data_sources TEXT;
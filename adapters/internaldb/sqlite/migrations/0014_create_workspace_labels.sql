@@ -0,0 +1,18 @@
+-- One row per workspace label, so listings filter on labels with the same
+-- query in both engines. Workspaces keep their labels column for reads.
+CREATE TABLE IF NOT EXISTS workspace_labels (
+    tenant_id TEXT NOT NULL,
+    key       TEXT NOT NULL,
+    value     TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS workspace_labels_key_value_idx ON workspace_labels (key, value);
+
+INSERT OR IGNORE INTO workspace_labels (tenant_id, key, value)
+SELECT workspaces.tenant_id, labels.key, labels.value
+FROM workspaces, json_each(workspaces.labels) AS labels
+WHERE workspaces.labels IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS workspaces_updated_at_idx ON workspaces (updated_at, tenant_id);
+CREATE INDEX IF NOT EXISTS workspaces_name_idx ON workspaces (name, tenant_id);
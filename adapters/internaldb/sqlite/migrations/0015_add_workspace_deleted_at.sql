@@ -0,0 +1,5 @@
+-- Set on soft-deleted workspaces, which are purged once their retention
+-- window ends.
+ALTER TABLE workspaces ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS workspaces_deleted_at_idx ON workspaces (deleted_at);
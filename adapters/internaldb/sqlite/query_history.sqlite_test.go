@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_QueryHistory(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	entries := []*domains.QueryHistoryEntry{
+		{TenantID: "tenant_123", Prompt: "how many users?", Query: "SELECT COUNT(*) FROM users"},
+		{TenantID: "tenant_other", Prompt: "list orders", Query: "SELECT * FROM orders"},
+		{TenantID: "tenant_123", Prompt: "drop users", Query: "DROP TABLE users", Warning: "DDL"},
+	}
+	for _, entry := range entries {
+		require.NoError(t, adapter.AppendQueryHistory(ctx, entry))
+		require.NotZero(t, entry.ID)
+	}
+
+	history, err := adapter.ListQueryHistoryByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "how many users?", history[0].Prompt)
+	require.Equal(t, "DDL", history[1].Warning)
+
+	entry, err := adapter.GetQueryHistoryEntry(ctx, "tenant_123", entries[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT COUNT(*) FROM users", entry.Query)
+
+	// Entries of another tenant are not visible
+	entry, err = adapter.GetQueryHistoryEntry(ctx, "tenant_123", entries[1].ID)
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	require.NoError(t, adapter.DeleteQueryHistoryByTenantID(ctx, "tenant_123"))
+	history, err = adapter.ListQueryHistoryByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	history, err = adapter.ListQueryHistoryByTenantID(ctx, "tenant_other")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_SchemaVersions(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	stored, err := adapter.GetSchemaVersion(ctx, "tenant_123", 1)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	first := &domains.DatabaseMetadata{
+		TenantID: "tenant_123",
+		Tables:   []domains.Table{{Name: "orders", Columns: []domains.Column{{Name: "id", Type: "INT", IsPrimaryKey: true}}}},
+		Checksum: "checksum_abc",
+	}
+	version, err := adapter.SaveSchemaVersion(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), version.Version)
+
+	second := &domains.DatabaseMetadata{
+		TenantID: "tenant_123",
+		Tables:   append(first.Tables, domains.Table{Name: "customers"}),
+		Checksum: "checksum_def",
+	}
+	version, err = adapter.SaveSchemaVersion(ctx, second)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version.Version)
+
+	// Rolling back to the first schema ingests its checksum again
+	version, err = adapter.SaveSchemaVersion(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), version.Version)
+
+	_, err = adapter.SaveSchemaVersion(ctx, &domains.DatabaseMetadata{TenantID: "tenant_456", Checksum: "checksum_abc"})
+	require.NoError(t, err)
+
+	versions, err := adapter.ListSchemaVersionsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, "checksum_abc", versions[0].Checksum)
+	require.Equal(t, "checksum_def", versions[1].Checksum)
+	require.Nil(t, versions[1].Metadata)
+
+	stored, err = adapter.GetSchemaVersion(ctx, "tenant_123", 2)
+	require.NoError(t, err)
+	require.Equal(t, second, stored.Metadata)
+
+	require.NoError(t, adapter.DeleteSchemaVersionsByTenantID(ctx, "tenant_123"))
+	versions, err = adapter.ListSchemaVersionsByTenantID(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	versions, err = adapter.ListSchemaVersionsByTenantID(ctx, "tenant_456")
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+}
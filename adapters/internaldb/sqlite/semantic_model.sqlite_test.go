@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_SemanticModel(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	stored, err := adapter.GetSemanticModel(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	semanticModel := &domains.SemanticModel{
+		TenantID: "tenant_123",
+		Metrics:  []domains.Metric{{Name: "revenue", Expression: "SUM(orders.total)", Table: "orders", Filters: []string{"orders.refunded = false"}}},
+	}
+	require.NoError(t, adapter.UpsertSemanticModel(ctx, semanticModel))
+
+	semanticModel.Dimensions = []domains.Dimension{{Name: "region", Expression: "customers.region", Table: "customers"}}
+	require.NoError(t, adapter.UpsertSemanticModel(ctx, semanticModel))
+
+	stored, err = adapter.GetSemanticModel(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Equal(t, semanticModel.Metrics, stored.Metrics)
+	require.Equal(t, semanticModel.Dimensions, stored.Dimensions)
+
+	require.NoError(t, adapter.DeleteSemanticModel(ctx, "tenant_123"))
+	stored, err = adapter.GetSemanticModel(ctx, "tenant_123")
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
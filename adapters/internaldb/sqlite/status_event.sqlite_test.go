@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_StatusEvents(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+	mockTenantID := "tenant_123"
+	startedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	events := []*domains.StatusEvent{
+		{TenantID: mockTenantID, Type: domains.StatusEventInProgress},
+		{TenantID: mockTenantID, Type: domains.StatusEventProgress, Progress: &domains.IngestionProgress{
+			Phase:     domains.IngestionPhaseEmbedding,
+			Done:      5,
+			Total:     10,
+			StartedAt: startedAt,
+		}},
+		{TenantID: "tenant_other", Type: domains.StatusEventDone},
+		{TenantID: mockTenantID, Type: domains.StatusEventError, Message: "upsert failed"},
+	}
+	for _, event := range events {
+		require.NoError(t, adapter.AppendStatusEvent(ctx, event))
+		require.NotZero(t, event.ID)
+	}
+
+	t.Run("history is per tenant and oldest first", func(t *testing.T) {
+		history, err := adapter.ListStatusEventsByTenantID(ctx, mockTenantID)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		require.Equal(t, domains.StatusEventInProgress, history[0].Type)
+		require.Equal(t, domains.StatusEventProgress, history[1].Type)
+		require.Equal(t, 5, history[1].Progress.Done)
+		require.True(t, startedAt.Equal(history[1].Progress.StartedAt))
+		require.Equal(t, domains.StatusEventError, history[2].Type)
+		require.Equal(t, "upsert failed", history[2].Message)
+	})
+
+	t.Run("latest of the requested types", func(t *testing.T) {
+		latest, err := adapter.GetLatestStatusEventByTenantID(ctx, mockTenantID, []domains.StatusEventType{domains.StatusEventProgress, domains.StatusEventInProgress})
+		require.NoError(t, err)
+		require.Equal(t, domains.StatusEventProgress, latest.Type)
+
+		latest, err = adapter.GetLatestStatusEventByTenantID(ctx, mockTenantID, []domains.StatusEventType{domains.StatusEventWarn})
+		require.NoError(t, err)
+		require.Nil(t, latest)
+	})
+
+	t.Run("delete history of one tenant", func(t *testing.T) {
+		require.NoError(t, adapter.DeleteStatusEventsByTenantID(ctx, mockTenantID))
+
+		history, err := adapter.ListStatusEventsByTenantID(ctx, mockTenantID)
+		require.NoError(t, err)
+		require.Empty(t, history)
+
+		history, err = adapter.ListStatusEventsByTenantID(ctx, "tenant_other")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	})
+
+	t.Run("transition sets only the workspace status", func(t *testing.T) {
+		require.NoError(t, adapter.UpsertWorkspace(ctx, &domains.Workspace{TenantID: mockTenantID, Status: domains.StatusInProgress, Checksum: "checksum_new"}))
+
+		event := &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventDone}
+		require.NoError(t, adapter.AppendStatusTransition(ctx, event, domains.StatusDone))
+		require.NotZero(t, event.ID)
+
+		workspace, err := adapter.GetWorkspaceByTenantID(ctx, mockTenantID)
+		require.NoError(t, err)
+		require.Equal(t, domains.StatusDone, workspace.Status)
+		require.Equal(t, "checksum_new", workspace.Checksum)
+
+		require.NoError(t, adapter.AppendStatusTransition(ctx, &domains.StatusEvent{TenantID: "tenant_missing", Type: domains.StatusEventDone}, domains.StatusDone))
+		workspace, err = adapter.GetWorkspaceByTenantID(ctx, "tenant_missing")
+		require.NoError(t, err)
+		require.Nil(t, workspace)
+	})
+}
+
+func TestSQLiteAdapter_ConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nl2query.db")
+
+	// Two adapters on one file behave like two processes sharing it.
+	adapters := []*SQLiteAdapter{newTestAdapter(t, path), newTestAdapter(t, path)}
+
+	const writers = 10
+	const writesPerWriter = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*writesPerWriter*2)
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			adapter := adapters[w%len(adapters)]
+			tenantID := fmt.Sprintf("tenant_%d", w)
+			for range writesPerWriter {
+				errs <- adapter.UpsertWorkspace(ctx, &domains.Workspace{TenantID: tenantID, Status: domains.StatusInProgress})
+				errs <- adapter.AppendStatusEvent(ctx, &domains.StatusEvent{TenantID: tenantID, Type: domains.StatusEventInProgress})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	workspaces, err := adapters[0].ListAllWorkspaces(ctx, &domains.WorkspaceQuery{Limit: writers})
+	require.NoError(t, err)
+	require.Len(t, workspaces.Workspaces, writers)
+
+	history, err := adapters[1].ListStatusEventsByTenantID(ctx, "tenant_0")
+	require.NoError(t, err)
+	require.Len(t, history, writesPerWriter)
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_TenantAliases(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	tenantID, err := adapter.GetTenantIDByAlias(ctx, "tenant_rotated")
+	require.NoError(t, err)
+	require.Empty(t, tenantID)
+
+	require.NoError(t, adapter.SaveTenantAlias(ctx, "tenant_rotated", "tenant_123"))
+	require.NoError(t, adapter.SaveTenantAlias(ctx, "tenant_other", "tenant_456"))
+
+	tenantID, err = adapter.GetTenantIDByAlias(ctx, "tenant_rotated")
+	require.NoError(t, err)
+	require.Equal(t, "tenant_123", tenantID)
+
+	// Rotating back and forth points the alias to the latest tenant
+	require.NoError(t, adapter.SaveTenantAlias(ctx, "tenant_rotated", "tenant_789"))
+	tenantID, err = adapter.GetTenantIDByAlias(ctx, "tenant_rotated")
+	require.NoError(t, err)
+	require.Equal(t, "tenant_789", tenantID)
+
+	aliases, err := adapter.ListTenantAliasesByTenantID(ctx, "tenant_789")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant_rotated"}, aliases)
+
+	require.NoError(t, adapter.DeleteTenantAliasesByTenantID(ctx, "tenant_789"))
+	tenantID, err = adapter.GetTenantIDByAlias(ctx, "tenant_rotated")
+	require.NoError(t, err)
+	require.Empty(t, tenantID)
+
+	tenantID, err = adapter.GetTenantIDByAlias(ctx, "tenant_other")
+	require.NoError(t, err)
+	require.Equal(t, "tenant_456", tenantID)
+}
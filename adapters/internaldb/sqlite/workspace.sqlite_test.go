@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteAdapter_Workspaces(t *testing.T) {
+	ctx := context.Background()
+	adapter := newTestAdapter(t, filepath.Join(t.TempDir(), "nl2query.db"))
+
+	t.Run("missing workspace returns nil", func(t *testing.T) {
+		workspace, err := adapter.GetWorkspaceByTenantID(ctx, "tenant_missing")
+		require.NoError(t, err)
+		require.Nil(t, workspace)
+	})
+
+	first := &domains.Workspace{
+		TenantID:       "tenant_123",
+		EncryptedDBURL: "enc_1",
+		Status:         domains.StatusInProgress,
+	}
+	second := &domains.Workspace{
+		TenantID:       "tenant_456",
+		EncryptedDBURL: "enc_2",
+		Status:         domains.StatusDone,
+		Checksum:       "checksum_def",
+	}
+
+	t.Run("insert fills timestamps", func(t *testing.T) {
+		require.NoError(t, adapter.UpsertWorkspace(ctx, first))
+		require.False(t, first.CreatedAt.IsZero())
+		require.Equal(t, first.CreatedAt, first.UpdatedAt)
+
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, adapter.UpsertWorkspace(ctx, second))
+	})
+
+	t.Run("update keeps creation time", func(t *testing.T) {
+		createdAt := first.CreatedAt
+		time.Sleep(5 * time.Millisecond)
+
+		updated := &domains.Workspace{
+			TenantID:       first.TenantID,
+			EncryptedDBURL: first.EncryptedDBURL,
+			Status:         domains.StatusDone,
+			Checksum:       "checksum_abc",
+			TableChecksums: map[string]string{"orders": "o1"},
+			ActiveVersion:  2,
+			PinnedVersion:  1,
+			SyncOptions:    &domains.SyncOptions{ExcludeTables: []string{"audit_*"}},
+			SyncSchedule:   "@daily",
+			Name:           "Sales",
+			Labels:         map[string]string{"team": "sales"},
+			DataSources:    []domains.DataSource{{Name: "oltp", EncryptedDBURL: "enc_oltp"}},
+		}
+		require.NoError(t, adapter.UpsertWorkspace(ctx, updated))
+		require.True(t, createdAt.Equal(updated.CreatedAt))
+		require.True(t, updated.UpdatedAt.After(createdAt))
+
+		stored, err := adapter.GetWorkspaceByTenantID(ctx, first.TenantID)
+		require.NoError(t, err)
+		require.Equal(t, domains.StatusDone, stored.Status)
+		require.Equal(t, "checksum_abc", stored.Checksum)
+		require.Equal(t, map[string]string{"orders": "o1"}, stored.TableChecksums)
+		require.Equal(t, int64(2), stored.ActiveVersion)
+		require.Equal(t, int64(1), stored.PinnedVersion)
+		require.Equal(t, &domains.SyncOptions{ExcludeTables: []string{"audit_*"}}, stored.SyncOptions)
+		require.Equal(t, "@daily", stored.SyncSchedule)
+		require.Equal(t, "Sales", stored.Name)
+		require.Equal(t, map[string]string{"team": "sales"}, stored.Labels)
+		require.Equal(t, []domains.DataSource{{Name: "oltp", EncryptedDBURL: "enc_oltp"}}, stored.DataSources)
+		require.True(t, createdAt.Equal(stored.CreatedAt))
+		require.True(t, updated.UpdatedAt.Equal(stored.UpdatedAt))
+	})
+
+	t.Run("list ordered by creation", func(t *testing.T) {
+		page, err := adapter.ListAllWorkspaces(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, page.Workspaces, 2)
+		require.Equal(t, "tenant_123", page.Workspaces[0].TenantID)
+		require.Equal(t, "tenant_456", page.Workspaces[1].TenantID)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("list pages with a cursor", func(t *testing.T) {
+		query := &domains.WorkspaceQuery{SortBy: domains.WorkspaceSortUpdatedAt, Descending: true, Limit: 1}
+		page, err := adapter.ListAllWorkspaces(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, page.Workspaces, 1)
+		require.Equal(t, "tenant_123", page.Workspaces[0].TenantID)
+		require.NotEmpty(t, page.NextCursor)
+
+		query.Cursor = page.NextCursor
+		page, err = adapter.ListAllWorkspaces(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, page.Workspaces, 1)
+		require.Equal(t, "tenant_456", page.Workspaces[0].TenantID)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("list filtered", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			query  *domains.WorkspaceQuery
+			expect []string
+		}{
+			"by status":         {&domains.WorkspaceQuery{Statuses: []domains.WorkspaceStatus{domains.StatusDone}}, []string{"tenant_123", "tenant_456"}},
+			"by label":          {&domains.WorkspaceQuery{Labels: map[string]string{"team": "sales"}}, []string{"tenant_123"}},
+			"by unknown label":  {&domains.WorkspaceQuery{Labels: map[string]string{"team": "ops"}}, nil},
+			"by name search":    {&domains.WorkspaceQuery{Search: "SAL"}, []string{"tenant_123"}},
+			"by id search":      {&domains.WorkspaceQuery{Search: "456"}, []string{"tenant_456"}},
+			"by wildcard":       {&domains.WorkspaceQuery{Search: "_"}, []string{"tenant_123", "tenant_456"}},
+			"by creation range": {&domains.WorkspaceQuery{CreatedSince: second.CreatedAt}, []string{"tenant_456"}},
+			"by update range":   {&domains.WorkspaceQuery{UpdatedBefore: second.UpdatedAt}, nil},
+			"by name":           {&domains.WorkspaceQuery{SortBy: domains.WorkspaceSortName, Descending: true}, []string{"tenant_123", "tenant_456"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				page, err := adapter.ListAllWorkspaces(ctx, tt.query)
+				require.NoError(t, err)
+				var tenantIDs []string
+				for _, workspace := range page.Workspaces {
+					tenantIDs = append(tenantIDs, workspace.TenantID)
+				}
+				require.Equal(t, tt.expect, tenantIDs)
+			})
+		}
+	})
+
+	t.Run("soft-deleted workspaces are listed apart", func(t *testing.T) {
+		deletedAt := time.Now().UTC().Add(-time.Hour)
+		second.DeletedAt = deletedAt
+		require.NoError(t, adapter.UpsertWorkspace(ctx, second))
+
+		stored, err := adapter.GetWorkspaceByTenantID(ctx, second.TenantID)
+		require.NoError(t, err)
+		require.True(t, deletedAt.Equal(stored.DeletedAt))
+
+		page, err := adapter.ListAllWorkspaces(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, page.Workspaces, 1)
+		require.Equal(t, "tenant_123", page.Workspaces[0].TenantID)
+
+		page, err = adapter.ListAllWorkspaces(ctx, &domains.WorkspaceQuery{Deleted: true, DeletedBefore: deletedAt.Add(time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, page.Workspaces, 1)
+		require.Equal(t, "tenant_456", page.Workspaces[0].TenantID)
+
+		page, err = adapter.ListAllWorkspaces(ctx, &domains.WorkspaceQuery{Deleted: true, DeletedBefore: deletedAt})
+		require.NoError(t, err)
+		require.Empty(t, page.Workspaces)
+
+		second.DeletedAt = time.Time{}
+		require.NoError(t, adapter.UpsertWorkspace(ctx, second))
+		stored, err = adapter.GetWorkspaceByTenantID(ctx, second.TenantID)
+		require.NoError(t, err)
+		require.False(t, stored.IsDeleted())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, adapter.DeleteWorkspaceByTenantID(ctx, "tenant_456"))
+		require.ErrorIs(t, adapter.DeleteWorkspaceByTenantID(ctx, "tenant_456"), domains.ErrWorkspaceNotFound)
+
+		page, err := adapter.ListAllWorkspaces(ctx, &domains.WorkspaceQuery{Labels: map[string]string{"team": "sales"}})
+		require.NoError(t, err)
+		require.Len(t, page.Workspaces, 1)
+	})
+}
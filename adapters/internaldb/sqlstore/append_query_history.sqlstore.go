@@ -0,0 +1,29 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *Store) AppendQueryHistory(ctx context.Context, entry *model.QueryHistoryEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			INSERT INTO query_history (tenant_id, prompt, query, warning, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+			entry.TenantID,
+			entry.Prompt,
+			entry.Query,
+			entry.Warning,
+			entry.CreatedAt,
+		).Scan(&entry.ID)
+	})
+}
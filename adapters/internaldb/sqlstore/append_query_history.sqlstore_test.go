@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_AppendQueryHistory(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO query_history`)
+
+	tests := []struct {
+		name        string
+		entry       *domains.QueryHistoryEntry
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectID    int64
+		expectError error
+	}{
+		{
+			name:  "success",
+			entry: &domains.QueryHistoryEntry{TenantID: "tenant_123", Prompt: "how many users?", Query: "SELECT COUNT(*) FROM users", CreatedAt: createdAt},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs("tenant_123", "how many users?", "SELECT COUNT(*) FROM users", "", createdAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectCommit()
+			},
+			expectID: 4,
+		},
+		{
+			name:  "error insert",
+			entry: &domains.QueryHistoryEntry{TenantID: "tenant_123"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.AppendQueryHistory(context.Background(), tt.entry)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectID, tt.entry.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *Store) AppendStatusEvent(ctx context.Context, event *model.StatusEvent) error {
+	progress, err := encodeProgress(event.Progress)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return insertStatusEvent(ctx, tx, event, progress)
+	})
+}
+
+// insertStatusEvent inserts the event, defaulting its CreatedAt to now and
+// writing back its ID.
+func insertStatusEvent(ctx context.Context, tx *sql.Tx, event *model.StatusEvent, progress any) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	event.CreatedAt = event.CreatedAt.UTC()
+
+	return tx.QueryRowContext(ctx, `
+		INSERT INTO workspace_status_events (tenant_id, type, message, progress, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		event.TenantID,
+		string(event.Type),
+		event.Message,
+		progress,
+		event.CreatedAt,
+	).Scan(&event.ID)
+}
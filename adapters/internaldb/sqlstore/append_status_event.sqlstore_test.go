@@ -0,0 +1,84 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_AppendStatusEvent(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO workspace_status_events`)
+
+	tests := []struct {
+		name        string
+		event       *domains.StatusEvent
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectID    int64
+		expectError error
+	}{
+		{
+			name:  "success transition",
+			event: &domains.StatusEvent{TenantID: "tenant_123", Type: domains.StatusEventError, Message: "embed failed", CreatedAt: createdAt},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs("tenant_123", "ERROR", "embed failed", nil, createdAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectCommit()
+			},
+			expectID: 7,
+		},
+		{
+			name: "success progress stored as json",
+			event: &domains.StatusEvent{
+				TenantID:  "tenant_123",
+				Type:      domains.StatusEventProgress,
+				Progress:  &domains.IngestionProgress{Phase: domains.IngestionPhaseEmbedding, Done: 1, Total: 2, StartedAt: createdAt},
+				CreatedAt: createdAt,
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs("tenant_123", "PROGRESS", "", `{"phase":"EMBEDDING","done":1,"total":2,"startedAt":"2026-01-01T00:00:00Z"}`, createdAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+				mock.ExpectCommit()
+			},
+			expectID: 8,
+		},
+		{
+			name:  "error insert",
+			event: &domains.StatusEvent{TenantID: "tenant_123", Type: domains.StatusEventDone},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.AppendStatusEvent(context.Background(), tt.event)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectID, tt.event.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// AppendStatusTransition appends the event and sets the status of the
+// tenant's workspace in the same transaction, touching no other column. A
+// tenant without a stored workspace only gets the event.
+func (s *Store) AppendStatusTransition(ctx context.Context, event *model.StatusEvent, status model.WorkspaceStatus) error {
+	progress, err := encodeProgress(event.Progress)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := insertStatusEvent(ctx, tx, event, progress); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+			UPDATE workspaces SET status = $1, updated_at = $2
+			WHERE tenant_id = $3 AND status <> $1`,
+			string(status),
+			event.CreatedAt,
+			event.TenantID,
+		)
+		return err
+	})
+}
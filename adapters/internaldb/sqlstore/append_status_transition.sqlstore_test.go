@@ -0,0 +1,79 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_AppendStatusTransition(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO workspace_status_events`)
+	updateQuery := regexp.QuoteMeta(`UPDATE workspaces SET status = $1, updated_at = $2`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectError error
+	}{
+		{
+			name: "success appends the event and sets the status only",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs("tenant_123", "ERROR", "embed failed", nil, createdAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec(updateQuery).
+					WithArgs("ERROR", createdAt, "tenant_123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "error insert",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error update rolls the event back",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec(updateQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			event := &domains.StatusEvent{TenantID: "tenant_123", Type: domains.StatusEventError, Message: "embed failed", CreatedAt: createdAt}
+			err := store.AppendStatusTransition(context.Background(), event, domains.StatusError)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, int64(7), event.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteDescription removes the description of a table, or of its column
+// when column is not empty. Deleting a missing description is not an error.
+func (s *Store) DeleteDescription(ctx context.Context, tenantID, table, column string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_descriptions WHERE tenant_id = $1 AND table_name = $2 AND column_name = $3`, tenantID, table, column)
+		return err
+	})
+}
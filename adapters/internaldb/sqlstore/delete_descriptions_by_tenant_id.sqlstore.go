@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteDescriptionsByTenantID removes every description of the tenant.
+// Deleting a tenant without descriptions is not an error.
+func (s *Store) DeleteDescriptionsByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_descriptions WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
@@ -0,0 +1,70 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_DeleteDescriptionsByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM schema_descriptions WHERE tenant_id = $1`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).
+					WithArgs(mockTenantID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "success without rows",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).
+					WithArgs(mockTenantID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "error exec",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).
+					WithArgs(mockTenantID).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.DeleteDescriptionsByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *Store) DeleteExample(ctx context.Context, tenantID string, id int64) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM examples WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return model.ErrExampleNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteExamplesByTenantID removes every example of the tenant. Deleting a
+// tenant without examples is not an error.
+func (s *Store) DeleteExamplesByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM examples WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
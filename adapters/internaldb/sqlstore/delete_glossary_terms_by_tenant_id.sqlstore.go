@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteGlossaryTermsByTenantID removes the tenant's whole glossary. Deleting
+// a tenant without a glossary is not an error.
+func (s *Store) DeleteGlossaryTermsByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM glossary_terms WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
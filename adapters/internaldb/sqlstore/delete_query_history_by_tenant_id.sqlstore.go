@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteQueryHistoryByTenantID removes every stored query of the tenant.
+// Deleting a tenant without history is not an error.
+func (s *Store) DeleteQueryHistoryByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM query_history WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteSchemaVersionsByTenantID removes every schema version of the tenant.
+// Deleting a tenant without versions is not an error.
+func (s *Store) DeleteSchemaVersionsByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_versions WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
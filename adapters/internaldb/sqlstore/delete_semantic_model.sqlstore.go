@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteSemanticModel removes the tenant's semantic model. Deleting a tenant
+// without one is not an error.
+func (s *Store) DeleteSemanticModel(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM semantic_models WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteStatusEventsByTenantID removes the tenant's whole status history.
+// Deleting a tenant without history is not an error.
+func (s *Store) DeleteStatusEventsByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM workspace_status_events WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
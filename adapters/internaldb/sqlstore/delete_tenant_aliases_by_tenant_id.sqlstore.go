@@ -0,0 +1,15 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DeleteTenantAliasesByTenantID removes every alias of the tenant. Deleting a
+// tenant without aliases is not an error.
+func (s *Store) DeleteTenantAliasesByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM tenant_aliases WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
@@ -0,0 +1,26 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *Store) DeleteWorkspaceByTenantID(ctx context.Context, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM workspaces WHERE tenant_id = $1`, tenantID)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return model.ErrWorkspaceNotFound
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM workspace_labels WHERE tenant_id = $1`, tenantID)
+		return err
+	})
+}
@@ -0,0 +1,35 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const descriptionColumns = `tenant_id, table_name, column_name, description, source, created_at, updated_at`
+
+func scanDescription(row rowScanner) (*model.Description, error) {
+	var (
+		description model.Description
+		source      string
+	)
+	if err := row.Scan(&description.TenantID, &description.Table, &description.Column, &description.Text, &source, &description.CreatedAt, &description.UpdatedAt); err != nil {
+		return nil, err
+	}
+	description.Source = model.DescriptionSource(source)
+	return &description, nil
+}
+
+func scanDescriptions(rows *sql.Rows) ([]*model.Description, error) {
+	defer rows.Close()
+
+	descriptions := []*model.Description{}
+	for rows.Next() {
+		description, err := scanDescription(rows)
+		if err != nil {
+			return nil, err
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions, rows.Err()
+}
@@ -0,0 +1,31 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const exampleColumns = `id, tenant_id, question, query, notes, created_at, updated_at`
+
+func scanExample(row rowScanner) (*model.Example, error) {
+	var example model.Example
+	if err := row.Scan(&example.ID, &example.TenantID, &example.Question, &example.Query, &example.Notes, &example.CreatedAt, &example.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &example, nil
+}
+
+func scanExamples(rows *sql.Rows) ([]*model.Example, error) {
+	defer rows.Close()
+
+	examples := []*model.Example{}
+	for rows.Next() {
+		example, err := scanExample(rows)
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, example)
+	}
+	return examples, rows.Err()
+}
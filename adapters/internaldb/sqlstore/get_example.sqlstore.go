@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetExample returns nil without an error when the tenant has no example with
+// that ID.
+func (s *Store) GetExample(ctx context.Context, tenantID string, id int64) (*model.Example, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	row := db.QueryRowContext(ctx, `SELECT `+exampleColumns+` FROM examples WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	example, err := scanExample(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return example, err
+}
@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetGlossaryTerm returns nil without an error when the tenant has no term
+// with that ID.
+func (s *Store) GetGlossaryTerm(ctx context.Context, tenantID string, id int64) (*model.GlossaryTerm, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	row := db.QueryRowContext(ctx, `SELECT `+glossaryTermColumns+` FROM glossary_terms WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	term, err := scanGlossaryTerm(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return term, err
+}
@@ -0,0 +1,71 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetGlossaryTerm(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	selectQuery := regexp.QuoteMeta(`FROM glossary_terms WHERE tenant_id = $1 AND id = $2`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.GlossaryTerm
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(1)).
+					WillReturnRows(sqlmock.NewRows(glossaryTermRowColumns).
+						AddRow(1, mockTenantID, "client", "customers table", `["customer"]`, createdAt, createdAt))
+			},
+			expectData: &domains.GlossaryTerm{ID: 1, TenantID: mockTenantID, Term: "client", Definition: "customers table", Synonyms: []string{"customer"}, CreatedAt: createdAt, UpdatedAt: createdAt},
+		},
+		{
+			name: "not found returns nil",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(1)).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetGlossaryTerm(context.Background(), mockTenantID, 1)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetLatestStatusEventByTenantID returns the newest event of one of the given
+// types, or nil when there is none.
+func (s *Store) GetLatestStatusEventByTenantID(ctx context.Context, tenantID string, eventTypes []model.StatusEventType) (*model.StatusEvent, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+	if len(eventTypes) == 0 {
+		return nil, nil
+	}
+
+	args := make([]any, 0, len(eventTypes)+1)
+	args = append(args, tenantID)
+	for _, eventType := range eventTypes {
+		args = append(args, string(eventType))
+	}
+
+	row := db.QueryRowContext(ctx, `SELECT `+statusEventColumns+` FROM workspace_status_events
+		WHERE tenant_id = $1 AND type IN (`+placeholders(2, len(eventTypes))+`)
+		ORDER BY id DESC LIMIT 1`, args...)
+	event, err := scanStatusEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return event, err
+}
@@ -0,0 +1,84 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetLatestStatusEventByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	eventTypes := []domains.StatusEventType{domains.StatusEventDone, domains.StatusEventError}
+	latestQuery := regexp.QuoteMeta(`WHERE tenant_id = $1 AND type IN ($2, $3)
+		ORDER BY id DESC LIMIT 1`)
+
+	tests := []struct {
+		name        string
+		eventTypes  []domains.StatusEventType
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.StatusEvent
+		expectError error
+	}{
+		{
+			name:       "success",
+			eventTypes: eventTypes,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(latestQuery).
+					WithArgs(mockTenantID, "DONE", "ERROR").
+					WillReturnRows(sqlmock.NewRows(statusEventRowColumns).
+						AddRow(5, mockTenantID, "DONE", "", nil, createdAt))
+			},
+			expectData: &domains.StatusEvent{ID: 5, TenantID: mockTenantID, Type: domains.StatusEventDone, CreatedAt: createdAt},
+		},
+		{
+			name:       "no matching event",
+			eventTypes: eventTypes,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(latestQuery).
+					WithArgs(mockTenantID, "DONE", "ERROR").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectData: nil,
+		},
+		{
+			name:        "no event types",
+			eventTypes:  nil,
+			prepareMock: func(mock sqlmock.Sqlmock) {},
+			expectData:  nil,
+		},
+		{
+			name:       "error query",
+			eventTypes: eventTypes,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(latestQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetLatestStatusEventByTenantID(context.Background(), mockTenantID, tt.eventTypes)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
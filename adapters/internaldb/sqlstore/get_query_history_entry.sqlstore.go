@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetQueryHistoryEntry returns nil without an error when the tenant has no
+// entry with that ID.
+func (s *Store) GetQueryHistoryEntry(ctx context.Context, tenantID string, id int64) (*model.QueryHistoryEntry, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	row := db.QueryRowContext(ctx, `SELECT `+queryHistoryColumns+` FROM query_history WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	entry, err := scanQueryHistoryEntry(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return entry, err
+}
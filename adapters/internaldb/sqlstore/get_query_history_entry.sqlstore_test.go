@@ -0,0 +1,71 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetQueryHistoryEntry(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	selectQuery := regexp.QuoteMeta(`FROM query_history WHERE tenant_id = $1 AND id = $2`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.QueryHistoryEntry
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(4)).
+					WillReturnRows(sqlmock.NewRows(queryHistoryRowColumns).
+						AddRow(4, mockTenantID, "How many orders?", "SELECT count(*) FROM orders", "", createdAt))
+			},
+			expectData: &domains.QueryHistoryEntry{ID: 4, TenantID: mockTenantID, Prompt: "How many orders?", Query: "SELECT count(*) FROM orders", CreatedAt: createdAt},
+		},
+		{
+			name: "not found returns nil",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(4)).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetQueryHistoryEntry(context.Background(), mockTenantID, 4)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
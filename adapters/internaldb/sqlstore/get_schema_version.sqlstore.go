@@ -0,0 +1,36 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetSchemaVersion returns nil without an error when the tenant has no such
+// version.
+func (s *Store) GetSchemaVersion(ctx context.Context, tenantID string, version int64) (*model.SchemaVersion, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	var (
+		schemaVersion = model.SchemaVersion{TenantID: tenantID, Version: version}
+		encoded       string
+	)
+	err := db.QueryRowContext(ctx, `SELECT checksum, metadata, created_at FROM schema_versions WHERE tenant_id = $1 AND version = $2`, tenantID, version).
+		Scan(&schemaVersion.Checksum, &encoded, &schemaVersion.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(encoded), &schemaVersion.Metadata); err != nil {
+		return nil, err
+	}
+	return &schemaVersion, nil
+}
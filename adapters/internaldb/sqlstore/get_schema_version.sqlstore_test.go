@@ -0,0 +1,90 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetSchemaVersion(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	selectQuery := regexp.QuoteMeta(`SELECT checksum, metadata, created_at FROM schema_versions WHERE tenant_id = $1 AND version = $2`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.SchemaVersion
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"checksum", "metadata", "created_at"}).
+						AddRow("checksum_abc", `{"TenantID":"tenant_123","Tables":[{"Name":"orders","Columns":[{"Name":"id","Type":"INT"}]}],"Checksum":"checksum_abc"}`, createdAt))
+			},
+			expectData: &domains.SchemaVersion{
+				TenantID: mockTenantID,
+				Version:  2,
+				Checksum: "checksum_abc",
+				Metadata: &domains.DatabaseMetadata{
+					TenantID: mockTenantID,
+					Tables:   []domains.Table{{Name: "orders", Columns: []domains.Column{{Name: "id", Type: "INT"}}}},
+					Checksum: "checksum_abc",
+				},
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "not found returns nil",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(2)).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error invalid metadata",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"checksum", "metadata", "created_at"}).AddRow("checksum_abc", `not json`, createdAt))
+			},
+			expectError: errors.New("invalid character 'o' in literal null (expecting 'u')"),
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetSchemaVersion(context.Background(), mockTenantID, 2)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetSemanticModel returns nil without an error when the tenant has no
+// semantic model.
+func (s *Store) GetSemanticModel(ctx context.Context, tenantID string) (*model.SemanticModel, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	var (
+		semanticModel = model.SemanticModel{TenantID: tenantID}
+		definition    string
+	)
+	err := db.QueryRowContext(ctx, `SELECT definition, updated_at FROM semantic_models WHERE tenant_id = $1`, tenantID).
+		Scan(&definition, &semanticModel.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(definition), &semanticModel); err != nil {
+		return nil, err
+	}
+	return &semanticModel, nil
+}
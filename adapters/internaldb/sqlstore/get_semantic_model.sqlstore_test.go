@@ -0,0 +1,84 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetSemanticModel(t *testing.T) {
+	mockTenantID := "tenant_123"
+	updatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	selectQuery := regexp.QuoteMeta(`SELECT definition, updated_at FROM semantic_models WHERE tenant_id = $1`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.SemanticModel
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows([]string{"definition", "updated_at"}).
+						AddRow(`{"metrics":[{"name":"revenue","expression":"SUM(orders.total)","table":"orders"}]}`, updatedAt))
+			},
+			expectData: &domains.SemanticModel{
+				TenantID:  mockTenantID,
+				Metrics:   []domains.Metric{{Name: "revenue", Expression: "SUM(orders.total)", Table: "orders"}},
+				UpdatedAt: updatedAt,
+			},
+		},
+		{
+			name: "not found returns nil",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error invalid definition",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows([]string{"definition", "updated_at"}).AddRow(`not json`, updatedAt))
+			},
+			expectError: errors.New("invalid character 'o' in literal null (expecting 'u')"),
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetSemanticModel(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
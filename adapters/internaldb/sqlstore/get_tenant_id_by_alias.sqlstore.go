@@ -0,0 +1,26 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// GetTenantIDByAlias returns an empty string without an error when alias
+// points to no tenant.
+func (s *Store) GetTenantIDByAlias(ctx context.Context, alias string) (string, error) {
+	db := s.conn()
+	if db == nil {
+		return "", ErrNotConnected
+	}
+
+	var tenantID string
+	err := db.QueryRowContext(ctx, `SELECT tenant_id FROM tenant_aliases WHERE alias = $1`, alias).Scan(&tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return tenantID, nil
+}
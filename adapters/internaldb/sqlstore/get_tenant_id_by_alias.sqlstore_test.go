@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetTenantIDByAlias(t *testing.T) {
+	mockAlias := "tenant_rotated"
+	selectQuery := regexp.QuoteMeta(`SELECT tenant_id FROM tenant_aliases WHERE alias = $1`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  string
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockAlias).
+					WillReturnRows(sqlmock.NewRows([]string{"tenant_id"}).AddRow("tenant_123"))
+			},
+			expectData: "tenant_123",
+		},
+		{
+			name: "not found returns empty",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockAlias).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetTenantIDByAlias(context.Background(), mockAlias)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
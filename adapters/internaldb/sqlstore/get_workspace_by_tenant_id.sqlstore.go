@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// GetWorkspaceByTenantID returns nil without an error when the tenant has no
+// workspace yet, which is how the services tell a first sync apart.
+func (s *Store) GetWorkspaceByTenantID(ctx context.Context, tenantID string) (*model.Workspace, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	row := db.QueryRowContext(ctx, `SELECT `+workspaceColumns+` FROM workspaces WHERE tenant_id = $1`, tenantID)
+	workspace, err := scanWorkspace(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return workspace, err
+}
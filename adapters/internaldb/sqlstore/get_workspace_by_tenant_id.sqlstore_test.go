@@ -0,0 +1,80 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetWorkspaceByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.Workspace
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE tenant_id = $1`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(workspaceRowColumns).
+						AddRow(mockTenantID, "enc_1", "DONE", "checksum_abc", nil, 1, 0, nil, "", "", nil, nil, createdAt, createdAt, nil))
+			},
+			expectData: &domains.Workspace{
+				TenantID:       mockTenantID,
+				EncryptedDBURL: "enc_1",
+				Status:         domains.StatusDone,
+				Checksum:       "checksum_abc",
+				ActiveVersion:  1,
+				CreatedAt:      createdAt,
+				UpdatedAt:      createdAt,
+			},
+		},
+		{
+			name: "not found returns nil",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE tenant_id = $1`)).
+					WithArgs(mockTenantID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectData: nil,
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE tenant_id = $1`)).
+					WithArgs(mockTenantID).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.GetWorkspaceByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const glossaryTermColumns = `id, tenant_id, term, definition, synonyms, created_at, updated_at`
+
+func scanGlossaryTerm(row rowScanner) (*model.GlossaryTerm, error) {
+	var (
+		term     model.GlossaryTerm
+		synonyms string
+	)
+	if err := row.Scan(&term.ID, &term.TenantID, &term.Term, &term.Definition, &synonyms, &term.CreatedAt, &term.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(synonyms), &term.Synonyms); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func scanGlossaryTerms(rows *sql.Rows) ([]*model.GlossaryTerm, error) {
+	defer rows.Close()
+
+	terms := []*model.GlossaryTerm{}
+	for rows.Next() {
+		term, err := scanGlossaryTerm(rows)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, rows.Err()
+}
+
+func encodeSynonyms(synonyms []string) (string, error) {
+	if synonyms == nil {
+		synonyms = []string{}
+	}
+	encoded, err := json.Marshal(synonyms)
+	return string(encoded), err
+}
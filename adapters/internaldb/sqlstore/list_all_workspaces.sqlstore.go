@@ -0,0 +1,132 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// workspaceSortColumns maps the sort fields to their column. ORDER BY takes no
+// placeholder, so only these names are ever written into the statement.
+var workspaceSortColumns = map[model.WorkspaceSortField]string{
+	model.WorkspaceSortCreatedAt: "created_at",
+	model.WorkspaceSortUpdatedAt: "updated_at",
+	model.WorkspaceSortName:      "name",
+	model.WorkspaceSortTenantID:  "tenant_id",
+}
+
+// ListAllWorkspaces pages with the sort key of the last workspace rather than
+// an offset, so a page does not skip or repeat workspaces written meanwhile.
+func (s *Store) ListAllWorkspaces(ctx context.Context, query *model.WorkspaceQuery) (*model.WorkspacePage, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	cursor, err := query.DecodeCursor()
+	if err != nil {
+		return nil, err
+	}
+	if query == nil {
+		query = &model.WorkspaceQuery{}
+	}
+	sortBy, ok := workspaceSortColumns[query.Sort()]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort field %q", query.SortBy)
+	}
+
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if !query.Deleted {
+		conditions = append(conditions, `deleted_at IS NULL`)
+	} else if query.DeletedBefore.IsZero() {
+		conditions = append(conditions, `deleted_at IS NOT NULL`)
+	} else {
+		conditions = append(conditions, `deleted_at < `+arg(query.DeletedBefore.UTC()))
+	}
+
+	if len(query.Statuses) > 0 {
+		placeholders := make([]string, len(query.Statuses))
+		for i, status := range query.Statuses {
+			placeholders[i] = arg(string(status))
+		}
+		conditions = append(conditions, `status IN (`+strings.Join(placeholders, ", ")+`)`)
+	}
+	keys := make([]string, 0, len(query.Labels))
+	for key := range query.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conditions = append(conditions, `tenant_id IN (SELECT tenant_id FROM workspace_labels WHERE key = `+arg(key)+` AND value = `+arg(query.Labels[key])+`)`)
+	}
+	if query.Search != "" {
+		pattern := "%" + escapeLike(strings.ToLower(query.Search)) + "%"
+		conditions = append(conditions, `(LOWER(name) LIKE `+arg(pattern)+` ESCAPE '\' OR LOWER(tenant_id) LIKE `+arg(pattern)+` ESCAPE '\')`)
+	}
+	if !query.CreatedSince.IsZero() {
+		conditions = append(conditions, `created_at >= `+arg(query.CreatedSince.UTC()))
+	}
+	if !query.CreatedBefore.IsZero() {
+		conditions = append(conditions, `created_at < `+arg(query.CreatedBefore.UTC()))
+	}
+	if !query.UpdatedSince.IsZero() {
+		conditions = append(conditions, `updated_at >= `+arg(query.UpdatedSince.UTC()))
+	}
+	if !query.UpdatedBefore.IsZero() {
+		conditions = append(conditions, `updated_at < `+arg(query.UpdatedBefore.UTC()))
+	}
+
+	direction, comparison := "", ">"
+	if query.Descending {
+		direction, comparison = " DESC", "<"
+	}
+	orderBy := sortBy + direction
+	if query.Sort() != model.WorkspaceSortTenantID {
+		orderBy += `, tenant_id` + direction
+	}
+	if cursor != nil {
+		if cursor.SortBy == model.WorkspaceSortTenantID {
+			conditions = append(conditions, `tenant_id `+comparison+` `+arg(cursor.TenantID))
+		} else {
+			conditions = append(conditions, `(`+sortBy+`, tenant_id) `+comparison+` (`+arg(cursor.Value)+`, `+arg(cursor.TenantID)+`)`)
+		}
+	}
+
+	statement := `SELECT ` + workspaceColumns + ` FROM workspaces WHERE ` + strings.Join(conditions, ` AND `)
+	// One more row than the page tells whether there is a next page
+	limit := query.PageSize()
+	statement += ` ORDER BY ` + orderBy + ` LIMIT ` + arg(limit+1)
+
+	rows, err := db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	workspaces, err := scanWorkspaces(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.WorkspacePage{Workspaces: workspaces}
+	if len(workspaces) > limit {
+		page.Workspaces = workspaces[:limit]
+		page.NextCursor = query.NextCursor(workspaces[limit-1])
+	}
+	return page, nil
+}
+
+// escapeLike escapes the LIKE wildcards of a search term.
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
@@ -0,0 +1,136 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var workspaceRowColumns = []string{"tenant_id", "encrypted_db_url", "status", "checksum", "table_checksums", "active_version", "pinned_version", "sync_options", "sync_schedule", "name", "labels", "data_sources", "created_at", "updated_at", "deleted_at"}
+
+func TestStore_ListAllWorkspaces(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	secondPage := (&domains.WorkspaceQuery{SortBy: domains.WorkspaceSortUpdatedAt, Descending: true}).
+		NextCursor(&domains.Workspace{TenantID: "tenant_123", UpdatedAt: updatedAt})
+
+	tests := []struct {
+		name        string
+		query       *domains.WorkspaceQuery
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  *domains.WorkspacePage
+		expectError error
+	}{
+		{
+			name: "success first page ordered by creation",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE deleted_at IS NULL ORDER BY created_at, tenant_id LIMIT $1`)).
+					WithArgs(domains.DefaultWorkspacePageSize + 1).
+					WillReturnRows(sqlmock.NewRows(workspaceRowColumns).
+						AddRow("tenant_123", "enc_1", "DONE", "checksum_abc", []byte(`{"orders":"o1"}`), 2, 1, []byte(`{"sensitive_columns":["*password*"]}`), "*/30 * * * *", "", nil, nil, createdAt, updatedAt, nil).
+						AddRow("sales", "", "DONE", "", nil, 0, 0, nil, "", "Sales", []byte(`{"team":"sales"}`), []byte(`[{"name":"oltp","encrypted_db_url":"enc_2"}]`), updatedAt, updatedAt, nil))
+			},
+			expectData: &domains.WorkspacePage{
+				Workspaces: []*domains.Workspace{
+					{TenantID: "tenant_123", EncryptedDBURL: "enc_1", Status: domains.StatusDone, Checksum: "checksum_abc", TableChecksums: map[string]string{"orders": "o1"}, ActiveVersion: 2, PinnedVersion: 1, SyncOptions: &domains.SyncOptions{SensitiveColumns: []string{"*password*"}}, SyncSchedule: "*/30 * * * *", CreatedAt: createdAt, UpdatedAt: updatedAt},
+					{TenantID: "sales", Status: domains.StatusDone, Name: "Sales", Labels: map[string]string{"team": "sales"}, DataSources: []domains.DataSource{{Name: "oltp", EncryptedDBURL: "enc_2"}}, CreatedAt: updatedAt, UpdatedAt: updatedAt},
+				},
+			},
+		},
+		{
+			name: "success filtered with a next page",
+			query: &domains.WorkspaceQuery{
+				Statuses:      []domains.WorkspaceStatus{domains.StatusDone, domains.StatusWarn},
+				Labels:        map[string]string{"team": "sales", "env": "prod"},
+				Search:        "Sal_",
+				CreatedSince:  createdAt,
+				UpdatedBefore: updatedAt,
+				SortBy:        domains.WorkspaceSortName,
+				Limit:         1,
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE deleted_at IS NULL AND status IN ($1, $2)`+
+					` AND tenant_id IN (SELECT tenant_id FROM workspace_labels WHERE key = $3 AND value = $4)`+
+					` AND tenant_id IN (SELECT tenant_id FROM workspace_labels WHERE key = $5 AND value = $6)`+
+					` AND (LOWER(name) LIKE $7 ESCAPE '\' OR LOWER(tenant_id) LIKE $8 ESCAPE '\')`+
+					` AND created_at >= $9 AND updated_at < $10`+
+					` ORDER BY name, tenant_id LIMIT $11`)).
+					WithArgs("DONE", "WARN", "env", "prod", "team", "sales", `%sal\_%`, `%sal\_%`, createdAt, updatedAt, 2).
+					WillReturnRows(sqlmock.NewRows(workspaceRowColumns).
+						AddRow("sales", "", "DONE", "", nil, 0, 0, nil, "", "Sales", nil, nil, createdAt, createdAt, nil).
+						AddRow("sales_eu", "", "DONE", "", nil, 0, 0, nil, "", "Sales EU", nil, nil, createdAt, createdAt, nil))
+			},
+			expectData: &domains.WorkspacePage{
+				Workspaces: []*domains.Workspace{{TenantID: "sales", Status: domains.StatusDone, Name: "Sales", CreatedAt: createdAt, UpdatedAt: createdAt}},
+				NextCursor: (&domains.WorkspaceQuery{SortBy: domains.WorkspaceSortName}).NextCursor(&domains.Workspace{TenantID: "sales", Name: "Sales"}),
+			},
+		},
+		{
+			name:  "success next page after the cursor",
+			query: &domains.WorkspaceQuery{SortBy: domains.WorkspaceSortUpdatedAt, Descending: true, Cursor: secondPage},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE deleted_at IS NULL AND (updated_at, tenant_id) < ($1, $2) ORDER BY updated_at DESC, tenant_id DESC LIMIT $3`)).
+					WithArgs(updatedAt, "tenant_123", domains.DefaultWorkspacePageSize+1).
+					WillReturnRows(sqlmock.NewRows(workspaceRowColumns))
+			},
+			expectData: &domains.WorkspacePage{Workspaces: []*domains.Workspace{}},
+		},
+		{
+			name:  "success deleted before sorted by tenant ID",
+			query: &domains.WorkspaceQuery{Deleted: true, DeletedBefore: updatedAt, SortBy: domains.WorkspaceSortTenantID, Cursor: (&domains.WorkspaceQuery{SortBy: domains.WorkspaceSortTenantID}).NextCursor(&domains.Workspace{TenantID: "tenant_123"})},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces WHERE deleted_at < $1 AND tenant_id > $2 ORDER BY tenant_id LIMIT $3`)).
+					WithArgs(updatedAt, "tenant_123", domains.DefaultWorkspacePageSize+1).
+					WillReturnRows(sqlmock.NewRows(workspaceRowColumns).
+						AddRow("tenant_456", "enc_2", "DONE", "", nil, 0, 0, nil, "", "", nil, nil, createdAt, createdAt, createdAt))
+			},
+			expectData: &domains.WorkspacePage{Workspaces: []*domains.Workspace{
+				{TenantID: "tenant_456", EncryptedDBURL: "enc_2", Status: domains.StatusDone, CreatedAt: createdAt, UpdatedAt: createdAt, DeletedAt: createdAt},
+			}},
+		},
+		{
+			name:        "error invalid cursor",
+			query:       &domains.WorkspaceQuery{Cursor: "not a cursor"},
+			prepareMock: func(mock sqlmock.Sqlmock) {},
+			expectError: domains.ErrInvalidWorkspaceCursor,
+		},
+		{
+			name:        "error sort field outside the whitelist",
+			query:       &domains.WorkspaceQuery{SortBy: "created_at; DROP TABLE workspaces"},
+			prepareMock: func(mock sqlmock.Sqlmock) {},
+			expectError: fmt.Errorf("invalid sort field %q", "created_at; DROP TABLE workspaces"),
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspaces`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListAllWorkspaces(context.Background(), tt.query)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package sqlstore
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ListDescriptionsByTenantID returns the tenant's descriptions ordered by
+// table, with each table's own description before its columns'.
+func (s *Store) ListDescriptionsByTenantID(ctx context.Context, tenantID string) ([]*model.Description, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+descriptionColumns+` FROM schema_descriptions WHERE tenant_id = $1 ORDER BY table_name, column_name`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanDescriptions(rows)
+}
@@ -0,0 +1,76 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var descriptionRowColumns = []string{"tenant_id", "table_name", "column_name", "description", "source", "created_at", "updated_at"}
+
+func TestStore_ListDescriptionsByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []*domains.Description
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM schema_descriptions WHERE tenant_id = $1 ORDER BY table_name, column_name`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(descriptionRowColumns).
+						AddRow(mockTenantID, "customers", "", "Registered customers", "USER", createdAt, createdAt).
+						AddRow(mockTenantID, "customers", "city", "City of residence", "INFERRED", createdAt, createdAt))
+			},
+			expectData: []*domains.Description{
+				{TenantID: mockTenantID, Table: "customers", Text: "Registered customers", Source: domains.DescriptionSourceUser, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{TenantID: mockTenantID, Table: "customers", Column: "city", Text: "City of residence", Source: domains.DescriptionSourceInferred, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+		{
+			name: "success empty",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM schema_descriptions`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(descriptionRowColumns))
+			},
+			expectData: []*domains.Description{},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM schema_descriptions`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListDescriptionsByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sqlstore
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ListExamplesByTenantID returns the tenant's examples oldest first.
+func (s *Store) ListExamplesByTenantID(ctx context.Context, tenantID string) ([]*model.Example, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+exampleColumns+` FROM examples WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanExamples(rows)
+}
@@ -0,0 +1,76 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleRowColumns = []string{"id", "tenant_id", "question", "query", "notes", "created_at", "updated_at"}
+
+func TestStore_ListExamplesByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []*domains.Example
+		expectError error
+	}{
+		{
+			name: "success oldest first",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM examples WHERE tenant_id = $1 ORDER BY id`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(exampleRowColumns).
+						AddRow(1, mockTenantID, "How many orders?", "SELECT count(*) FROM orders", "", createdAt, createdAt).
+						AddRow(2, mockTenantID, "Top clients", "SELECT name FROM customers LIMIT 10", "by revenue", createdAt, createdAt))
+			},
+			expectData: []*domains.Example{
+				{ID: 1, TenantID: mockTenantID, Question: "How many orders?", Query: "SELECT count(*) FROM orders", CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: 2, TenantID: mockTenantID, Question: "Top clients", Query: "SELECT name FROM customers LIMIT 10", Notes: "by revenue", CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+		{
+			name: "success empty",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM examples`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(exampleRowColumns))
+			},
+			expectData: []*domains.Example{},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM examples`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListExamplesByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sqlstore
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ListGlossaryTermsByTenantID returns the tenant's glossary oldest first.
+func (s *Store) ListGlossaryTermsByTenantID(ctx context.Context, tenantID string) ([]*model.GlossaryTerm, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+glossaryTermColumns+` FROM glossary_terms WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanGlossaryTerms(rows)
+}
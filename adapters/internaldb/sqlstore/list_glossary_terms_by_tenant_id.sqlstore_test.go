@@ -0,0 +1,77 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var glossaryTermRowColumns = []string{"id", "tenant_id", "term", "definition", "synonyms", "created_at", "updated_at"}
+
+func TestStore_ListGlossaryTermsByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []*domains.GlossaryTerm
+		expectError error
+	}{
+		{
+			name: "success oldest first",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM glossary_terms WHERE tenant_id = $1 ORDER BY id`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(glossaryTermRowColumns).
+						AddRow(1, mockTenantID, "GMV", "sum(order_items.price*qty) excluding refunds", `["gross merchandise value"]`, createdAt, createdAt).
+						AddRow(2, mockTenantID, "client", "customers table", `[]`, createdAt, createdAt))
+			},
+			expectData: []*domains.GlossaryTerm{
+				{ID: 1, TenantID: mockTenantID, Term: "GMV", Definition: "sum(order_items.price*qty) excluding refunds", Synonyms: []string{"gross merchandise value"}, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: 2, TenantID: mockTenantID, Term: "client", Definition: "customers table", Synonyms: []string{}, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+		{
+			name: "error invalid synonyms",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM glossary_terms`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(glossaryTermRowColumns).
+						AddRow(1, mockTenantID, "GMV", "revenue", `not json`, createdAt, createdAt))
+			},
+			expectError: errors.New("invalid character 'o' in literal null (expecting 'u')"),
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM glossary_terms`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListGlossaryTermsByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sqlstore
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ListQueryHistoryByTenantID returns the tenant's generated queries oldest first.
+func (s *Store) ListQueryHistoryByTenantID(ctx context.Context, tenantID string) ([]*model.QueryHistoryEntry, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+queryHistoryColumns+` FROM query_history WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanQueryHistoryEntries(rows)
+}
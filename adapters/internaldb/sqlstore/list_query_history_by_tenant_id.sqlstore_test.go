@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var queryHistoryRowColumns = []string{"id", "tenant_id", "prompt", "query", "warning", "created_at"}
+
+func TestStore_ListQueryHistoryByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []*domains.QueryHistoryEntry
+		expectError error
+	}{
+		{
+			name: "success oldest first",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM query_history WHERE tenant_id = $1 ORDER BY id`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(queryHistoryRowColumns).
+						AddRow(1, mockTenantID, "how many users?", "SELECT COUNT(*) FROM users", "", createdAt).
+						AddRow(2, mockTenantID, "drop users", "DROP TABLE users", "DDL or DML statement detected. Query won't be executed.", createdAt))
+			},
+			expectData: []*domains.QueryHistoryEntry{
+				{ID: 1, TenantID: mockTenantID, Prompt: "how many users?", Query: "SELECT COUNT(*) FROM users", CreatedAt: createdAt},
+				{ID: 2, TenantID: mockTenantID, Prompt: "drop users", Query: "DROP TABLE users", Warning: "DDL or DML statement detected. Query won't be executed.", CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM query_history`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListQueryHistoryByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package sqlstore
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ListSchemaVersionsByTenantID returns the tenant's versions oldest first,
+// without their metadata.
+func (s *Store) ListSchemaVersionsByTenantID(ctx context.Context, tenantID string) ([]*model.SchemaVersion, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+schemaVersionColumns+` FROM schema_versions WHERE tenant_id = $1 ORDER BY version`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanSchemaVersions(rows)
+}
@@ -0,0 +1,76 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var schemaVersionRowColumns = []string{"tenant_id", "version", "checksum", "created_at"}
+
+func TestStore_ListSchemaVersionsByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []*domains.SchemaVersion
+		expectError error
+	}{
+		{
+			name: "success oldest first",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM schema_versions WHERE tenant_id = $1 ORDER BY version`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(schemaVersionRowColumns).
+						AddRow(mockTenantID, 1, "checksum_abc", createdAt).
+						AddRow(mockTenantID, 2, "checksum_def", createdAt))
+			},
+			expectData: []*domains.SchemaVersion{
+				{TenantID: mockTenantID, Version: 1, Checksum: "checksum_abc", CreatedAt: createdAt},
+				{TenantID: mockTenantID, Version: 2, Checksum: "checksum_def", CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "success empty",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM schema_versions`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(schemaVersionRowColumns))
+			},
+			expectData: []*domains.SchemaVersion{},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM schema_versions`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListSchemaVersionsByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sqlstore
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ListStatusEventsByTenantID returns the tenant's status history oldest first.
+func (s *Store) ListStatusEventsByTenantID(ctx context.Context, tenantID string) ([]*model.StatusEvent, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+statusEventColumns+` FROM workspace_status_events WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanStatusEvents(rows)
+}
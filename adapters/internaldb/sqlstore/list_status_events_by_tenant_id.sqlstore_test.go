@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+var statusEventRowColumns = []string{"id", "tenant_id", "type", "message", "progress", "created_at"}
+
+func TestStore_ListStatusEventsByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []*domains.StatusEvent
+		expectError error
+	}{
+		{
+			name: "success oldest first",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspace_status_events WHERE tenant_id = $1 ORDER BY id`)).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows(statusEventRowColumns).
+						AddRow(1, mockTenantID, "IN_PROGRESS", "", nil, createdAt).
+						AddRow(2, mockTenantID, "PROGRESS", "", []byte(`{"phase":"UPSERTING","done":2,"total":4}`), createdAt).
+						AddRow(3, mockTenantID, "ERROR", "upsert failed", nil, createdAt))
+			},
+			expectData: []*domains.StatusEvent{
+				{ID: 1, TenantID: mockTenantID, Type: domains.StatusEventInProgress, CreatedAt: createdAt},
+				{ID: 2, TenantID: mockTenantID, Type: domains.StatusEventProgress, Progress: &domains.IngestionProgress{Phase: domains.IngestionPhaseUpserting, Done: 2, Total: 4}, CreatedAt: createdAt},
+				{ID: 3, TenantID: mockTenantID, Type: domains.StatusEventError, Message: "upsert failed", CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM workspace_status_events`)).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListStatusEventsByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
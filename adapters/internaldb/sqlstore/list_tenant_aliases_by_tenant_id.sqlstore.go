@@ -0,0 +1,28 @@
+package sqlstore
+
+import "context"
+
+// ListTenantAliasesByTenantID returns the aliases pointing to the tenant,
+// sorted.
+func (s *Store) ListTenantAliasesByTenantID(ctx context.Context, tenantID string) ([]string, error) {
+	db := s.conn()
+	if db == nil {
+		return nil, ErrNotConnected
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT alias FROM tenant_aliases WHERE tenant_id = $1 ORDER BY alias`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, rows.Err()
+}
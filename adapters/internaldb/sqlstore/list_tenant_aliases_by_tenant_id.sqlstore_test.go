@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_ListTenantAliasesByTenantID(t *testing.T) {
+	mockTenantID := "tenant_123"
+	selectQuery := regexp.QuoteMeta(`SELECT alias FROM tenant_aliases WHERE tenant_id = $1 ORDER BY alias`)
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectData  []string
+		expectError error
+	}{
+		{
+			name: "success sorted",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows([]string{"alias"}).AddRow("tenant_a").AddRow("tenant_b"))
+			},
+			expectData: []string{"tenant_a", "tenant_b"},
+		},
+		{
+			name: "success empty",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows([]string{"alias"}))
+			},
+			expectData: []string{},
+		},
+		{
+			name: "error query",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			result, err := store.ListTenantAliasesByTenantID(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
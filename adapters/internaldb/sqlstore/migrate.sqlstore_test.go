@@ -0,0 +1,98 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("ordered by version", func(t *testing.T) {
+		migrations, err := LoadMigrations(fstest.MapFS{
+			"0002_second.sql": {Data: []byte("CREATE TABLE b (id INT);")},
+			"0001_first.sql":  {Data: []byte("CREATE TABLE a (id INT);")},
+			"README.md":       {Data: []byte("ignored")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []Migration{
+			{Version: 1, Name: "0001_first", SQL: "CREATE TABLE a (id INT);"},
+			{Version: 2, Name: "0002_second", SQL: "CREATE TABLE b (id INT);"},
+		}, migrations)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := LoadMigrations(fstest.MapFS{
+			"first.sql": {Data: []byte("SELECT 1;")},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "0001_first", SQL: "CREATE TABLE a (id INT);"},
+		{Version: 2, Name: "0002_second", SQL: "CREATE TABLE b (id INT);"},
+	}
+
+	expectSchemaTable := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	expectCheck := func(mock sqlmock.Sqlmock, version int, applied bool) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`LOCK`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`)).
+			WithArgs(version).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(applied))
+	}
+	lock := func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `LOCK`)
+		return err
+	}
+
+	t.Run("applies pending migrations in order", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		expectSchemaTable(mock)
+		for _, m := range migrations {
+			expectCheck(mock, m.Version, false)
+			mock.ExpectExec(regexp.QuoteMeta(m.SQL)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`)).
+				WithArgs(m.Version, m.Name, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+		}
+
+		require.NoError(t, Migrate(context.Background(), store.db, migrations, lock))
+	})
+
+	t.Run("skips applied migrations", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		expectSchemaTable(mock)
+		for _, m := range migrations {
+			expectCheck(mock, m.Version, true)
+			mock.ExpectCommit()
+		}
+
+		require.NoError(t, Migrate(context.Background(), store.db, migrations, lock))
+	})
+
+	t.Run("stops at a failing migration", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		expectSchemaTable(mock)
+		expectCheck(mock, migrations[0].Version, false)
+		mock.ExpectExec(regexp.QuoteMeta(migrations[0].SQL)).
+			WillReturnError(errors.New("syntax error"))
+		mock.ExpectRollback()
+
+		err := Migrate(context.Background(), store.db, migrations, lock)
+		require.ErrorContains(t, err, migrations[0].Name)
+	})
+}
@@ -0,0 +1,31 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const queryHistoryColumns = `id, tenant_id, prompt, query, warning, created_at`
+
+func scanQueryHistoryEntry(row rowScanner) (*model.QueryHistoryEntry, error) {
+	var entry model.QueryHistoryEntry
+	if err := row.Scan(&entry.ID, &entry.TenantID, &entry.Prompt, &entry.Query, &entry.Warning, &entry.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func scanQueryHistoryEntries(rows *sql.Rows) ([]*model.QueryHistoryEntry, error) {
+	defer rows.Close()
+
+	entries := []*model.QueryHistoryEntry{}
+	for rows.Next() {
+		entry, err := scanQueryHistoryEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
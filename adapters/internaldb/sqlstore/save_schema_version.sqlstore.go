@@ -0,0 +1,61 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// SaveSchemaVersion returns the version already stored for the checksum, or
+// inserts metadata as the version after the tenant's latest one.
+func (s *Store) SaveSchemaVersion(ctx context.Context, metadata *model.DatabaseMetadata) (*model.SchemaVersion, error) {
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var version *model.SchemaVersion
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `SELECT `+schemaVersionColumns+` FROM schema_versions WHERE tenant_id = $1 AND checksum = $2`,
+			metadata.TenantID,
+			metadata.Checksum,
+		)
+		existing, err := scanSchemaVersion(row)
+		if err == nil {
+			version = existing
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		version = &model.SchemaVersion{
+			TenantID:  metadata.TenantID,
+			Checksum:  metadata.Checksum,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) + 1 FROM schema_versions WHERE tenant_id = $1`, metadata.TenantID).
+			Scan(&version.Version); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO schema_versions (tenant_id, version, checksum, metadata, created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			version.TenantID,
+			version.Version,
+			version.Checksum,
+			string(encoded),
+			version.CreatedAt,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
@@ -0,0 +1,112 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SaveSchemaVersion(t *testing.T) {
+	mockTenantID := "tenant_123"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	selectQuery := regexp.QuoteMeta(`FROM schema_versions WHERE tenant_id = $1 AND checksum = $2`)
+	nextQuery := regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) + 1 FROM schema_versions WHERE tenant_id = $1`)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO schema_versions`)
+	mockMetadata := &domains.DatabaseMetadata{
+		TenantID: mockTenantID,
+		Tables:   []domains.Table{{Name: "orders"}},
+		Checksum: "checksum_abc",
+	}
+
+	tests := []struct {
+		name          string
+		prepareMock   func(mock sqlmock.Sqlmock)
+		expectVersion int64
+		expectError   error
+	}{
+		{
+			name: "success new version",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, "checksum_abc").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(nextQuery).
+					WithArgs(mockTenantID).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+				mock.ExpectExec(insertQuery).
+					WithArgs(
+						mockTenantID,
+						int64(3),
+						"checksum_abc",
+						`{"TenantID":"tenant_123","Tables":[{"Name":"orders","Columns":null,"Indexes":null,"Constraints":null,"Comments":"","Description":""}],"Relations":null,"Checksum":"checksum_abc"}`,
+						sqlmock.AnyArg(),
+					).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectVersion: 3,
+		},
+		{
+			name: "success existing checksum is not stored again",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs(mockTenantID, "checksum_abc").
+					WillReturnRows(sqlmock.NewRows(schemaVersionRowColumns).AddRow(mockTenantID, 1, "checksum_abc", createdAt))
+				mock.ExpectCommit()
+			},
+			expectVersion: 1,
+		},
+		{
+			name: "error select",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error insert",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(nextQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+				mock.ExpectExec(insertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			version, err := store.SaveSchemaVersion(context.Background(), mockMetadata)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectVersion, version.Version)
+				require.Equal(t, "checksum_abc", version.Checksum)
+				require.Nil(t, version.Metadata)
+			}
+		})
+	}
+}
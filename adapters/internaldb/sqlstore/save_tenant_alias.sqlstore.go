@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SaveTenantAlias points alias to tenantID, replacing the tenant it pointed
+// to before.
+func (s *Store) SaveTenantAlias(ctx context.Context, alias, tenantID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO tenant_aliases (alias, tenant_id, created_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (alias) DO UPDATE SET
+				tenant_id  = EXCLUDED.tenant_id,
+				created_at = EXCLUDED.created_at`,
+			alias,
+			tenantID,
+			time.Now().UTC(),
+		)
+		return err
+	})
+}
@@ -0,0 +1,31 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const schemaVersionColumns = `tenant_id, version, checksum, created_at`
+
+func scanSchemaVersion(row rowScanner) (*model.SchemaVersion, error) {
+	var version model.SchemaVersion
+	if err := row.Scan(&version.TenantID, &version.Version, &version.Checksum, &version.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func scanSchemaVersions(rows *sql.Rows) ([]*model.SchemaVersion, error) {
+	defer rows.Close()
+
+	versions := []*model.SchemaVersion{}
+	for rows.Next() {
+		version, err := scanSchemaVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
@@ -0,0 +1,65 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const statusEventColumns = `id, tenant_id, type, message, progress, created_at`
+
+func scanStatusEvent(row rowScanner) (*model.StatusEvent, error) {
+	var (
+		event     model.StatusEvent
+		eventType string
+		progress  []byte
+	)
+	if err := row.Scan(&event.ID, &event.TenantID, &eventType, &event.Message, &progress, &event.CreatedAt); err != nil {
+		return nil, err
+	}
+	event.Type = model.StatusEventType(eventType)
+	if len(progress) > 0 {
+		event.Progress = &model.IngestionProgress{}
+		if err := json.Unmarshal(progress, event.Progress); err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+func encodeProgress(progress *model.IngestionProgress) (any, error) {
+	if progress == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(progress)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// placeholders returns "$from, $from+1, ..." for n arguments.
+func placeholders(from, n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = "$" + strconv.Itoa(from+i)
+	}
+	return strings.Join(params, ", ")
+}
+
+func scanStatusEvents(rows *sql.Rows) ([]*model.StatusEvent, error) {
+	defer rows.Close()
+
+	events := []*model.StatusEvent{}
+	for rows.Next() {
+		event, err := scanStatusEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
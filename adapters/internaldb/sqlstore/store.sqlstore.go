@@ -0,0 +1,122 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+var ErrNotConnected = errors.New("sqlstore: internal database is not connected")
+
+// Store implements ports.InternalDatabasePort on top of database/sql. Queries
+// are written in the subset of SQL shared by PostgreSQL and SQLite, so the
+// dialect specific adapters only open the connection and run migrations.
+type Store struct {
+	// mu guards db, which Connect sets and Close clears while other requests
+	// query it.
+	mu sync.RWMutex
+	db *sql.DB
+
+	// openMu lets a single Open dial and migrate a pool at a time.
+	openMu sync.Mutex
+
+	// serializeWrites funnels every write through writeMu, for engines that
+	// only allow a single writer at a time.
+	serializeWrites bool
+	writeMu         sync.Mutex
+}
+
+type Options struct {
+	SerializeWrites bool
+}
+
+func New(options Options) *Store {
+	return &Store{
+		serializeWrites: options.SerializeWrites,
+	}
+}
+
+// Open attaches the pool returned by open, or only pings the attached one.
+// Concurrent callers wait for the first, so a single pool is opened and
+// migrated.
+func (s *Store) Open(ctx context.Context, open func(ctx context.Context) (*sql.DB, error)) error {
+	s.openMu.Lock()
+	defer s.openMu.Unlock()
+
+	if db := s.conn(); db != nil {
+		return db.PingContext(ctx)
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	s.Attach(db)
+	return nil
+}
+
+// Attach hands an opened and migrated connection pool to the store. When a
+// pool is attached already the store keeps it and closes db.
+func (s *Store) Attach(db *sql.DB) {
+	s.mu.Lock()
+	if s.db == nil {
+		s.db = db
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	_ = db.Close()
+}
+
+// conn returns the attached pool, or nil when the store is not connected.
+func (s *Store) conn() *sql.DB {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.db
+}
+
+func (s *Store) Connected() bool {
+	return s.conn() != nil
+}
+
+func (s *Store) Ping(ctx context.Context) error {
+	db := s.conn()
+	if db == nil {
+		return ErrNotConnected
+	}
+	return db.PingContext(ctx)
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	db := s.db
+	s.db = nil
+	s.mu.Unlock()
+
+	if db == nil {
+		return nil
+	}
+	return db.Close()
+}
+
+// withTx runs fn inside a write transaction, committing on success.
+func (s *Store) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	db := s.conn()
+	if db == nil {
+		return ErrNotConnected
+	}
+	if s.serializeWrites {
+		s.writeMu.Lock()
+		defer s.writeMu.Unlock()
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,152 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockStore(t *testing.T) (*Store, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	store := New(Options{})
+	store.Attach(db)
+	return store, mock
+}
+
+func TestStore_Connection(t *testing.T) {
+	t.Run("not connected", func(t *testing.T) {
+		store := New(Options{})
+
+		require.False(t, store.Connected())
+		require.ErrorIs(t, store.Ping(context.Background()), ErrNotConnected)
+		_, err := store.ListAllWorkspaces(context.Background(), nil)
+		require.ErrorIs(t, err, ErrNotConnected)
+		require.ErrorIs(t, store.withTx(context.Background(), nil), ErrNotConnected)
+		require.NoError(t, store.Close())
+	})
+
+	t.Run("ping and close", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		mock.ExpectPing()
+		mock.ExpectClose()
+
+		require.True(t, store.Connected())
+		require.NoError(t, store.Ping(context.Background()))
+		require.NoError(t, store.Close())
+		require.False(t, store.Connected())
+	})
+
+	t.Run("attach keeps the first pool and closes the other", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		other, otherMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		otherMock.ExpectClose()
+		mock.ExpectPing()
+
+		store.Attach(other)
+
+		require.NoError(t, otherMock.ExpectationsWereMet())
+		require.NoError(t, store.Ping(context.Background()))
+	})
+
+	t.Run("open runs once for concurrent callers", func(t *testing.T) {
+		store := New(Options{})
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+
+		const callers = 8
+		for range callers - 1 {
+			mock.ExpectPing()
+		}
+
+		var (
+			wg     sync.WaitGroup
+			opened atomic.Int32
+		)
+		for range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, store.Open(context.Background(), func(context.Context) (*sql.DB, error) {
+					opened.Add(1)
+					return db, nil
+				}))
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(1), opened.Load())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStore_WithTx(t *testing.T) {
+	t.Run("commits on success", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		require.NoError(t, store.withTx(context.Background(), func(*sql.Tx) error { return nil }))
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err := store.withTx(context.Background(), func(*sql.Tx) error { return errors.New("write error") })
+		require.EqualError(t, err, "write error")
+	})
+
+	t.Run("serialized writes never overlap", func(t *testing.T) {
+		store, mock := newMockStore(t)
+		store.serializeWrites = true
+		mock.MatchExpectationsInOrder(false)
+
+		const writers = 8
+		for range writers {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+		}
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			active  int
+			overlap bool
+		)
+		for range writers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = store.withTx(context.Background(), func(*sql.Tx) error {
+					mu.Lock()
+					active++
+					overlap = overlap || active > 1
+					mu.Unlock()
+
+					mu.Lock()
+					active--
+					mu.Unlock()
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+
+		require.False(t, overlap)
+	})
+}
@@ -0,0 +1,36 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// UpsertDescription inserts or replaces the description of a table or
+// column. CreatedAt is kept from the first write; both timestamps are written
+// back onto description.
+func (s *Store) UpsertDescription(ctx context.Context, description *model.Description) error {
+	now := time.Now().UTC()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `
+			INSERT INTO schema_descriptions (tenant_id, table_name, column_name, description, source, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (tenant_id, table_name, column_name) DO UPDATE SET
+				description = EXCLUDED.description,
+				source      = EXCLUDED.source,
+				updated_at  = EXCLUDED.updated_at
+			RETURNING created_at, updated_at`,
+			description.TenantID,
+			description.Table,
+			description.Column,
+			description.Text,
+			string(description.Source),
+			now,
+			now,
+		)
+		return row.Scan(&description.CreatedAt, &description.UpdatedAt)
+	})
+}
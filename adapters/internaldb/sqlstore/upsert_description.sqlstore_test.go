@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_UpsertDescription(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	upsertQuery := regexp.QuoteMeta(`ON CONFLICT (tenant_id, table_name, column_name) DO UPDATE SET`)
+
+	tests := []struct {
+		name        string
+		description *domains.Description
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectError error
+	}{
+		{
+			name:        "success",
+			description: &domains.Description{TenantID: "tenant_123", Table: "customers", Column: "city", Text: "City of residence", Source: domains.DescriptionSourceUser},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(upsertQuery).
+					WithArgs("tenant_123", "customers", "city", "City of residence", "USER", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, updatedAt))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error upsert",
+			description: &domains.Description{TenantID: "tenant_123", Table: "customers"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(upsertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.UpsertDescription(context.Background(), tt.description)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, createdAt, tt.description.CreatedAt)
+				require.Equal(t, updatedAt, tt.description.UpdatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// UpsertExample inserts the example when its ID is zero and updates the
+// tenant's example with that ID otherwise. The ID and timestamps are written
+// back onto example.
+func (s *Store) UpsertExample(ctx context.Context, example *model.Example) error {
+	now := time.Now().UTC()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if example.ID == 0 {
+			return tx.QueryRowContext(ctx, `
+				INSERT INTO examples (tenant_id, question, query, notes, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				RETURNING id, created_at, updated_at`,
+				example.TenantID,
+				example.Question,
+				example.Query,
+				example.Notes,
+				now,
+				now,
+			).Scan(&example.ID, &example.CreatedAt, &example.UpdatedAt)
+		}
+
+		err := tx.QueryRowContext(ctx, `
+			UPDATE examples SET
+				question   = $3,
+				query      = $4,
+				notes      = $5,
+				updated_at = $6
+			WHERE tenant_id = $1 AND id = $2
+			RETURNING created_at, updated_at`,
+			example.TenantID,
+			example.ID,
+			example.Question,
+			example.Query,
+			example.Notes,
+			now,
+		).Scan(&example.CreatedAt, &example.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrExampleNotFound
+		}
+		return err
+	})
+}
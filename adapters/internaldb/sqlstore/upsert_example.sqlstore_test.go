@@ -0,0 +1,94 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_UpsertExample(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO examples`)
+	updateQuery := regexp.QuoteMeta(`UPDATE examples SET`)
+
+	tests := []struct {
+		name        string
+		example     *domains.Example
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectID    int64
+		expectError error
+	}{
+		{
+			name:    "success insert",
+			example: &domains.Example{TenantID: "tenant_123", Question: "How many orders?", Query: "SELECT count(*) FROM orders"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs("tenant_123", "How many orders?", "SELECT count(*) FROM orders", "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, createdAt, createdAt))
+				mock.ExpectCommit()
+			},
+			expectID: 3,
+		},
+		{
+			name:    "success update",
+			example: &domains.Example{ID: 3, TenantID: "tenant_123", Question: "How many orders?", Query: "SELECT count(id) FROM orders", Notes: "count ids"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).
+					WithArgs("tenant_123", int64(3), "How many orders?", "SELECT count(id) FROM orders", "count ids", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, updatedAt))
+				mock.ExpectCommit()
+			},
+			expectID: 3,
+		},
+		{
+			name:    "error update unknown example",
+			example: &domains.Example{ID: 9, TenantID: "tenant_123", Question: "How many orders?", Query: "SELECT 1"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectError: domains.ErrExampleNotFound,
+		},
+		{
+			name:    "error insert",
+			example: &domains.Example{TenantID: "tenant_123"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.UpsertExample(context.Background(), tt.example)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectID, tt.example.ID)
+				require.Equal(t, createdAt, tt.example.CreatedAt)
+			}
+		})
+	}
+}
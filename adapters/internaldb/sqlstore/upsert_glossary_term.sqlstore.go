@@ -0,0 +1,58 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// UpsertGlossaryTerm inserts the term when its ID is zero and updates the
+// tenant's term with that ID otherwise. The ID and timestamps are written back
+// onto term.
+func (s *Store) UpsertGlossaryTerm(ctx context.Context, term *model.GlossaryTerm) error {
+	synonyms, err := encodeSynonyms(term.Synonyms)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if term.ID == 0 {
+			return tx.QueryRowContext(ctx, `
+				INSERT INTO glossary_terms (tenant_id, term, definition, synonyms, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				RETURNING id, created_at, updated_at`,
+				term.TenantID,
+				term.Term,
+				term.Definition,
+				synonyms,
+				now,
+				now,
+			).Scan(&term.ID, &term.CreatedAt, &term.UpdatedAt)
+		}
+
+		err := tx.QueryRowContext(ctx, `
+			UPDATE glossary_terms SET
+				term       = $3,
+				definition = $4,
+				synonyms   = $5,
+				updated_at = $6
+			WHERE tenant_id = $1 AND id = $2
+			RETURNING created_at, updated_at`,
+			term.TenantID,
+			term.ID,
+			term.Term,
+			term.Definition,
+			synonyms,
+			now,
+		).Scan(&term.CreatedAt, &term.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrGlossaryTermNotFound
+		}
+		return err
+	})
+}
@@ -0,0 +1,94 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_UpsertGlossaryTerm(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO glossary_terms`)
+	updateQuery := regexp.QuoteMeta(`UPDATE glossary_terms SET`)
+
+	tests := []struct {
+		name        string
+		term        *domains.GlossaryTerm
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectID    int64
+		expectError error
+	}{
+		{
+			name: "success insert",
+			term: &domains.GlossaryTerm{TenantID: "tenant_123", Term: "client", Definition: "customers table"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs("tenant_123", "client", "customers table", `[]`, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, createdAt, createdAt))
+				mock.ExpectCommit()
+			},
+			expectID: 3,
+		},
+		{
+			name: "success update",
+			term: &domains.GlossaryTerm{ID: 3, TenantID: "tenant_123", Term: "client", Definition: "customers table", Synonyms: []string{"customer"}},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).
+					WithArgs("tenant_123", int64(3), "client", "customers table", `["customer"]`, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, updatedAt))
+				mock.ExpectCommit()
+			},
+			expectID: 3,
+		},
+		{
+			name: "error update unknown term",
+			term: &domains.GlossaryTerm{ID: 9, TenantID: "tenant_123", Term: "client", Definition: "customers table"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectError: domains.ErrGlossaryTermNotFound,
+		},
+		{
+			name: "error insert",
+			term: &domains.GlossaryTerm{TenantID: "tenant_123"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.UpsertGlossaryTerm(context.Background(), tt.term)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectID, tt.term.ID)
+				require.Equal(t, createdAt, tt.term.CreatedAt)
+			}
+		})
+	}
+}
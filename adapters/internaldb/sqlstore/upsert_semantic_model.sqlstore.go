@@ -0,0 +1,35 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// UpsertSemanticModel replaces the tenant's semantic model and writes the
+// update time back onto semanticModel.
+func (s *Store) UpsertSemanticModel(ctx context.Context, semanticModel *model.SemanticModel) error {
+	definition, err := json.Marshal(semanticModel)
+	if err != nil {
+		return err
+	}
+
+	semanticModel.UpdatedAt = time.Now().UTC()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO semantic_models (tenant_id, definition, updated_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (tenant_id) DO UPDATE SET
+				definition = EXCLUDED.definition,
+				updated_at = EXCLUDED.updated_at`,
+			semanticModel.TenantID,
+			string(definition),
+			semanticModel.UpdatedAt,
+		)
+		return err
+	})
+}
@@ -0,0 +1,72 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_UpsertSemanticModel(t *testing.T) {
+	upsertQuery := regexp.QuoteMeta(`INSERT INTO semantic_models`)
+	mockModel := func() *domains.SemanticModel {
+		return &domains.SemanticModel{
+			TenantID: "tenant_123",
+			Metrics:  []domains.Metric{{Name: "revenue", Expression: "SUM(orders.total)", Table: "orders"}},
+			Joins:    []domains.JoinPath{{From: "orders", To: "customers", On: "orders.customer_id = customers.id"}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func(mock sqlmock.Sqlmock)
+		expectError error
+	}{
+		{
+			name: "success",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(upsertQuery).
+					WithArgs(
+						"tenant_123",
+						`{"metrics":[{"name":"revenue","expression":"SUM(orders.total)","table":"orders"}],"joins":[{"from":"orders","to":"customers","on":"orders.customer_id = customers.id"}]}`,
+						sqlmock.AnyArg(),
+					).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "error exec",
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(upsertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			semanticModel := mockModel()
+			err := store.UpsertSemanticModel(context.Background(), semanticModel)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.False(t, semanticModel.UpdatedAt.IsZero())
+			}
+		})
+	}
+}
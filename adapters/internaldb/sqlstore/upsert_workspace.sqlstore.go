@@ -0,0 +1,79 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// UpsertWorkspace inserts or updates the workspace in one transaction. CreatedAt
+// is only taken from the caller on insert and is kept afterwards; UpdatedAt is
+// always set to the write time. Both are written back onto workspace. The
+// labels are copied to workspace_labels, which listings filter on.
+func (s *Store) UpsertWorkspace(ctx context.Context, workspace *model.Workspace) error {
+	tableChecksums, err := encodeTableChecksums(workspace.TableChecksums)
+	if err != nil {
+		return err
+	}
+	syncOptions, err := encodeSyncOptions(workspace.SyncOptions)
+	if err != nil {
+		return err
+	}
+	labels, err := encodeLabels(workspace.Labels)
+	if err != nil {
+		return err
+	}
+	dataSources, err := encodeDataSources(workspace.DataSources)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	createdAt := workspace.CreatedAt.UTC()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `
+			INSERT INTO workspaces (tenant_id, encrypted_db_url, status, checksum, table_checksums, active_version, pinned_version, sync_options, sync_schedule, name, labels, data_sources, created_at, updated_at, deleted_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			ON CONFLICT (tenant_id) DO UPDATE SET
+				encrypted_db_url = EXCLUDED.encrypted_db_url,
+				status           = EXCLUDED.status,
+				checksum         = EXCLUDED.checksum,
+				table_checksums  = EXCLUDED.table_checksums,
+				active_version   = EXCLUDED.active_version,
+				pinned_version   = EXCLUDED.pinned_version,
+				sync_options     = EXCLUDED.sync_options,
+				sync_schedule    = EXCLUDED.sync_schedule,
+				name             = EXCLUDED.name,
+				labels           = EXCLUDED.labels,
+				data_sources     = EXCLUDED.data_sources,
+				updated_at       = EXCLUDED.updated_at,
+				deleted_at       = EXCLUDED.deleted_at
+			RETURNING created_at, updated_at`,
+			workspace.TenantID,
+			workspace.EncryptedDBURL,
+			string(workspace.Status),
+			workspace.Checksum,
+			tableChecksums,
+			workspace.ActiveVersion,
+			workspace.PinnedVersion,
+			syncOptions,
+			workspace.SyncSchedule,
+			workspace.Name,
+			labels,
+			dataSources,
+			createdAt,
+			now,
+			encodeDeletedAt(workspace.DeletedAt),
+		)
+		if err := row.Scan(&workspace.CreatedAt, &workspace.UpdatedAt); err != nil {
+			return err
+		}
+		return replaceWorkspaceLabels(ctx, tx, workspace.TenantID, workspace.Labels)
+	})
+}
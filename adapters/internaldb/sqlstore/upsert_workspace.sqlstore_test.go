@@ -0,0 +1,133 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_UpsertWorkspace(t *testing.T) {
+	originalCreatedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	writeTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	deletedAt := writeTime.Add(-time.Hour)
+	upsertQuery := regexp.QuoteMeta(`ON CONFLICT (tenant_id) DO UPDATE SET`)
+	deleteLabelsQuery := regexp.QuoteMeta(`DELETE FROM workspace_labels WHERE tenant_id = $1`)
+	insertLabelQuery := regexp.QuoteMeta(`INSERT INTO workspace_labels (tenant_id, key, value) VALUES ($1, $2, $3)`)
+
+	tests := []struct {
+		name            string
+		workspace       *domains.Workspace
+		prepareMock     func(mock sqlmock.Sqlmock)
+		expectCreatedAt time.Time
+		expectUpdatedAt time.Time
+		expectError     error
+	}{
+		{
+			name:      "insert fills timestamps",
+			workspace: &domains.Workspace{TenantID: "tenant_123", EncryptedDBURL: "enc_1", Status: domains.StatusInProgress},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(upsertQuery).
+					WithArgs("tenant_123", "enc_1", "IN_PROGRESS", "", nil, int64(0), int64(0), nil, "", "", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(writeTime, writeTime))
+				mock.ExpectExec(deleteLabelsQuery).
+					WithArgs("tenant_123").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectCreatedAt: writeTime,
+			expectUpdatedAt: writeTime,
+		},
+		{
+			name: "update keeps stored creation time",
+			workspace: &domains.Workspace{
+				TenantID:       "tenant_123",
+				Status:         domains.StatusDone,
+				Checksum:       "checksum_abc",
+				TableChecksums: map[string]string{"orders": "o1"},
+				ActiveVersion:  2,
+				SyncOptions:    &domains.SyncOptions{ExcludeTables: []string{"audit_*"}},
+				SyncSchedule:   "@hourly",
+				Name:           "Sales",
+				Labels:         map[string]string{"team": "sales", "env": "prod"},
+				DataSources:    []domains.DataSource{{Name: "oltp", EncryptedDBURL: "enc_1"}},
+				CreatedAt:      writeTime, // ignored on conflict
+				DeletedAt:      deletedAt,
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(upsertQuery).
+					WithArgs("tenant_123", "", "DONE", "checksum_abc", `{"orders":"o1"}`, int64(2), int64(0), `{"exclude_tables":["audit_*"]}`, "@hourly", "Sales", `{"env":"prod","team":"sales"}`, `[{"name":"oltp","encrypted_db_url":"enc_1"}]`, writeTime, sqlmock.AnyArg(), deletedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(originalCreatedAt, writeTime))
+				mock.ExpectExec(deleteLabelsQuery).
+					WithArgs("tenant_123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertLabelQuery).
+					WithArgs("tenant_123", "env", "prod").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertLabelQuery).
+					WithArgs("tenant_123", "team", "sales").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectCreatedAt: originalCreatedAt,
+			expectUpdatedAt: writeTime,
+		},
+		{
+			name:      "error rolls back",
+			workspace: &domains.Workspace{TenantID: "tenant_123"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(upsertQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:      "error replacing labels rolls back",
+			workspace: &domains.Workspace{TenantID: "tenant_123"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(upsertQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(writeTime, writeTime))
+				mock.ExpectExec(deleteLabelsQuery).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:      "error begin",
+			workspace: &domains.Workspace{TenantID: "tenant_123"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+			tt.prepareMock(mock)
+
+			err := store.UpsertWorkspace(context.Background(), tt.workspace)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectCreatedAt, tt.workspace.CreatedAt)
+				require.Equal(t, tt.expectUpdatedAt, tt.workspace.UpdatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const workspaceColumns = `tenant_id, encrypted_db_url, status, checksum, table_checksums, active_version, pinned_version, sync_options, sync_schedule, name, labels, data_sources, created_at, updated_at, deleted_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWorkspace(row rowScanner) (*model.Workspace, error) {
+	var (
+		workspace      model.Workspace
+		status         string
+		tableChecksums []byte
+		syncOptions    []byte
+		labels         []byte
+		dataSources    []byte
+		deletedAt      sql.NullTime
+	)
+	if err := row.Scan(
+		&workspace.TenantID,
+		&workspace.EncryptedDBURL,
+		&status,
+		&workspace.Checksum,
+		&tableChecksums,
+		&workspace.ActiveVersion,
+		&workspace.PinnedVersion,
+		&syncOptions,
+		&workspace.SyncSchedule,
+		&workspace.Name,
+		&labels,
+		&dataSources,
+		&workspace.CreatedAt,
+		&workspace.UpdatedAt,
+		&deletedAt,
+	); err != nil {
+		return nil, err
+	}
+	workspace.Status = model.WorkspaceStatus(status)
+	if deletedAt.Valid {
+		workspace.DeletedAt = deletedAt.Time
+	}
+	if len(tableChecksums) > 0 {
+		if err := json.Unmarshal(tableChecksums, &workspace.TableChecksums); err != nil {
+			return nil, err
+		}
+	}
+	if len(syncOptions) > 0 {
+		if err := json.Unmarshal(syncOptions, &workspace.SyncOptions); err != nil {
+			return nil, err
+		}
+	}
+	if len(labels) > 0 {
+		if err := json.Unmarshal(labels, &workspace.Labels); err != nil {
+			return nil, err
+		}
+	}
+	if len(dataSources) > 0 {
+		if err := json.Unmarshal(dataSources, &workspace.DataSources); err != nil {
+			return nil, err
+		}
+	}
+	return &workspace, nil
+}
+
+func encodeTableChecksums(checksums map[string]string) (any, error) {
+	if checksums == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(checksums)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func encodeSyncOptions(options *model.SyncOptions) (any, error) {
+	if options == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func encodeLabels(labels map[string]string) (any, error) {
+	if labels == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(labels)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func encodeDataSources(sources []model.DataSource) (any, error) {
+	if sources == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(sources)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func encodeDeletedAt(deletedAt time.Time) any {
+	if deletedAt.IsZero() {
+		return nil
+	}
+	return deletedAt.UTC()
+}
+
+func scanWorkspaces(rows *sql.Rows) ([]*model.Workspace, error) {
+	defer rows.Close()
+
+	workspaces := []*model.Workspace{}
+	for rows.Next() {
+		workspace, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, rows.Err()
+}
+
+func replaceWorkspaceLabels(ctx context.Context, tx *sql.Tx, tenantID string, labels map[string]string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM workspace_labels WHERE tenant_id = $1`, tenantID); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO workspace_labels (tenant_id, key, value) VALUES ($1, $2, $3)`, tenantID, key, labels[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
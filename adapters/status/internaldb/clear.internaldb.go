@@ -0,0 +1,11 @@
+package internaldb
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *StatusAdapter) Clear(ctx context.Context, tenantID string) error {
+	return s.appendEvent(ctx, tenantID, model.StatusEventCleared, "")
+}
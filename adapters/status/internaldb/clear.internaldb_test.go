@@ -0,0 +1,57 @@
+package internaldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusAdapter_Clear(t *testing.T) {
+	var mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+
+	mockTenantID := "tenant_123"
+
+	tests := []struct {
+		name        string
+		appendError error
+		expectError error
+	}{
+		{
+			name: "success clear appends cleared event",
+		},
+		{
+			name:        "error append event",
+			appendError: errors.New("database error"),
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			adapter := NewStatusAdapter(mockInternalDatabaseAdapter)
+
+			mockInternalDatabaseAdapter.
+				EXPECT().
+				AppendStatusEvent(gomock.Any(), statusEventMatcher{mockTenantID, domains.StatusEventCleared, ""}).
+				Return(tt.appendError)
+
+			err := adapter.Clear(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package internaldb
+
+import "github.com/kamil5b/go-nl2query-lib/ports"
+
+var _ ports.StatusPort = (*StatusAdapter)(nil)
+
+// StatusAdapter is a ports.StatusPort that keeps every status transition as an
+// append-only event in the internal database and mirrors the resulting state
+// onto the stored workspace.
+type StatusAdapter struct {
+	internalDatabaseAdapter ports.InternalDatabasePort
+}
+
+func NewStatusAdapter(
+	internalDatabaseAdapter ports.InternalDatabasePort,
+) *StatusAdapter {
+	return &StatusAdapter{
+		internalDatabaseAdapter: internalDatabaseAdapter,
+	}
+}
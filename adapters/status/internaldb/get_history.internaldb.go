@@ -0,0 +1,11 @@
+package internaldb
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *StatusAdapter) GetHistory(ctx context.Context, tenantID string) ([]*model.StatusEvent, error) {
+	return s.internalDatabaseAdapter.ListStatusEventsByTenantID(ctx, tenantID)
+}
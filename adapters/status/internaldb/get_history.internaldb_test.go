@@ -0,0 +1,75 @@
+package internaldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusAdapter_GetHistory(t *testing.T) {
+	var mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+
+	mockTenantID := "tenant_123"
+	mockHistory := []*domains.StatusEvent{
+		{ID: 1, TenantID: mockTenantID, Type: domains.StatusEventInProgress, CreatedAt: time.Now()},
+		{ID: 2, TenantID: mockTenantID, Type: domains.StatusEventError, Message: "embed failed", CreatedAt: time.Now()},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.StatusEvent
+	}{
+		{
+			name: "success get history",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListStatusEventsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockHistory, nil)
+			},
+			expectData: mockHistory,
+		},
+		{
+			name: "error get history",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListStatusEventsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			adapter := NewStatusAdapter(mockInternalDatabaseAdapter)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := adapter.GetHistory(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package internaldb
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// progressEventTypes bound a single ingestion run: progress is only reported
+// when a PROGRESS event is newer than any transition that starts or ends a run.
+var progressEventTypes = []model.StatusEventType{
+	model.StatusEventProgress,
+	model.StatusEventInProgress,
+	model.StatusEventDone,
+	model.StatusEventError,
+	model.StatusEventCleared,
+}
+
+func (s *StatusAdapter) GetProgress(ctx context.Context, tenantID string) (*model.IngestionProgress, error) {
+	event, err := s.internalDatabaseAdapter.GetLatestStatusEventByTenantID(ctx, tenantID, progressEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil || event.Type != model.StatusEventProgress {
+		return nil, nil
+	}
+
+	return event.Progress, nil
+}
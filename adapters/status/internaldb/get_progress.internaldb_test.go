@@ -0,0 +1,84 @@
+package internaldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusAdapter_GetProgress(t *testing.T) {
+	var mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+
+	mockTenantID := "tenant_123"
+	mockProgress := &domains.IngestionProgress{
+		Phase:     domains.IngestionPhaseUpserting,
+		Done:      3,
+		Total:     4,
+		StartedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		latest      *domains.StatusEvent
+		latestError error
+		expectData  *domains.IngestionProgress
+		expectError error
+	}{
+		{
+			name:       "no history",
+			latest:     nil,
+			expectData: nil,
+		},
+		{
+			name:       "progress reported",
+			latest:     &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventProgress, Progress: mockProgress},
+			expectData: mockProgress,
+		},
+		{
+			name:       "run started without progress yet",
+			latest:     &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventInProgress},
+			expectData: nil,
+		},
+		{
+			name:       "run already finished",
+			latest:     &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventDone},
+			expectData: nil,
+		},
+		{
+			name:        "error reading history",
+			latestError: errors.New("database error"),
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			adapter := NewStatusAdapter(mockInternalDatabaseAdapter)
+
+			mockInternalDatabaseAdapter.
+				EXPECT().
+				GetLatestStatusEventByTenantID(gomock.Any(), mockTenantID, progressEventTypes).
+				Return(tt.latest, tt.latestError)
+
+			result, err := adapter.GetProgress(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package internaldb
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// statusEventTypes are the events that decide the current workspace status;
+// progress events are left out so they never mask a transition.
+var statusEventTypes = []model.StatusEventType{
+	model.StatusEventInProgress,
+	model.StatusEventDone,
+	model.StatusEventError,
+	model.StatusEventWarn,
+	model.StatusEventCleared,
+}
+
+func (s *StatusAdapter) GetStatus(ctx context.Context, tenantID string) (model.WorkspaceStatus, *string, error) {
+	event, err := s.internalDatabaseAdapter.GetLatestStatusEventByTenantID(ctx, tenantID, statusEventTypes)
+	if err != nil {
+		return "", nil, err
+	}
+	if event == nil {
+		return "", nil, nil
+	}
+
+	var msg *string
+	if event.Message != "" {
+		message := event.Message
+		msg = &message
+	}
+
+	status, _ := workspaceStatusOf(event.Type)
+	return status, msg, nil
+}
@@ -0,0 +1,92 @@
+package internaldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusAdapter_GetStatus(t *testing.T) {
+	var mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+
+	mockTenantID := "tenant_123"
+	constToMsg := func(msg string) *string {
+		return &msg
+	}
+
+	tests := []struct {
+		name         string
+		latest       *domains.StatusEvent
+		latestError  error
+		expectStatus domains.WorkspaceStatus
+		expectMsg    *string
+		expectError  error
+	}{
+		{
+			name:         "no history",
+			latest:       nil,
+			expectStatus: "",
+			expectMsg:    nil,
+		},
+		{
+			name:         "in progress",
+			latest:       &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventInProgress},
+			expectStatus: domains.StatusInProgress,
+			expectMsg:    nil,
+		},
+		{
+			name:         "error keeps its message",
+			latest:       &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventError, Message: "embed failed"},
+			expectStatus: domains.StatusError,
+			expectMsg:    constToMsg("embed failed"),
+		},
+		{
+			name:         "warn keeps its message",
+			latest:       &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventWarn, Message: "using cached schema"},
+			expectStatus: domains.StatusWarn,
+			expectMsg:    constToMsg("using cached schema"),
+		},
+		{
+			name:         "cleared status",
+			latest:       &domains.StatusEvent{TenantID: mockTenantID, Type: domains.StatusEventCleared},
+			expectStatus: "",
+			expectMsg:    nil,
+		},
+		{
+			name:        "error reading history",
+			latestError: errors.New("database error"),
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			adapter := NewStatusAdapter(mockInternalDatabaseAdapter)
+
+			mockInternalDatabaseAdapter.
+				EXPECT().
+				GetLatestStatusEventByTenantID(gomock.Any(), mockTenantID, statusEventTypes).
+				Return(tt.latest, tt.latestError)
+
+			status, msg, err := adapter.GetStatus(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectStatus, status)
+				require.Equal(t, tt.expectMsg, msg)
+			}
+		})
+	}
+}
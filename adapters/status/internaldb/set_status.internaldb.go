@@ -0,0 +1,65 @@
+package internaldb
+
+import (
+	"context"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *StatusAdapter) SetInProgress(ctx context.Context, tenantID string) error {
+	return s.appendEvent(ctx, tenantID, model.StatusEventInProgress, "")
+}
+
+func (s *StatusAdapter) SetDone(ctx context.Context, tenantID string) error {
+	return s.appendEvent(ctx, tenantID, model.StatusEventDone, "")
+}
+
+func (s *StatusAdapter) SetError(ctx context.Context, tenantID string, message string) error {
+	return s.appendEvent(ctx, tenantID, model.StatusEventError, message)
+}
+
+func (s *StatusAdapter) SetWarn(ctx context.Context, tenantID string, message string) error {
+	return s.appendEvent(ctx, tenantID, model.StatusEventWarn, message)
+}
+
+func (s *StatusAdapter) SetProgress(ctx context.Context, tenantID string, progress model.IngestionProgress) error {
+	return s.internalDatabaseAdapter.AppendStatusEvent(ctx, &model.StatusEvent{
+		TenantID:  tenantID,
+		Type:      model.StatusEventProgress,
+		Progress:  &progress,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *StatusAdapter) appendEvent(ctx context.Context, tenantID string, eventType model.StatusEventType, message string) error {
+	event := &model.StatusEvent{
+		TenantID:  tenantID,
+		Type:      eventType,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+
+	// Append the transition to the status history, mirroring the new state
+	// onto the stored workspace in the same write
+	if status, ok := workspaceStatusOf(eventType); ok {
+		return s.internalDatabaseAdapter.AppendStatusTransition(ctx, event, status)
+	}
+	return s.internalDatabaseAdapter.AppendStatusEvent(ctx, event)
+}
+
+// workspaceStatusOf maps an event to the workspace status it leads to. Events
+// that do not change the workspace status report false.
+func workspaceStatusOf(eventType model.StatusEventType) (model.WorkspaceStatus, bool) {
+	switch eventType {
+	case model.StatusEventInProgress:
+		return model.StatusInProgress, true
+	case model.StatusEventDone:
+		return model.StatusDone, true
+	case model.StatusEventError:
+		return model.StatusError, true
+	case model.StatusEventWarn:
+		return model.StatusWarn, true
+	}
+	return "", false
+}
@@ -0,0 +1,174 @@
+package internaldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type statusEventMatcher struct {
+	tenantID  string
+	eventType domains.StatusEventType
+	message   string
+}
+
+func (m statusEventMatcher) Matches(x interface{}) bool {
+	event, ok := x.(*domains.StatusEvent)
+	if !ok {
+		return false
+	}
+	return event.TenantID == m.tenantID &&
+		event.Type == m.eventType &&
+		event.Message == m.message &&
+		!event.CreatedAt.IsZero()
+}
+
+func (m statusEventMatcher) String() string {
+	return fmt.Sprintf("status event %s for %s with message %q", m.eventType, m.tenantID, m.message)
+}
+
+func TestStatusAdapter_SetStatus(t *testing.T) {
+	var mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+
+	mockTenantID := "tenant_123"
+	mockMessage := "embedding provider unavailable"
+	mockProgress := domains.IngestionProgress{
+		Phase:     domains.IngestionPhaseEmbedding,
+		Done:      10,
+		Total:     40,
+		StartedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		call        func(s *StatusAdapter) error
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success set in progress",
+			call: func(s *StatusAdapter) error {
+				return s.SetInProgress(context.Background(), mockTenantID)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusTransition(gomock.Any(), statusEventMatcher{mockTenantID, domains.StatusEventInProgress, ""}, domains.StatusInProgress).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name: "success set done",
+			call: func(s *StatusAdapter) error {
+				return s.SetDone(context.Background(), mockTenantID)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusTransition(gomock.Any(), statusEventMatcher{mockTenantID, domains.StatusEventDone, ""}, domains.StatusDone).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name: "success set error",
+			call: func(s *StatusAdapter) error {
+				return s.SetError(context.Background(), mockTenantID, mockMessage)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusTransition(gomock.Any(), statusEventMatcher{mockTenantID, domains.StatusEventError, mockMessage}, domains.StatusError).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name: "success set warn",
+			call: func(s *StatusAdapter) error {
+				return s.SetWarn(context.Background(), mockTenantID, mockMessage)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusTransition(gomock.Any(), statusEventMatcher{mockTenantID, domains.StatusEventWarn, mockMessage}, domains.StatusWarn).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name: "success set progress only appends event",
+			call: func(s *StatusAdapter) error {
+				return s.SetProgress(context.Background(), mockTenantID, mockProgress)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *domains.StatusEvent) error {
+						require.Equal(t, domains.StatusEventProgress, event.Type)
+						require.Equal(t, &mockProgress, event.Progress)
+						return nil
+					})
+			},
+			expectError: nil,
+		},
+		{
+			name: "error set progress",
+			call: func(s *StatusAdapter) error {
+				return s.SetProgress(context.Background(), mockTenantID, mockProgress)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusEvent(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error append transition",
+			call: func(s *StatusAdapter) error {
+				return s.SetDone(context.Background(), mockTenantID)
+			},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendStatusTransition(gomock.Any(), gomock.Any(), domains.StatusDone).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			adapter := NewStatusAdapter(mockInternalDatabaseAdapter)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := tt.call(adapter)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package domains
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2026, 1, 14, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		expect     time.Time
+	}{
+		{
+			name:       "every minute",
+			expression: "* * * * *",
+			expect:     time.Date(2026, 1, 14, 10, 18, 0, 0, time.UTC),
+		},
+		{
+			name:       "step of minutes",
+			expression: "*/15 * * * *",
+			expect:     time.Date(2026, 1, 14, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "hourly descriptor",
+			expression: "@hourly",
+			expect:     time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "daily at a time already passed today",
+			expression: "30 2 * * *",
+			expect:     time.Date(2026, 1, 15, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "range and list of week days",
+			expression: "0 9 * * 1-2,5",
+			expect:     time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Sunday written as 7",
+			expression: "0 0 * * 7",
+			expect:     time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "day of month or day of week when both are set",
+			expression: "0 0 20 * 5",
+			expect:     time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "next year",
+			expression: "@yearly",
+			expect:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			expect:     time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "fixed interval",
+			expression: "@every 90m",
+			expect:     time.Date(2026, 1, 14, 11, 47, 30, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if next := schedule.Next(from); !next.Equal(tt.expect) {
+				t.Fatalf("expected %v, got %v", tt.expect, next)
+			}
+		})
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		expect     string
+	}{
+		{"* * * *", `invalid cron expression "* * * *": expected 5 fields, got 4`},
+		{"60 * * * *", `value "60" out of range 0-59 in minute field`},
+		{"* 5-2 * * *", `value "5-2" out of range 0-23 in hour field`},
+		{"*/0 * * * *", `invalid step "0" in minute field`},
+		{"* * x * *", `invalid value "x" in day of month field`},
+		{"@every 10s", `invalid interval "10s": must be a duration of at least 1m`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := ParseCronSchedule(tt.expression)
+			if err == nil || err.Error() != tt.expect {
+				t.Fatalf("expected %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
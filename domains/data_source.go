@@ -0,0 +1,187 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DataSource is a client database attached to a named workspace.
+type DataSource struct {
+	Name           string `json:"name"`
+	EncryptedDBURL string `json:"encrypted_db_url"`
+	// Preferred makes the source the read target of the tables it shares
+	// with other sources of the workspace.
+	Preferred bool `json:"preferred,omitempty"`
+}
+
+// WorkspaceDefinition describes a named workspace to create or update.
+type WorkspaceDefinition struct {
+	ID     string
+	Name   string
+	Labels map[string]string
+	// DataSources are ingested in order. A table found in several of them is
+	// kept once, from the preferred source if it has the table and from the
+	// first one otherwise, and queries reading it run there.
+	DataSources []DataSourceDefinition
+}
+
+// DataSourceDefinition names the database URL of a data source. On update, an
+// empty DBURL keeps the stored URL of the source with that name.
+type DataSourceDefinition struct {
+	Name  string
+	DBURL string
+	// Preferred marks the source as the read target of duplicate tables. At
+	// most one source of a workspace is preferred.
+	Preferred bool
+}
+
+// ErrCrossDataSource is returned for a query reading tables of several data
+// sources, which no single database can run.
+var ErrCrossDataSource = errors.New("query reads tables of several data sources")
+
+// hashedTenantIDPrefix starts the IDs generated from database URLs.
+const hashedTenantIDPrefix = "tenant_"
+
+var (
+	workspaceIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+	dataSourcePattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+)
+
+// Validate returns an error naming the first invalid field. IDs starting with
+// "tenant_" are reserved for the workspaces identified by their database URL.
+func (d *WorkspaceDefinition) Validate() error {
+	if !workspaceIDPattern.MatchString(d.ID) {
+		return fmt.Errorf("invalid id %q: use 1 to 63 lowercase letters, digits, '-' or '_'", d.ID)
+	}
+	if strings.HasPrefix(d.ID, hashedTenantIDPrefix) {
+		return fmt.Errorf("invalid id %q: the %q prefix is reserved", d.ID, hashedTenantIDPrefix)
+	}
+	for key := range d.Labels {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("label keys must not be empty")
+		}
+	}
+	if len(d.DataSources) == 0 {
+		return errors.New("at least one data source is required")
+	}
+	seen := make(map[string]bool, len(d.DataSources))
+	preferred := ""
+	for _, source := range d.DataSources {
+		if !dataSourcePattern.MatchString(source.Name) {
+			return fmt.Errorf("invalid data source name %q: use lowercase letters, digits, '-' or '_'", source.Name)
+		}
+		if seen[source.Name] {
+			return fmt.Errorf("duplicate data source %q", source.Name)
+		}
+		seen[source.Name] = true
+		if source.Preferred {
+			if preferred != "" {
+				return fmt.Errorf("data sources %q and %q are both preferred: prefer at most one", preferred, source.Name)
+			}
+			preferred = source.Name
+		}
+	}
+	return nil
+}
+
+// MergeMetadata merges the metadata of the data sources of a workspace, in
+// order. A table of the preferred source, when not empty, wins over the tables
+// of the same name in other sources. Otherwise a table already merged from an
+// earlier source is skipped. The relations starting from a skipped table are
+// skipped with it.
+func MergeMetadata(tenantID string, preferred string, sources ...*DatabaseMetadata) *DatabaseMetadata {
+	merged := &DatabaseMetadata{TenantID: tenantID}
+	seen := make(map[string]bool)
+	relations := make(map[Relation]bool)
+
+	owned := make(map[string]bool)
+	for _, metadata := range sources {
+		if metadata == nil || preferred == "" {
+			continue
+		}
+		for _, table := range metadata.Tables {
+			if table.Source == preferred {
+				owned[strings.ToLower(table.Name)] = true
+			}
+		}
+	}
+
+	for _, metadata := range sources {
+		if metadata == nil {
+			continue
+		}
+
+		kept := make(map[string]bool, len(metadata.Tables))
+		for _, table := range metadata.Tables {
+			key := strings.ToLower(table.Name)
+			if seen[key] || (owned[key] && table.Source != preferred) {
+				continue
+			}
+			seen[key] = true
+			kept[key] = true
+			merged.Tables = append(merged.Tables, table)
+		}
+
+		for _, relation := range metadata.Relations {
+			if kept[strings.ToLower(relation.SourceTable)] && !relations[relation] {
+				relations[relation] = true
+				merged.Relations = append(merged.Relations, relation)
+			}
+		}
+	}
+	return merged
+}
+
+// TableSources returns the data source of each table read from one, keyed by
+// the lowercase table name.
+func (m *DatabaseMetadata) TableSources() map[string]string {
+	if m == nil {
+		return nil
+	}
+	sources := make(map[string]string)
+	for _, table := range m.Tables {
+		if table.Source != "" {
+			sources[strings.ToLower(table.Name)] = table.Source
+		}
+	}
+	return sources
+}
+
+// RouteReferences returns the data source holding the tables a query reads,
+// or an empty string when none of them is known. It fails with
+// ErrCrossDataSource when they are spread over several sources.
+func RouteReferences(tableSources map[string]string, references []QueryReference) (string, error) {
+	bySource := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, reference := range references {
+		source, ok := tableSources[strings.ToLower(reference.Table)]
+		if reference.Table == "" || !ok || seen[reference.Table] {
+			continue
+		}
+		seen[reference.Table] = true
+		bySource[source] = append(bySource[source], reference.Table)
+	}
+
+	switch len(bySource) {
+	case 0:
+		return "", nil
+	case 1:
+		for source := range bySource {
+			return source, nil
+		}
+	}
+
+	names := make([]string, 0, len(bySource))
+	for source := range bySource {
+		names = append(names, source)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, source := range names {
+		parts[i] = source + " (" + strings.Join(bySource[source], ", ") + ")"
+	}
+	return "", fmt.Errorf("%w: %s", ErrCrossDataSource, strings.Join(parts, ", "))
+}
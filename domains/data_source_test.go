@@ -0,0 +1,175 @@
+package domains
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWorkspaceDefinitionValidate(t *testing.T) {
+	sources := []DataSourceDefinition{{Name: "oltp", DBURL: "postgres://primary/app"}, {Name: "reporting", DBURL: "postgres://replica/app"}}
+
+	tests := []struct {
+		name       string
+		definition WorkspaceDefinition
+		expect     string
+	}{
+		{
+			name:       "valid",
+			definition: WorkspaceDefinition{ID: "sales-eu", Name: "Sales EU", Labels: map[string]string{"team": "sales"}, DataSources: sources},
+		},
+		{
+			name:       "invalid id",
+			definition: WorkspaceDefinition{ID: "Sales EU", DataSources: sources},
+			expect:     `invalid id "Sales EU": use 1 to 63 lowercase letters, digits, '-' or '_'`,
+		},
+		{
+			name:       "reserved id prefix",
+			definition: WorkspaceDefinition{ID: "tenant_123", DataSources: sources},
+			expect:     `invalid id "tenant_123": the "tenant_" prefix is reserved`,
+		},
+		{
+			name:       "empty label key",
+			definition: WorkspaceDefinition{ID: "sales", Labels: map[string]string{" ": "x"}, DataSources: sources},
+			expect:     "label keys must not be empty",
+		},
+		{
+			name:       "no data source",
+			definition: WorkspaceDefinition{ID: "sales"},
+			expect:     "at least one data source is required",
+		},
+		{
+			name:       "duplicate data source",
+			definition: WorkspaceDefinition{ID: "sales", DataSources: []DataSourceDefinition{sources[0], sources[0]}},
+			expect:     `duplicate data source "oltp"`,
+		},
+		{
+			name: "several preferred data sources",
+			definition: WorkspaceDefinition{ID: "sales", DataSources: []DataSourceDefinition{
+				{Name: "oltp", DBURL: "postgres://primary/app", Preferred: true},
+				{Name: "reporting", DBURL: "postgres://replica/app", Preferred: true},
+			}},
+			expect: `data sources "oltp" and "reporting" are both preferred: prefer at most one`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.definition.Validate()
+			if tt.expect == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expect {
+				t.Fatalf("expected %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+	oltp := &DatabaseMetadata{
+		Tables: []Table{
+			{Name: "orders", Source: "oltp"},
+			{Name: "customers", Source: "oltp"},
+		},
+		Relations: []Relation{{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"}},
+	}
+	reporting := &DatabaseMetadata{
+		Tables: []Table{
+			{Name: "Orders", Source: "reporting"},
+			{Name: "daily_revenue", Source: "reporting"},
+		},
+		Relations: []Relation{
+			{SourceTable: "Orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"},
+			{SourceTable: "daily_revenue", SourceColumn: "day", TargetTable: "calendar", TargetColumn: "day"},
+		},
+	}
+
+	expect := &DatabaseMetadata{
+		TenantID: "sales",
+		Tables: []Table{
+			{Name: "orders", Source: "oltp"},
+			{Name: "customers", Source: "oltp"},
+			{Name: "daily_revenue", Source: "reporting"},
+		},
+		Relations: []Relation{oltp.Relations[0], reporting.Relations[1]},
+	}
+
+	if merged := MergeMetadata("sales", "", oltp, nil, reporting); !reflect.DeepEqual(expect, merged) {
+		t.Fatalf("expected %+v, got %+v", expect, merged)
+	}
+
+	// The preferred source keeps the duplicate table, and its relations,
+	// without reordering the tables of the other sources
+	expectPreferred := &DatabaseMetadata{
+		TenantID: "sales",
+		Tables: []Table{
+			{Name: "customers", Source: "oltp"},
+			{Name: "Orders", Source: "reporting"},
+			{Name: "daily_revenue", Source: "reporting"},
+		},
+		Relations: reporting.Relations,
+	}
+
+	if merged := MergeMetadata("sales", "reporting", oltp, nil, reporting); !reflect.DeepEqual(expectPreferred, merged) {
+		t.Fatalf("expected %+v, got %+v", expectPreferred, merged)
+	}
+}
+
+func TestWorkspacePreferredDataSource(t *testing.T) {
+	workspace := &Workspace{DataSources: []DataSource{{Name: "oltp"}, {Name: "reporting", Preferred: true}}}
+	if preferred := workspace.PreferredDataSource(); preferred != "reporting" {
+		t.Fatalf("expected reporting, got %q", preferred)
+	}
+	if preferred := (&Workspace{DataSources: []DataSource{{Name: "oltp"}}}).PreferredDataSource(); preferred != "" {
+		t.Fatalf("expected no preferred source, got %q", preferred)
+	}
+}
+
+func TestRouteReferences(t *testing.T) {
+	tableSources := (&DatabaseMetadata{Tables: []Table{
+		{Name: "orders", Source: "oltp"},
+		{Name: "customers", Source: "oltp"},
+		{Name: "daily_revenue", Source: "reporting"},
+	}}).TableSources()
+
+	tests := []struct {
+		name       string
+		references []QueryReference
+		expect     string
+		expectErr  string
+	}{
+		{
+			name:       "one source",
+			references: []QueryReference{{Table: "ORDERS"}, {Table: "customers", Column: "id"}, {Column: "total"}},
+			expect:     "oltp",
+		},
+		{
+			name:       "unknown tables",
+			references: []QueryReference{{Table: "calendar"}},
+		},
+		{
+			name:       "several sources",
+			references: []QueryReference{{Table: "orders"}, {Table: "daily_revenue"}, {Table: "orders", Column: "id"}},
+			expectErr:  "query reads tables of several data sources: oltp (orders), reporting (daily_revenue)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := RouteReferences(tableSources, tt.references)
+			if tt.expectErr != "" {
+				if err == nil || err.Error() != tt.expectErr || !errors.Is(err, ErrCrossDataSource) {
+					t.Fatalf("expected %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil || source != tt.expect {
+				t.Fatalf("expected %q, got %q, %v", tt.expect, source, err)
+			}
+		})
+	}
+}
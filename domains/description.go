@@ -0,0 +1,59 @@
+package domains
+
+import "time"
+
+// DescriptionSource tells who wrote a schema description.
+type DescriptionSource string
+
+const (
+	DescriptionSourceInferred DescriptionSource = "INFERRED"
+	DescriptionSourceUser     DescriptionSource = "USER"
+	// DescriptionSourceImported descriptions come from documentation such as
+	// a dbt manifest. They replace inferred ones, never user overrides.
+	DescriptionSourceImported DescriptionSource = "IMPORTED"
+)
+
+// Description annotates a table, or one of its columns when Column is set,
+// of a tenant's schema. It is kept apart from the comments of the client
+// database so it can be reviewed and overridden.
+type Description struct {
+	TenantID  string
+	Table     string
+	Column    string
+	Text      string
+	Source    DescriptionSource
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableDescription is what the LLM infers for one table.
+type TableDescription struct {
+	Description string
+	// Columns maps column names to their description.
+	Columns map[string]string
+}
+
+// ApplyDescriptions sets Table.Description and Column.Description from the
+// stored descriptions. Descriptions of unknown tables or columns are ignored.
+func (m *DatabaseMetadata) ApplyDescriptions(descriptions []*Description) {
+	if m == nil {
+		return
+	}
+
+	byKey := make(map[[2]string]string, len(descriptions))
+	for _, description := range descriptions {
+		byKey[[2]string{description.Table, description.Column}] = description.Text
+	}
+
+	for i := range m.Tables {
+		table := &m.Tables[i]
+		if text, ok := byKey[[2]string{table.Name, ""}]; ok {
+			table.Description = text
+		}
+		for j := range table.Columns {
+			if text, ok := byKey[[2]string{table.Name, table.Columns[j].Name}]; ok {
+				table.Columns[j].Description = text
+			}
+		}
+	}
+}
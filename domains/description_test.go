@@ -0,0 +1,35 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDatabaseMetadata_ApplyDescriptions(t *testing.T) {
+	metadata := &DatabaseMetadata{
+		Tables: []Table{
+			{Name: "customers", Comments: "people who buy", Columns: []Column{{Name: "id"}, {Name: "city"}}},
+			{Name: "orders", Columns: []Column{{Name: "id"}}},
+		},
+	}
+
+	metadata.ApplyDescriptions([]*Description{
+		{Table: "customers", Text: "Registered customers", Source: DescriptionSourceUser},
+		{Table: "customers", Column: "city", Text: "City of residence", Source: DescriptionSourceInferred},
+		{Table: "customers", Column: "deleted", Text: "ignored"},
+		{Table: "invoices", Text: "ignored"},
+	})
+
+	expect := &DatabaseMetadata{
+		Tables: []Table{
+			{Name: "customers", Comments: "people who buy", Description: "Registered customers", Columns: []Column{{Name: "id"}, {Name: "city", Description: "City of residence"}}},
+			{Name: "orders", Columns: []Column{{Name: "id"}}},
+		},
+	}
+	if !reflect.DeepEqual(expect, metadata) {
+		t.Errorf("expected %+v, got %+v", expect, metadata)
+	}
+
+	var nilMetadata *DatabaseMetadata
+	nilMetadata.ApplyDescriptions([]*Description{{Table: "customers", Text: "x"}})
+}
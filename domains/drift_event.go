@@ -0,0 +1,16 @@
+package domains
+
+import "time"
+
+// DriftEvent tells that a scheduled sync found the client schema changed
+// since the last ingestion.
+type DriftEvent struct {
+	TenantID         string
+	PreviousChecksum string
+	Checksum         string
+	// Outcome is ENQUEUED when the change is being ingested, or PINNED when
+	// the workspace is pinned to a schema version and only reports it.
+	Outcome    SyncOutcome
+	Diff       SchemaDiff
+	DetectedAt time.Time
+}
@@ -0,0 +1,38 @@
+package domains
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Example is a curated question with its verified query, given to the LLM as
+// a few-shot pair when a prompt is similar to the question.
+type Example struct {
+	ID        int64
+	TenantID  string
+	Question  string
+	Query     string
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var ErrExampleNotFound = errors.New("example not found")
+
+// ExampleDocument returns the document embedded for the example. Only the
+// question is embedded, since prompts are matched against questions. Its ID
+// only depends on the tenant and the example ID, so an edited example
+// overwrites its previous vector.
+func ExampleDocument(example *Example) Vector {
+	metadata := map[string]string{
+		VectorMetadataKind:    string(DocumentKindExample),
+		VectorMetadataExample: strconv.FormatInt(example.ID, 10),
+	}
+	return Vector{
+		ID:       DocumentID(example.TenantID, metadata),
+		TenantID: example.TenantID,
+		Content:  example.Question,
+		Metadata: metadata,
+	}
+}
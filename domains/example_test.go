@@ -0,0 +1,26 @@
+package domains
+
+import "testing"
+
+func TestExampleDocument(t *testing.T) {
+	example := &Example{
+		ID:       3,
+		TenantID: "tenant_123",
+		Question: "How many orders shipped last week?",
+		Query:    "SELECT count(*) FROM orders WHERE status = 'shipped'",
+	}
+
+	document := ExampleDocument(example)
+	if document.Content != example.Question {
+		t.Errorf("unexpected content %q", document.Content)
+	}
+	if document.Kind() != DocumentKindExample || document.Metadata[VectorMetadataExample] != "3" {
+		t.Errorf("unexpected metadata %v", document.Metadata)
+	}
+
+	edited := *example
+	edited.Question = "How many orders were shipped last week?"
+	if ExampleDocument(&edited).ID != document.ID {
+		t.Error("expected an edited example to keep its document ID")
+	}
+}
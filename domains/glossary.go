@@ -0,0 +1,45 @@
+package domains
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GlossaryTerm is a piece of business vocabulary attached to a workspace,
+// such as a metric definition or a synonym of a table.
+type GlossaryTerm struct {
+	ID         int64
+	TenantID   string
+	Term       string
+	Definition string
+	Synonyms   []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+var ErrGlossaryTermNotFound = errors.New("glossary term not found")
+
+// GlossaryDocument returns the document embedded for the term, such as
+// "GMV (also: gross merchandise value): sum(order_items.price*qty) excluding refunds".
+// Its ID only depends on the tenant and the term ID, so an edited term
+// overwrites its previous vector.
+func GlossaryDocument(term *GlossaryTerm) Vector {
+	content := term.Term
+	if len(term.Synonyms) > 0 {
+		content += " (also: " + strings.Join(term.Synonyms, ", ") + ")"
+	}
+	content += ": " + term.Definition
+
+	metadata := map[string]string{
+		VectorMetadataKind:         string(DocumentKindGlossary),
+		VectorMetadataGlossaryTerm: strconv.FormatInt(term.ID, 10),
+	}
+	return Vector{
+		ID:       DocumentID(term.TenantID, metadata),
+		TenantID: term.TenantID,
+		Content:  content,
+		Metadata: metadata,
+	}
+}
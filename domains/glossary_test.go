@@ -0,0 +1,31 @@
+package domains
+
+import "testing"
+
+func TestGlossaryDocument(t *testing.T) {
+	term := &GlossaryTerm{
+		ID:         7,
+		TenantID:   "tenant_123",
+		Term:       "GMV",
+		Definition: "sum(order_items.price*qty) excluding refunds",
+		Synonyms:   []string{"gross merchandise value", "sales"},
+	}
+
+	document := GlossaryDocument(term)
+	if document.Content != "GMV (also: gross merchandise value, sales): sum(order_items.price*qty) excluding refunds" {
+		t.Errorf("unexpected content %q", document.Content)
+	}
+	if document.Kind() != DocumentKindGlossary || document.Metadata[VectorMetadataGlossaryTerm] != "7" {
+		t.Errorf("unexpected metadata %v", document.Metadata)
+	}
+
+	edited := *term
+	edited.Definition = "sum(order_items.price*qty)"
+	edited.Synonyms = nil
+	if GlossaryDocument(&edited).ID != document.ID {
+		t.Error("expected an edited term to keep its document ID")
+	}
+	if GlossaryDocument(&edited).Content != "GMV: sum(order_items.price*qty)" {
+		t.Errorf("unexpected content %q", GlossaryDocument(&edited).Content)
+	}
+}
@@ -1,5 +1,12 @@
 package domains
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+)
+
 type DatabaseMetadata struct {
 	TenantID  string // Hashed Client's DB URL
 	Tables    []Table
@@ -13,6 +20,13 @@ type Table struct {
 	Indexes     []Index
 	Constraints []Constraint
 	Comments    string
+	// Description is the inferred or user-reviewed description, kept apart
+	// from the database's own Comments.
+	Description string
+	// Source is the data source of a named workspace the table was read
+	// from, empty for the other workspaces. Omitted when empty so it leaves
+	// the table checksums of the other workspaces unchanged.
+	Source string `json:",omitempty"`
 }
 
 type Column struct {
@@ -23,6 +37,30 @@ type Column struct {
 	IsPrimaryKey bool
 	IsForeignKey bool
 	Comments     string
+	// Description is the inferred or user-reviewed description, kept apart
+	// from the database's own Comments.
+	Description string
+	// Profile is filled when column profiling is enabled during sync.
+	Profile *ColumnProfile
+}
+
+// ColumnProfile summarizes the data held by a column.
+type ColumnProfile struct {
+	RowCount      int64
+	NullCount     int64
+	DistinctCount int64
+	Min           string
+	Max           string
+	// Values lists the distinct values of a low-cardinality text column.
+	Values []string
+}
+
+// NullRatio returns the share of rows where the column is null.
+func (p *ColumnProfile) NullRatio() float64 {
+	if p == nil || p.RowCount == 0 {
+		return 0
+	}
+	return float64(p.NullCount) / float64(p.RowCount)
 }
 
 type Index struct {
@@ -45,3 +83,62 @@ type Relation struct {
 	TargetColumn string
 	RelationType string
 }
+
+// TableChecksums returns a checksum per table name. A table's checksum covers
+// its columns, indexes, constraints, comment and the relations it is the
+// source of, so any change to what is embedded for the table changes it.
+// Column profiles only count by their sampled values: row and null counts and
+// ranges move with every write, and would re-embed the table at each sync.
+func (m *DatabaseMetadata) TableChecksums() map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	checksums := make(map[string]string, len(m.Tables))
+	for _, table := range m.Tables {
+		var relations []Relation
+		for _, relation := range m.Relations {
+			if relation.SourceTable == table.Name {
+				relations = append(relations, relation)
+			}
+		}
+
+		encoded, _ := json.Marshal(struct {
+			Table     Table
+			Relations []Relation
+		}{withSampledValuesOnly(table), relations})
+		sum := sha256.Sum256(encoded)
+		checksums[table.Name] = hex.EncodeToString(sum[:])
+	}
+	return checksums
+}
+
+// withSampledValuesOnly returns a copy of the table whose column profiles
+// only keep their sampled values.
+func withSampledValuesOnly(table Table) Table {
+	table.Columns = slices.Clone(table.Columns)
+	for i, column := range table.Columns {
+		if column.Profile == nil {
+			continue
+		}
+		if len(column.Profile.Values) == 0 {
+			table.Columns[i].Profile = nil
+		} else {
+			table.Columns[i].Profile = &ColumnProfile{Values: column.Profile.Values}
+		}
+	}
+	return table
+}
+
+// SchemaFormat is the format of a schema imported without connecting to the
+// client database.
+type SchemaFormat string
+
+const (
+	SchemaFormatPostgresDDL SchemaFormat = "postgres"
+	SchemaFormatMySQLDDL    SchemaFormat = "mysql"
+	// SchemaFormatDBTManifest is the manifest.json written by dbt.
+	SchemaFormatDBTManifest SchemaFormat = "dbt"
+	// SchemaFormatPrisma is a schema.prisma file.
+	SchemaFormatPrisma SchemaFormat = "prisma"
+)
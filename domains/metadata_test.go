@@ -0,0 +1,95 @@
+package domains
+
+import (
+	"testing"
+)
+
+func TestDatabaseMetadata_TableChecksums(t *testing.T) {
+	base := func() *DatabaseMetadata {
+		return &DatabaseMetadata{
+			Tables: []Table{
+				{Name: "customers", Columns: []Column{{Name: "id", Type: "INT"}}},
+				{Name: "orders", Columns: []Column{{Name: "id", Type: "INT"}, {Name: "customer_id", Type: "INT"}}},
+			},
+			Relations: []Relation{
+				{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"},
+			},
+		}
+	}
+	original := base().TableChecksums()
+
+	tests := []struct {
+		name            string
+		mutate          func(m *DatabaseMetadata)
+		expectChanged   []string
+		expectUnchanged []string
+	}{
+		{
+			name:            "same schema",
+			mutate:          func(m *DatabaseMetadata) {},
+			expectUnchanged: []string{"customers", "orders"},
+		},
+		{
+			name:            "column type changed",
+			mutate:          func(m *DatabaseMetadata) { m.Tables[1].Columns[1].Type = "BIGINT" },
+			expectChanged:   []string{"orders"},
+			expectUnchanged: []string{"customers"},
+		},
+		{
+			name:            "comment changed",
+			mutate:          func(m *DatabaseMetadata) { m.Tables[0].Comments = "people who buy" },
+			expectChanged:   []string{"customers"},
+			expectUnchanged: []string{"orders"},
+		},
+		{
+			name:            "description changed",
+			mutate:          func(m *DatabaseMetadata) { m.Tables[1].Columns[1].Description = "buyer of the order" },
+			expectChanged:   []string{"orders"},
+			expectUnchanged: []string{"customers"},
+		},
+		{
+			name: "profile counts changed",
+			mutate: func(m *DatabaseMetadata) {
+				m.Tables[0].Columns[0].Profile = &ColumnProfile{RowCount: 1200, NullCount: 3, Min: "1", Max: "1200"}
+			},
+			expectUnchanged: []string{"customers", "orders"},
+		},
+		{
+			name: "sampled values changed",
+			mutate: func(m *DatabaseMetadata) {
+				m.Tables[0].Columns[0].Profile = &ColumnProfile{RowCount: 10, Values: []string{"1", "2"}}
+			},
+			expectChanged:   []string{"customers"},
+			expectUnchanged: []string{"orders"},
+		},
+		{
+			name:            "relation belongs to its source table",
+			mutate:          func(m *DatabaseMetadata) { m.Relations[0].RelationType = "MANY_TO_ONE" },
+			expectChanged:   []string{"orders"},
+			expectUnchanged: []string{"customers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := base()
+			tt.mutate(metadata)
+			checksums := metadata.TableChecksums()
+
+			for _, table := range tt.expectChanged {
+				if checksums[table] == original[table] {
+					t.Errorf("expected checksum of %s to change", table)
+				}
+			}
+			for _, table := range tt.expectUnchanged {
+				if checksums[table] != original[table] {
+					t.Errorf("expected checksum of %s to stay the same", table)
+				}
+			}
+		})
+	}
+
+	if (*DatabaseMetadata)(nil).TableChecksums() != nil {
+		t.Error("expected nil checksums for nil metadata")
+	}
+}
@@ -10,4 +10,18 @@ type Query struct {
 	ResultData  map[string]any
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// HistoryID identifies the query history entry recording the query, or is
+	// zero when it could not be recorded.
+	HistoryID int64
+}
+
+// QueryHistoryEntry records a query generated for a tenant's prompt.
+type QueryHistoryEntry struct {
+	ID        int64
+	TenantID  string
+	Prompt    string
+	Query     string
+	Warning   string
+	CreatedAt time.Time
 }
@@ -0,0 +1,15 @@
+package domains
+
+import "time"
+
+// SchemaVersion is an immutable snapshot of the metadata ingested for a
+// workspace. Each checksum is stored once, numbered from 1 per workspace in
+// the order it was first ingested.
+type SchemaVersion struct {
+	TenantID string
+	Version  int64
+	Checksum string
+	// Metadata is left nil when versions are listed.
+	Metadata  *DatabaseMetadata
+	CreatedAt time.Time
+}
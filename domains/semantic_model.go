@@ -0,0 +1,223 @@
+package domains
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SemanticModel holds the canonical business definitions of a workspace:
+// the metrics and dimensions the generated queries must reuse, and the join
+// paths they may follow.
+type SemanticModel struct {
+	TenantID   string      `json:"-" yaml:"-"`
+	Metrics    []Metric    `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+	Dimensions []Dimension `json:"dimensions,omitempty" yaml:"dimensions,omitempty"`
+	Joins      []JoinPath  `json:"joins,omitempty" yaml:"joins,omitempty"`
+	UpdatedAt  time.Time   `json:"-" yaml:"-"`
+}
+
+// Metric is a named aggregate, such as revenue, computed with Expression over
+// Table and restricted by Filters.
+type Metric struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Expression  string   `json:"expression" yaml:"expression"`
+	Table       string   `json:"table" yaml:"table"`
+	Filters     []string `json:"filters,omitempty" yaml:"filters,omitempty"`
+	Synonyms    []string `json:"synonyms,omitempty" yaml:"synonyms,omitempty"`
+}
+
+// Dimension is a named attribute that metrics are grouped or filtered by.
+type Dimension struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Expression  string   `json:"expression" yaml:"expression"`
+	Table       string   `json:"table" yaml:"table"`
+	Synonyms    []string `json:"synonyms,omitempty" yaml:"synonyms,omitempty"`
+}
+
+// JoinPath is an approved way of joining two tables.
+type JoinPath struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+	On   string `json:"on" yaml:"on"`
+}
+
+// Relevant returns the metrics and dimensions named in the prompt, by name or
+// synonym, along with the join paths touching their tables. It returns nil
+// when the prompt names none of them.
+func (m *SemanticModel) Relevant(prompt string) *SemanticModel {
+	if m == nil {
+		return nil
+	}
+
+	relevant := &SemanticModel{TenantID: m.TenantID, UpdatedAt: m.UpdatedAt}
+	tables := map[string]bool{}
+	for _, metric := range m.Metrics {
+		if mentions(prompt, metric.Name, metric.Synonyms) {
+			relevant.Metrics = append(relevant.Metrics, metric)
+			tables[metric.Table] = true
+		}
+	}
+	for _, dimension := range m.Dimensions {
+		if mentions(prompt, dimension.Name, dimension.Synonyms) {
+			relevant.Dimensions = append(relevant.Dimensions, dimension)
+			tables[dimension.Table] = true
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	for _, join := range m.Joins {
+		if tables[join.From] || tables[join.To] {
+			relevant.Joins = append(relevant.Joins, join)
+		}
+	}
+	return relevant
+}
+
+// Documents returns the context documents given to the LLM for the model.
+// They are not embedded, so they carry no embedding.
+func (m *SemanticModel) Documents() []Vector {
+	if m == nil {
+		return nil
+	}
+
+	documents := make([]Vector, 0, len(m.Metrics)+len(m.Dimensions)+len(m.Joins))
+	for _, metric := range m.Metrics {
+		content := "Metric " + metric.Name + withSynonyms(metric.Synonyms) + ": " + metric.Definition() +
+			withDescription(metric.Description) + ". Always compute it with this exact expression."
+		documents = append(documents, m.document(content, map[string]string{
+			VectorMetadataKind:   string(DocumentKindMetric),
+			VectorMetadataMetric: metric.Name,
+		}))
+	}
+	for _, dimension := range m.Dimensions {
+		content := "Dimension " + dimension.Name + withSynonyms(dimension.Synonyms) + ": " + dimension.Expression + " of " + dimension.Table + withDescription(dimension.Description) + "."
+		documents = append(documents, m.document(content, map[string]string{
+			VectorMetadataKind:      string(DocumentKindDimension),
+			VectorMetadataDimension: dimension.Name,
+		}))
+	}
+	for _, join := range m.Joins {
+		content := "Approved join: " + join.From + " to " + join.To + " on " + join.On + "."
+		documents = append(documents, m.document(content, map[string]string{
+			VectorMetadataKind:        string(DocumentKindJoin),
+			VectorMetadataTable:       join.From,
+			VectorMetadataTargetTable: join.To,
+		}))
+	}
+	return documents
+}
+
+func (m *SemanticModel) document(content string, metadata map[string]string) Vector {
+	return Vector{
+		ID:       DocumentID(m.TenantID, metadata),
+		TenantID: m.TenantID,
+		Content:  content,
+		Metadata: metadata,
+	}
+}
+
+// Definition returns the canonical definition of the metric: its expression,
+// base table and filters.
+func (m Metric) Definition() string {
+	definition := m.Expression + " over " + m.Table
+	if len(m.Filters) > 0 {
+		definition += " where " + strings.Join(m.Filters, " and ")
+	}
+	return definition
+}
+
+// UsedIn reports whether the query computes the metric with its canonical
+// definition: the expression and every filter appear in the query, ignoring
+// case and whitespace, and the base table is read in a FROM or JOIN clause.
+func (m Metric) UsedIn(query string) bool {
+	compacted := compact(query)
+	if !strings.Contains(compacted, compact(m.Expression)) {
+		return false
+	}
+	for _, filter := range m.Filters {
+		if !strings.Contains(compacted, compact(filter)) {
+			return false
+		}
+	}
+	return m.Table == "" || readsTable(query, m.Table)
+}
+
+var tableClause = regexp.MustCompile(`(?i)\b(?:from|join)\s+([\w."]+)`)
+
+// readsTable reports whether the query reads the table, schema-qualified or
+// not, in a FROM or JOIN clause.
+func readsTable(query, table string) bool {
+	table = strings.ToLower(table)
+	for _, match := range tableClause.FindAllStringSubmatch(query, -1) {
+		name := strings.ToLower(strings.ReplaceAll(match[1], `"`, ""))
+		if name == table || strings.HasSuffix(name, "."+table) {
+			return true
+		}
+	}
+	return false
+}
+
+func withSynonyms(synonyms []string) string {
+	if len(synonyms) == 0 {
+		return ""
+	}
+	return " (also: " + strings.Join(synonyms, ", ") + ")"
+}
+
+func withDescription(description string) string {
+	if description == "" {
+		return ""
+	}
+	return ", " + description
+}
+
+// mentions reports whether the prompt contains the name, with underscores
+// read as spaces, or one of the synonyms as whole words.
+func mentions(prompt, name string, synonyms []string) bool {
+	prompt = strings.ToLower(prompt)
+	candidates := append([]string{name, strings.ReplaceAll(name, "_", " ")}, synonyms...)
+	for _, candidate := range candidates {
+		if candidate = strings.ToLower(strings.TrimSpace(candidate)); candidate != "" && containsWord(prompt, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsWord(text, word string) bool {
+	for start := 0; ; {
+		index := strings.Index(text[start:], word)
+		if index < 0 {
+			return false
+		}
+		index += start
+		end := index + len(word)
+		if !isWordByte(text, index-1) && !isWordByte(text, end) {
+			return true
+		}
+		start = index + 1
+	}
+}
+
+func isWordByte(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return false
+	}
+	r := rune(text[i])
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func compact(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
@@ -0,0 +1,114 @@
+package domains
+
+import "testing"
+
+func testSemanticModel() *SemanticModel {
+	return &SemanticModel{
+		TenantID: "tenant_123",
+		Metrics: []Metric{
+			{
+				Name:       "revenue",
+				Expression: "SUM(order_items.price * order_items.qty)",
+				Table:      "order_items",
+				Filters:    []string{"order_items.refunded = false"},
+				Synonyms:   []string{"sales"},
+			},
+			{Name: "order_count", Expression: "COUNT(DISTINCT orders.id)", Table: "orders"},
+		},
+		Dimensions: []Dimension{
+			{Name: "region", Expression: "customers.region", Table: "customers"},
+		},
+		Joins: []JoinPath{
+			{From: "order_items", To: "orders", On: "order_items.order_id = orders.id"},
+			{From: "orders", To: "customers", On: "orders.customer_id = customers.id"},
+			{From: "products", To: "suppliers", On: "products.supplier_id = suppliers.id"},
+		},
+	}
+}
+
+func TestSemanticModel_Relevant(t *testing.T) {
+	model := testSemanticModel()
+
+	tests := []struct {
+		name          string
+		prompt        string
+		expectMetrics []string
+		expectDims    []string
+		expectJoins   int
+	}{
+		{name: "metric by synonym", prompt: "Total Sales last month", expectMetrics: []string{"revenue"}, expectJoins: 1},
+		{name: "metric name with underscores read as spaces", prompt: "order count per region", expectMetrics: []string{"order_count"}, expectDims: []string{"region"}, expectJoins: 2},
+		{name: "whole words only", prompt: "list the regional managers and wholesales", expectMetrics: nil},
+		{name: "nothing named", prompt: "list suppliers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relevant := model.Relevant(tt.prompt)
+			if len(tt.expectMetrics) == 0 && len(tt.expectDims) == 0 {
+				if relevant != nil {
+					t.Fatalf("expected nothing relevant, got %+v", relevant)
+				}
+				return
+			}
+
+			var metrics, dims []string
+			for _, metric := range relevant.Metrics {
+				metrics = append(metrics, metric.Name)
+			}
+			for _, dimension := range relevant.Dimensions {
+				dims = append(dims, dimension.Name)
+			}
+			if len(metrics) != len(tt.expectMetrics) || (len(metrics) > 0 && metrics[0] != tt.expectMetrics[0]) {
+				t.Errorf("expected metrics %v, got %v", tt.expectMetrics, metrics)
+			}
+			if len(dims) != len(tt.expectDims) {
+				t.Errorf("expected dimensions %v, got %v", tt.expectDims, dims)
+			}
+			if len(relevant.Joins) != tt.expectJoins {
+				t.Errorf("expected %d joins, got %v", tt.expectJoins, relevant.Joins)
+			}
+		})
+	}
+
+	var nilModel *SemanticModel
+	if nilModel.Relevant("revenue") != nil || nilModel.Documents() != nil {
+		t.Error("expected a nil model to have nothing relevant")
+	}
+}
+
+func TestSemanticModel_Documents(t *testing.T) {
+	documents := testSemanticModel().Relevant("revenue by region").Documents()
+	if len(documents) != 4 {
+		t.Fatalf("expected 4 documents, got %d", len(documents))
+	}
+	if documents[0].Content != "Metric revenue (also: sales): SUM(order_items.price * order_items.qty) over order_items where order_items.refunded = false. Always compute it with this exact expression." {
+		t.Errorf("unexpected metric content %q", documents[0].Content)
+	}
+	if documents[0].Kind() != DocumentKindMetric || documents[1].Kind() != DocumentKindDimension || documents[2].Kind() != DocumentKindJoin {
+		t.Errorf("unexpected kinds %v, %v, %v", documents[0].Kind(), documents[1].Kind(), documents[2].Kind())
+	}
+	if documents[2].Content != "Approved join: order_items to orders on order_items.order_id = orders.id." {
+		t.Errorf("unexpected join content %q", documents[2].Content)
+	}
+}
+
+func TestMetric_UsedIn(t *testing.T) {
+	metric := testSemanticModel().Metrics[0]
+
+	if !metric.UsedIn("select sum(order_items.price*order_items.qty) from order_items where order_items.refunded=false") {
+		t.Error("expected the canonical definition to be found regardless of case and spacing")
+	}
+	if !metric.UsedIn(`SELECT SUM(order_items.price * order_items.qty) FROM orders JOIN "public"."order_items" ON true WHERE order_items.refunded = false`) {
+		t.Error("expected the base table to be found when joined and schema-qualified")
+	}
+	if metric.UsedIn("SELECT SUM(order_items.price) FROM order_items") {
+		t.Error("expected an improvised expression to be rejected")
+	}
+	if metric.UsedIn("SELECT SUM(order_items.price * order_items.qty) FROM order_items") {
+		t.Error("expected a query without the metric filters to be rejected")
+	}
+	if metric.UsedIn("SELECT SUM(order_items.price * order_items.qty) FROM archived_items WHERE order_items.refunded = false") {
+		t.Error("expected a query not reading the base table to be rejected")
+	}
+}
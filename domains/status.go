@@ -0,0 +1,54 @@
+package domains
+
+import "time"
+
+type StatusEventType string
+
+const (
+	StatusEventInProgress StatusEventType = "IN_PROGRESS"
+	StatusEventDone       StatusEventType = "DONE"
+	StatusEventError      StatusEventType = "ERROR"
+	StatusEventWarn       StatusEventType = "WARN"
+	StatusEventCleared    StatusEventType = "CLEARED"
+	StatusEventProgress   StatusEventType = "PROGRESS"
+)
+
+// StatusEvent is a single, append-only entry of a workspace status history.
+// Progress is only set on PROGRESS events.
+type StatusEvent struct {
+	ID        int64
+	TenantID  string
+	Type      StatusEventType
+	Message   string
+	Progress  *IngestionProgress
+	CreatedAt time.Time
+}
+
+type IngestionPhase string
+
+const (
+	IngestionPhaseEmbedding IngestionPhase = "EMBEDDING"
+	IngestionPhaseUpserting IngestionPhase = "UPSERTING"
+)
+
+type IngestionProgress struct {
+	Phase     IngestionPhase `json:"phase"`
+	Done      int            `json:"done"`
+	Total     int            `json:"total"`
+	StartedAt time.Time      `json:"startedAt"`
+	ETA       time.Time      `json:"eta,omitzero"`
+}
+
+// EstimateETA extrapolates the completion time of the current phase from the
+// throughput observed since StartedAt. It returns the zero time while nothing
+// has been processed yet.
+func (p IngestionProgress) EstimateETA(now time.Time) time.Time {
+	if p.Done <= 0 || p.Total <= 0 {
+		return time.Time{}
+	}
+	if p.Done >= p.Total {
+		return now
+	}
+	perItem := now.Sub(p.StartedAt) / time.Duration(p.Done)
+	return now.Add(perItem * time.Duration(p.Total-p.Done))
+}
@@ -0,0 +1,52 @@
+package domains
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIngestionProgress_EstimateETA(t *testing.T) {
+	startedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := startedAt.Add(10 * time.Second)
+
+	tests := []struct {
+		name     string
+		progress IngestionProgress
+		expected time.Time
+	}{
+		{
+			name:     "nothing processed yet",
+			progress: IngestionProgress{Done: 0, Total: 100, StartedAt: startedAt},
+			expected: time.Time{},
+		},
+		{
+			name:     "empty phase",
+			progress: IngestionProgress{Done: 0, Total: 0, StartedAt: startedAt},
+			expected: time.Time{},
+		},
+		{
+			name:     "quarter done",
+			progress: IngestionProgress{Done: 25, Total: 100, StartedAt: startedAt},
+			expected: now.Add(30 * time.Second),
+		},
+		{
+			name:     "half done",
+			progress: IngestionProgress{Done: 50, Total: 100, StartedAt: startedAt},
+			expected: now.Add(10 * time.Second),
+		},
+		{
+			name:     "completed",
+			progress: IngestionProgress{Done: 100, Total: 100, StartedAt: startedAt},
+			expected: now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.progress.EstimateETA(now)
+			if !got.Equal(tt.expected) {
+				t.Errorf("EstimateETA() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package domains
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// SyncOptions choose the part of the client database a workspace syncs.
+// Patterns are case-insensitive path.Match globs. An empty include list
+// includes everything, and excludes win over includes. The same options are
+// enforced on generated queries, so what is filtered out can never be read.
+type SyncOptions struct {
+	// IncludeSchemas and ExcludeSchemas match the schema of schema-qualified
+	// table names, "sales" in "sales.orders". Tables named without a schema
+	// are in the default schema and are not filtered by them.
+	IncludeSchemas []string `json:"include_schemas,omitempty"`
+	ExcludeSchemas []string `json:"exclude_schemas,omitempty"`
+	// IncludeTables and ExcludeTables match both the table name and its
+	// schema-qualified name.
+	IncludeTables []string `json:"include_tables,omitempty"`
+	ExcludeTables []string `json:"exclude_tables,omitempty"`
+	// IncludeColumns and ExcludeColumns match both "table.column" and the bare
+	// column name.
+	IncludeColumns []string `json:"include_columns,omitempty"`
+	ExcludeColumns []string `json:"exclude_columns,omitempty"`
+	// SensitiveColumns are hidden like excluded columns, whatever the include
+	// patterns, and reported as sensitive when a generated query reads them.
+	SensitiveColumns []string `json:"sensitive_columns,omitempty"`
+}
+
+// QueryReference is a table, or a column of a table, read by a query, with
+// aliases resolved to their table.
+type QueryReference struct {
+	// Table is empty for a column that could not be attributed to a table.
+	Table string
+	// Column is empty for a reference to the table itself.
+	Column string
+}
+
+// IsEmpty reports whether the options keep the whole database.
+func (o *SyncOptions) IsEmpty() bool {
+	return o == nil ||
+		len(o.IncludeSchemas) == 0 && len(o.ExcludeSchemas) == 0 &&
+			len(o.IncludeTables) == 0 && len(o.ExcludeTables) == 0 &&
+			len(o.IncludeColumns) == 0 && len(o.ExcludeColumns) == 0 &&
+			len(o.SensitiveColumns) == 0
+}
+
+// Validate returns an error naming the first malformed pattern.
+func (o *SyncOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	for _, patterns := range [][]string{
+		o.IncludeSchemas, o.ExcludeSchemas,
+		o.IncludeTables, o.ExcludeTables,
+		o.IncludeColumns, o.ExcludeColumns,
+		o.SensitiveColumns,
+	} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AllowsTable reports whether the table is synced.
+func (o *SyncOptions) AllowsTable(table string) bool {
+	if o.IsEmpty() {
+		return true
+	}
+
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		schema := table[:i]
+		if matchesAny(o.ExcludeSchemas, schema) {
+			return false
+		}
+		if len(o.IncludeSchemas) > 0 && !matchesAny(o.IncludeSchemas, schema) {
+			return false
+		}
+	}
+
+	names := []string{table, table[strings.LastIndex(table, ".")+1:]}
+	if matchesAny(o.ExcludeTables, names...) {
+		return false
+	}
+	return len(o.IncludeTables) == 0 || matchesAny(o.IncludeTables, names...)
+}
+
+// AllowsColumn reports whether the column of the table is synced.
+func (o *SyncOptions) AllowsColumn(table, column string) bool {
+	if o.IsEmpty() {
+		return true
+	}
+	return o.AllowsTable(table) && o.columnHidden(table, column) == ""
+}
+
+// columnHidden returns why the column of an allowed table is filtered out, or
+// an empty string when it is synced.
+func (o *SyncOptions) columnHidden(table, column string) string {
+	names := []string{table + "." + column, column}
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		names = append(names, table[i+1:]+"."+column)
+	}
+
+	switch {
+	case matchesAny(o.SensitiveColumns, names...):
+		return "sensitive"
+	case matchesAny(o.ExcludeColumns, names...):
+		return "excluded"
+	case len(o.IncludeColumns) > 0 && !matchesAny(o.IncludeColumns, names...):
+		return "not included"
+	}
+	return ""
+}
+
+// unattributedColumnHidden is columnHidden for a column whose table is not
+// known. To stay a hard allowlist, a pattern for a qualified column hides
+// the column of every table.
+func (o *SyncOptions) unattributedColumnHidden(column string) string {
+	switch {
+	case matchesAny(columnParts(o.SensitiveColumns), column):
+		return "sensitive"
+	case matchesAny(columnParts(o.ExcludeColumns), column):
+		return "excluded"
+	case len(o.IncludeColumns) > 0 && !matchesAny(columnParts(o.IncludeColumns), column):
+		return "not included"
+	}
+	return ""
+}
+
+// Filter returns a copy of the metadata without the tables and columns the
+// options filter out, and without the indexes, constraints and relations
+// that involve them.
+func (o *SyncOptions) Filter(metadata *DatabaseMetadata) *DatabaseMetadata {
+	if metadata == nil || o.IsEmpty() {
+		return metadata
+	}
+
+	filtered := &DatabaseMetadata{TenantID: metadata.TenantID, Checksum: metadata.Checksum}
+	for _, table := range metadata.Tables {
+		if !o.AllowsTable(table.Name) {
+			continue
+		}
+
+		kept := table
+		kept.Columns = nil
+		kept.Indexes = nil
+		kept.Constraints = nil
+		for _, column := range table.Columns {
+			if o.AllowsColumn(table.Name, column.Name) {
+				kept.Columns = append(kept.Columns, column)
+			}
+		}
+		for _, index := range table.Indexes {
+			if o.allowsColumns(table.Name, index.Columns) {
+				kept.Indexes = append(kept.Indexes, index)
+			}
+		}
+		for _, constraint := range table.Constraints {
+			if o.allowsColumns(table.Name, constraint.Columns) && o.allowsReference(constraint.Reference) {
+				kept.Constraints = append(kept.Constraints, constraint)
+			}
+		}
+		filtered.Tables = append(filtered.Tables, kept)
+	}
+
+	for _, relation := range metadata.Relations {
+		if o.AllowsColumn(relation.SourceTable, relation.SourceColumn) &&
+			o.AllowsColumn(relation.TargetTable, relation.TargetColumn) {
+			filtered.Relations = append(filtered.Relations, relation)
+		}
+	}
+	return filtered
+}
+
+// Check returns an error naming the first reference of a query that the
+// options filter out.
+func (o *SyncOptions) Check(references []QueryReference) error {
+	if o.IsEmpty() {
+		return nil
+	}
+
+	for _, reference := range references {
+		if reference.Table != "" && !o.AllowsTable(reference.Table) {
+			return fmt.Errorf("table %s is not available", reference.Table)
+		}
+		if reference.Column == "" {
+			continue
+		}
+
+		name := reference.Column
+		reason := o.unattributedColumnHidden(reference.Column)
+		if reference.Table != "" {
+			name = reference.Table + "." + reference.Column
+			reason = o.columnHidden(reference.Table, reference.Column)
+		}
+		if reason != "" {
+			return fmt.Errorf("column %s is %s and must not be read", name, reason)
+		}
+	}
+	return nil
+}
+
+func (o *SyncOptions) allowsColumns(table string, columns []string) bool {
+	for _, column := range columns {
+		if !o.AllowsColumn(table, column) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsReference checks the target of a foreign key, written "table(col, ...)".
+func (o *SyncOptions) allowsReference(reference string) bool {
+	table, columns, ok := strings.Cut(reference, "(")
+	if !ok {
+		return reference == "" || o.AllowsTable(reference)
+	}
+	for _, column := range strings.Split(strings.TrimSuffix(columns, ")"), ",") {
+		if !o.AllowsColumn(strings.TrimSpace(table), strings.TrimSpace(column)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether one of the names matches one of the patterns.
+func matchesAny(patterns []string, names ...string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, strings.ToLower(name)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// columnParts drops the table part of qualified column patterns.
+func columnParts(patterns []string) []string {
+	parts := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		parts = append(parts, pattern[strings.LastIndex(pattern, ".")+1:])
+	}
+	return parts
+}
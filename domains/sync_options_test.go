@@ -0,0 +1,163 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSyncOptionsFilter(t *testing.T) {
+	metadata := &DatabaseMetadata{
+		TenantID: "tenant",
+		Tables: []Table{
+			{
+				Name: "customers",
+				Columns: []Column{
+					{Name: "id", Type: "INT", IsPrimaryKey: true},
+					{Name: "email", Type: "TEXT"},
+					{Name: "password_hash", Type: "TEXT"},
+				},
+				Indexes: []Index{
+					{Name: "customers_pkey", Columns: []string{"id"}, Unique: true},
+					{Name: "customers_email_idx", Columns: []string{"email"}, Unique: true},
+				},
+			},
+			{
+				Name:        "orders",
+				Columns:     []Column{{Name: "id", Type: "INT"}, {Name: "customer_id", Type: "INT"}},
+				Constraints: []Constraint{{Name: "orders_customer_id_fkey", Type: "FOREIGN KEY", Columns: []string{"customer_id"}, Reference: "customers(id)"}},
+			},
+			{Name: "audit_log", Columns: []Column{{Name: "id", Type: "INT"}}},
+			{Name: "staging.orders", Columns: []Column{{Name: "id", Type: "INT"}}},
+		},
+		Relations: []Relation{
+			{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"},
+			{SourceTable: "audit_log", SourceColumn: "id", TargetTable: "orders", TargetColumn: "id"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		options *SyncOptions
+		expect  *DatabaseMetadata
+	}{
+		{
+			name:    "no options",
+			options: nil,
+			expect:  metadata,
+		},
+		{
+			name: "exclude tables, schemas and sensitive columns",
+			options: &SyncOptions{
+				ExcludeSchemas:   []string{"staging"},
+				ExcludeTables:    []string{"AUDIT_*"},
+				SensitiveColumns: []string{"*password*", "customers.email"},
+			},
+			expect: &DatabaseMetadata{
+				TenantID: "tenant",
+				Tables: []Table{
+					{
+						Name:    "customers",
+						Columns: []Column{{Name: "id", Type: "INT", IsPrimaryKey: true}},
+						Indexes: []Index{{Name: "customers_pkey", Columns: []string{"id"}, Unique: true}},
+					},
+					metadata.Tables[1],
+				},
+				Relations: metadata.Relations[:1],
+			},
+		},
+		{
+			name:    "include tables drops references to the others",
+			options: &SyncOptions{IncludeTables: []string{"orders"}},
+			expect: &DatabaseMetadata{
+				TenantID: "tenant",
+				Tables: []Table{
+					{Name: "orders", Columns: metadata.Tables[1].Columns},
+					metadata.Tables[3],
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := tt.options.Filter(metadata)
+			if !reflect.DeepEqual(tt.expect, filtered) {
+				t.Fatalf("expected %+v, got %+v", tt.expect, filtered)
+			}
+		})
+	}
+}
+
+func TestSyncOptionsCheck(t *testing.T) {
+	options := &SyncOptions{
+		IncludeSchemas:   []string{"sales"},
+		ExcludeTables:    []string{"audit_*"},
+		SensitiveColumns: []string{"customers.email"},
+	}
+
+	tests := []struct {
+		name       string
+		options    *SyncOptions
+		references []QueryReference
+		expect     string
+	}{
+		{
+			name:       "no options",
+			references: []QueryReference{{Table: "audit_log"}},
+		},
+		{
+			name:       "allowed references",
+			options:    options,
+			references: []QueryReference{{Table: "customers"}, {Table: "customers", Column: "id"}, {Table: "sales.orders"}, {Column: "total"}},
+		},
+		{
+			name:       "excluded table",
+			options:    options,
+			references: []QueryReference{{Table: "customers"}, {Table: "audit_log", Column: "id"}},
+			expect:     "table audit_log is not available",
+		},
+		{
+			name:       "schema not included",
+			options:    options,
+			references: []QueryReference{{Table: "staging.orders"}},
+			expect:     "table staging.orders is not available",
+		},
+		{
+			name:       "sensitive column",
+			options:    options,
+			references: []QueryReference{{Table: "customers", Column: "EMAIL"}},
+			expect:     "column customers.EMAIL is sensitive and must not be read",
+		},
+		{
+			name:       "unattributed sensitive column",
+			options:    options,
+			references: []QueryReference{{Column: "email"}},
+			expect:     "column email is sensitive and must not be read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Check(tt.references)
+			if tt.expect == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expect {
+				t.Fatalf("expected %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestSyncOptionsValidate(t *testing.T) {
+	if err := (&SyncOptions{ExcludeTables: []string{"audit_*", "tmp_[0-9]"}}).Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err := (&SyncOptions{SensitiveColumns: []string{"secret_["}}).Validate()
+	if err == nil || err.Error() != `invalid pattern "secret_[": syntax error in pattern` {
+		t.Fatalf("expected an invalid pattern error, got %v", err)
+	}
+}
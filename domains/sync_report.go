@@ -0,0 +1,174 @@
+package domains
+
+import "slices"
+
+// SyncOutcome tells what a sync of the client database did.
+type SyncOutcome string
+
+const (
+	// SyncOutcomeUnchanged means the schema matches the last ingestion.
+	SyncOutcomeUnchanged SyncOutcome = "UNCHANGED"
+	// SyncOutcomeEnqueued means the schema changed, or the workspace is new,
+	// and an ingestion was enqueued.
+	SyncOutcomeEnqueued SyncOutcome = "ENQUEUED"
+	// SyncOutcomeUsedCachedSchema means the client database could not be
+	// reached and the schema of the last ingestion stays in use.
+	SyncOutcomeUsedCachedSchema SyncOutcome = "USED_CACHED_SCHEMA"
+	// SyncOutcomePinned means the workspace is pinned to a schema version, so
+	// the changes are reported but not ingested.
+	SyncOutcomePinned SyncOutcome = "PINNED"
+)
+
+// SyncReport is the result of a sync of the client database.
+type SyncReport struct {
+	TenantID string
+	Outcome  SyncOutcome
+	// Checksum is the checksum of the client schema, set when it differs from
+	// the one of the last ingestion.
+	Checksum string
+	// Metadata is the new metadata, set when an ingestion was enqueued.
+	Metadata *DatabaseMetadata
+	// Diff lists the changes against the active schema version, and is empty
+	// unless an ingestion was enqueued or the workspace is pinned.
+	Diff SchemaDiff
+}
+
+// SchemaDiff lists the changes between two versions of a schema. Only the
+// schema is compared: descriptions and column profiles are ignored.
+type SchemaDiff struct {
+	AddedTables      []string
+	RemovedTables    []string
+	AlteredTables    []TableDiff
+	AddedRelations   []Relation
+	RemovedRelations []Relation
+}
+
+// TableDiff lists the changes to a table present in both versions.
+type TableDiff struct {
+	Name               string
+	Comments           *Change[string]
+	AddedColumns       []Column
+	RemovedColumns     []Column
+	AlteredColumns     []Change[Column]
+	AddedIndexes       []Index
+	RemovedIndexes     []Index
+	AlteredIndexes     []Change[Index]
+	AddedConstraints   []Constraint
+	RemovedConstraints []Constraint
+	AlteredConstraints []Change[Constraint]
+}
+
+// Change holds the two versions of an altered element.
+type Change[T any] struct {
+	Before T
+	After  T
+}
+
+// IsEmpty reports whether the two versions have the same schema.
+func (d SchemaDiff) IsEmpty() bool {
+	return len(d.AddedTables) == 0 && len(d.RemovedTables) == 0 && len(d.AlteredTables) == 0 &&
+		len(d.AddedRelations) == 0 && len(d.RemovedRelations) == 0
+}
+
+func (d TableDiff) isEmpty() bool {
+	return d.Comments == nil &&
+		len(d.AddedColumns) == 0 && len(d.RemovedColumns) == 0 && len(d.AlteredColumns) == 0 &&
+		len(d.AddedIndexes) == 0 && len(d.RemovedIndexes) == 0 && len(d.AlteredIndexes) == 0 &&
+		len(d.AddedConstraints) == 0 && len(d.RemovedConstraints) == 0 && len(d.AlteredConstraints) == 0
+}
+
+// DiffMetadata compares two versions of a schema, matching tables, columns,
+// indexes and constraints by name. A nil before reports every table and
+// relation of after as added.
+func DiffMetadata(before, after *DatabaseMetadata) SchemaDiff {
+	if before == nil {
+		before = &DatabaseMetadata{}
+	}
+	if after == nil {
+		after = &DatabaseMetadata{}
+	}
+
+	var diff SchemaDiff
+	added, removed, altered := diffByName(before.Tables, after.Tables,
+		func(table Table) string { return table.Name },
+		func(a, b Table) bool { return diffTable(a, b).isEmpty() },
+	)
+	for _, table := range added {
+		diff.AddedTables = append(diff.AddedTables, table.Name)
+	}
+	for _, table := range removed {
+		diff.RemovedTables = append(diff.RemovedTables, table.Name)
+	}
+	for _, change := range altered {
+		diff.AlteredTables = append(diff.AlteredTables, diffTable(change.Before, change.After))
+	}
+
+	for _, relation := range after.Relations {
+		if !slices.Contains(before.Relations, relation) {
+			diff.AddedRelations = append(diff.AddedRelations, relation)
+		}
+	}
+	for _, relation := range before.Relations {
+		if !slices.Contains(after.Relations, relation) {
+			diff.RemovedRelations = append(diff.RemovedRelations, relation)
+		}
+	}
+	return diff
+}
+
+func diffTable(before, after Table) TableDiff {
+	diff := TableDiff{Name: after.Name}
+	if before.Comments != after.Comments {
+		diff.Comments = &Change[string]{Before: before.Comments, After: after.Comments}
+	}
+
+	diff.AddedColumns, diff.RemovedColumns, diff.AlteredColumns = diffByName(before.Columns, after.Columns,
+		func(column Column) string { return column.Name },
+		func(a, b Column) bool { return schemaOf(a) == schemaOf(b) },
+	)
+	diff.AddedIndexes, diff.RemovedIndexes, diff.AlteredIndexes = diffByName(before.Indexes, after.Indexes,
+		func(index Index) string { return index.Name },
+		func(a, b Index) bool { return a.Unique == b.Unique && slices.Equal(a.Columns, b.Columns) },
+	)
+	diff.AddedConstraints, diff.RemovedConstraints, diff.AlteredConstraints = diffByName(before.Constraints, after.Constraints,
+		func(constraint Constraint) string { return constraint.Name },
+		func(a, b Constraint) bool {
+			return a.Type == b.Type && a.Reference == b.Reference && slices.Equal(a.Columns, b.Columns)
+		},
+	)
+	return diff
+}
+
+// schemaOf drops what a column holds besides its schema.
+func schemaOf(column Column) Column {
+	column.Description = ""
+	column.Profile = nil
+	return column
+}
+
+// diffByName returns the elements only in after, those only in before, and
+// those in both that are not equal, in the order of after.
+func diffByName[T any](before, after []T, name func(T) string, equal func(a, b T) bool) (added, removed []T, altered []Change[T]) {
+	previous := make(map[string]T, len(before))
+	for _, element := range before {
+		previous[name(element)] = element
+	}
+	current := make(map[string]bool, len(after))
+
+	for _, element := range after {
+		current[name(element)] = true
+		old, ok := previous[name(element)]
+		switch {
+		case !ok:
+			added = append(added, element)
+		case !equal(old, element):
+			altered = append(altered, Change[T]{Before: old, After: element})
+		}
+	}
+	for _, element := range before {
+		if !current[name(element)] {
+			removed = append(removed, element)
+		}
+	}
+	return added, removed, altered
+}
@@ -0,0 +1,116 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMetadata(t *testing.T) {
+	before := &DatabaseMetadata{
+		Tables: []Table{
+			{
+				Name: "customers",
+				Columns: []Column{
+					{Name: "id", Type: "INT", IsPrimaryKey: true},
+					{Name: "email", Type: "TEXT", Description: "Login e-mail"},
+				},
+			},
+			{
+				Name:    "orders",
+				Columns: []Column{{Name: "id", Type: "INT"}, {Name: "customer_id", Type: "INT"}, {Name: "note", Type: "TEXT"}},
+				Indexes: []Index{{Name: "orders_customer_idx", Columns: []string{"customer_id"}}},
+			},
+			{Name: "legacy", Columns: []Column{{Name: "id", Type: "INT"}}},
+		},
+		Relations: []Relation{
+			{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"},
+		},
+	}
+	after := &DatabaseMetadata{
+		Tables: []Table{
+			{
+				Name: "customers",
+				Columns: []Column{
+					{Name: "id", Type: "INT", IsPrimaryKey: true},
+					// Descriptions and profiles are not part of the schema
+					{Name: "email", Type: "TEXT", Description: "Contact address", Profile: &ColumnProfile{RowCount: 10}},
+				},
+			},
+			{
+				Name:     "orders",
+				Comments: "One row per order",
+				Columns:  []Column{{Name: "id", Type: "BIGINT"}, {Name: "customer_id", Type: "INT"}, {Name: "placed_at", Type: "TIMESTAMP"}},
+				Indexes: []Index{
+					{Name: "orders_customer_idx", Columns: []string{"customer_id"}, Unique: true},
+					{Name: "orders_placed_at_idx", Columns: []string{"placed_at"}},
+				},
+				Constraints: []Constraint{{Name: "orders_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}}},
+			},
+			{Name: "payments", Columns: []Column{{Name: "id", Type: "INT"}}},
+		},
+		Relations: []Relation{
+			{SourceTable: "payments", SourceColumn: "order_id", TargetTable: "orders", TargetColumn: "id"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		before *DatabaseMetadata
+		after  *DatabaseMetadata
+		expect SchemaDiff
+	}{
+		{
+			name:   "same schema",
+			before: before,
+			after:  before,
+			expect: SchemaDiff{},
+		},
+		{
+			name:   "tables, columns, indexes, constraints and relations",
+			before: before,
+			after:  after,
+			expect: SchemaDiff{
+				AddedTables:   []string{"payments"},
+				RemovedTables: []string{"legacy"},
+				AlteredTables: []TableDiff{
+					{
+						Name:           "orders",
+						Comments:       &Change[string]{Before: "", After: "One row per order"},
+						AddedColumns:   []Column{{Name: "placed_at", Type: "TIMESTAMP"}},
+						RemovedColumns: []Column{{Name: "note", Type: "TEXT"}},
+						AlteredColumns: []Change[Column]{{Before: Column{Name: "id", Type: "INT"}, After: Column{Name: "id", Type: "BIGINT"}}},
+						AddedIndexes:   []Index{{Name: "orders_placed_at_idx", Columns: []string{"placed_at"}}},
+						AlteredIndexes: []Change[Index]{{
+							Before: Index{Name: "orders_customer_idx", Columns: []string{"customer_id"}},
+							After:  Index{Name: "orders_customer_idx", Columns: []string{"customer_id"}, Unique: true},
+						}},
+						AddedConstraints: []Constraint{{Name: "orders_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}}},
+					},
+				},
+				AddedRelations:   []Relation{{SourceTable: "payments", SourceColumn: "order_id", TargetTable: "orders", TargetColumn: "id"}},
+				RemovedRelations: []Relation{{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"}},
+			},
+		},
+		{
+			name:   "no previous schema",
+			before: nil,
+			after:  before,
+			expect: SchemaDiff{
+				AddedTables:    []string{"customers", "orders", "legacy"},
+				AddedRelations: before.Relations,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffMetadata(tt.before, tt.after)
+			if !reflect.DeepEqual(tt.expect, diff) {
+				t.Fatalf("expected %+v, got %+v", tt.expect, diff)
+			}
+			if diff.IsEmpty() != reflect.DeepEqual(tt.expect, SchemaDiff{}) {
+				t.Fatalf("IsEmpty returned %v for %+v", diff.IsEmpty(), diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package domains
 
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+)
+
 type Vector struct {
 	ID        string
 	TenantID  string
@@ -7,3 +13,69 @@ type Vector struct {
 	Metadata  map[string]string
 	Content   string
 }
+
+// DocumentKind tells what part of the schema a vector describes.
+type DocumentKind string
+
+const (
+	DocumentKindColumn     DocumentKind = "column"
+	DocumentKindTable      DocumentKind = "table"
+	DocumentKindRelation   DocumentKind = "relation"
+	DocumentKindIndex      DocumentKind = "index"
+	DocumentKindConstraint DocumentKind = "constraint"
+	DocumentKindValue      DocumentKind = "value"
+	DocumentKindGlossary   DocumentKind = "glossary"
+	DocumentKindExample    DocumentKind = "example"
+	DocumentKindMetric     DocumentKind = "metric"
+	DocumentKindDimension  DocumentKind = "dimension"
+	DocumentKindJoin       DocumentKind = "join"
+)
+
+// Keys of Vector.Metadata set by the ingestion document strategies and the
+// glossary, examples and semantic model. VectorMetadataSchemaVersion is not
+// part of the document ID.
+const (
+	VectorMetadataKind         = "kind"
+	VectorMetadataTable        = "table"
+	VectorMetadataColumn       = "column"
+	VectorMetadataTargetTable  = "target_table"
+	VectorMetadataTargetColumn = "target_column"
+	VectorMetadataIndex        = "index"
+	VectorMetadataConstraint   = "constraint"
+	VectorMetadataGlossaryTerm = "glossary_term"
+	VectorMetadataExample      = "example"
+	VectorMetadataMetric       = "metric"
+	VectorMetadataDimension    = "dimension"
+	// VectorMetadataSchemaVersion is the schema version a schema document was
+	// first embedded in. Incremental ingestions only re-embed the changed
+	// tables, so the documents of unchanged tables keep an older version than
+	// Workspace.ActiveVersion.
+	VectorMetadataSchemaVersion = "schema_version"
+)
+
+// Kind returns the document kind tagged on the vector, if any.
+func (v Vector) Kind() DocumentKind {
+	return DocumentKind(v.Metadata[VectorMetadataKind])
+}
+
+// DocumentID derives a stable, UUID-formatted vector ID from the tenant and
+// the metadata identifying a document, so re-ingesting the same schema object
+// overwrites its previous vector instead of adding a new one.
+func DocumentID(tenantID string, metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	hash.Write([]byte(tenantID))
+	for _, key := range keys {
+		hash.Write([]byte{0})
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write([]byte(metadata[key]))
+	}
+	sum := hash.Sum(nil)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
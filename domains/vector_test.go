@@ -0,0 +1,54 @@
+package domains
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDocumentID(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	metadata := map[string]string{VectorMetadataKind: "column", VectorMetadataTable: "orders", VectorMetadataColumn: "id"}
+
+	id := DocumentID("tenant_abc", metadata)
+	if !uuidPattern.MatchString(id) {
+		t.Fatalf("expected a UUID formatted ID, got %q", id)
+	}
+
+	tests := []struct {
+		name     string
+		tenantID string
+		metadata map[string]string
+		same     bool
+	}{
+		{
+			name:     "same document",
+			tenantID: "tenant_abc",
+			metadata: map[string]string{VectorMetadataColumn: "id", VectorMetadataTable: "orders", VectorMetadataKind: "column"},
+			same:     true,
+		},
+		{
+			name:     "other tenant",
+			tenantID: "tenant_def",
+			metadata: metadata,
+		},
+		{
+			name:     "other column",
+			tenantID: "tenant_abc",
+			metadata: map[string]string{VectorMetadataKind: "column", VectorMetadataTable: "orders", VectorMetadataColumn: "customer_id"},
+		},
+		{
+			name:     "values are not concatenated ambiguously",
+			tenantID: "tenant_abc",
+			metadata: map[string]string{VectorMetadataKind: "column", VectorMetadataTable: "ordersid", VectorMetadataColumn: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DocumentID(tt.tenantID, tt.metadata)
+			if (got == id) != tt.same {
+				t.Errorf("DocumentID() = %q, original %q, expected same: %v", got, id, tt.same)
+			}
+		})
+	}
+}
@@ -11,15 +11,77 @@ const (
 	StatusInProgress WorkspaceStatus = "IN_PROGRESS"
 	StatusDone       WorkspaceStatus = "DONE"
 	StatusError      WorkspaceStatus = "ERROR"
+	StatusWarn       WorkspaceStatus = "WARN"
 )
 
 type Workspace struct {
-	TenantID       string
+	// TenantID is the hash of the database URL for a workspace created by a
+	// sync, or the ID chosen for a named workspace.
+	TenantID string
+	// EncryptedDBURL is the database of a workspace created by a sync. Named
+	// workspaces use DataSources instead, and schema-only workspaces have
+	// neither.
 	EncryptedDBURL string
-	Status         WorkspaceStatus
-	Checksum       string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// Name and Labels describe a named workspace.
+	Name   string
+	Labels map[string]string
+	// DataSources are the databases of a named workspace, all ingested into
+	// it. Queries run on the source holding the tables they read.
+	DataSources []DataSource
+	Status      WorkspaceStatus
+	Checksum    string
+	// TableChecksums are the per-table checksums of the last successful
+	// ingestion, used to only re-embed changed tables.
+	TableChecksums map[string]string
+	// ActiveVersion is the schema version the stored vectors reflect, zero
+	// before the first ingestion.
+	ActiveVersion int64
+	// PinnedVersion, when set, is the schema version the workspace was rolled
+	// back to. Syncs then report schema changes without ingesting them.
+	PinnedVersion int64
+	// SyncOptions choose the tables and columns that are synced and that
+	// generated queries may read. Nil syncs the whole database.
+	SyncOptions *SyncOptions
+	// SyncSchedule is the cron expression of the scheduled syncs. Empty means
+	// the scheduler's default schedule.
+	SyncSchedule string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// DeletedAt is set on a soft-deleted workspace, zero otherwise.
+	DeletedAt time.Time
+}
+
+// IsDeleted reports whether the workspace was soft-deleted.
+func (w *Workspace) IsDeleted() bool {
+	return !w.DeletedAt.IsZero()
+}
+
+// IsSchemaOnly reports whether the workspace has no client database to
+// connect to.
+func (w *Workspace) IsSchemaOnly() bool {
+	return w.EncryptedDBURL == "" && len(w.DataSources) == 0
+}
+
+// DataSource returns the named data source, or the first one for an empty
+// name. It returns nil when there is no such source.
+func (w *Workspace) DataSource(name string) *DataSource {
+	for i := range w.DataSources {
+		if name == "" || w.DataSources[i].Name == name {
+			return &w.DataSources[i]
+		}
+	}
+	return nil
+}
+
+// PreferredDataSource returns the name of the preferred data source, or an
+// empty string when no source is preferred.
+func (w *Workspace) PreferredDataSource() string {
+	for _, source := range w.DataSources {
+		if source.Preferred {
+			return source.Name
+		}
+	}
+	return ""
 }
 
 var (
@@ -0,0 +1,90 @@
+package domains
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WorkspaceBundleFormat is the layout version of the bundles written by
+// WriteWorkspaceBundle. Bundles of another format are not read.
+const WorkspaceBundleFormat = 1
+
+// WorkspaceBundle is the portable backup of a workspace, to move it to another
+// installation or restore it after a loss. It holds no database URL, neither
+// plaintext nor encrypted since installations have their own encryption key;
+// the URLs are given again on import.
+type WorkspaceBundle struct {
+	Format     int       `json:"format"`
+	ExportedAt time.Time `json:"exported_at"`
+	// Workspace has its EncryptedDBURL and the URLs of its DataSources
+	// cleared. HasDBURL tells the workspace was created by a sync of a
+	// database URL.
+	Workspace *Workspace `json:"workspace"`
+	HasDBURL  bool       `json:"has_db_url,omitempty"`
+	// Aliases are the tenant IDs of rotated database URLs resolving to the
+	// workspace.
+	Aliases []string `json:"aliases,omitempty"`
+	// SchemaVersions are oldest first, with their metadata.
+	SchemaVersions []*SchemaVersion     `json:"schema_versions,omitempty"`
+	Descriptions   []*Description       `json:"descriptions,omitempty"`
+	GlossaryTerms  []*GlossaryTerm      `json:"glossary_terms,omitempty"`
+	Examples       []*Example           `json:"examples,omitempty"`
+	SemanticModel  *SemanticModel       `json:"semantic_model,omitempty"`
+	QueryHistory   []*QueryHistoryEntry `json:"query_history,omitempty"`
+	// EmbeddingModel identifies the model the Vectors were embedded with, so
+	// an installation using another model re-embeds them.
+	EmbeddingModel string   `json:"embedding_model,omitempty"`
+	Vectors        []Vector `json:"vectors,omitempty"`
+}
+
+// WorkspaceBundleURLs are the database URLs of an imported workspace: DBURL
+// for a workspace created by a sync, DataSources mapping each data source name
+// of a named workspace to its URL.
+type WorkspaceBundleURLs struct {
+	DBURL       string
+	DataSources map[string]string
+}
+
+var ErrInvalidWorkspaceBundle = errors.New("invalid workspace bundle")
+
+// WriteWorkspaceBundle writes the bundle to w as a single gzipped JSON
+// document.
+func WriteWorkspaceBundle(w io.Writer, bundle *WorkspaceBundle) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(bundle); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// ReadWorkspaceBundle reads a bundle written by WriteWorkspaceBundle. It
+// returns ErrInvalidWorkspaceBundle for a malformed bundle, one of another
+// format or one without a workspace.
+func ReadWorkspaceBundle(r io.Reader) (*WorkspaceBundle, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkspaceBundle, err)
+	}
+	defer zr.Close()
+
+	var bundle WorkspaceBundle
+	if err := json.NewDecoder(zr).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkspaceBundle, err)
+	}
+	if bundle.Format != WorkspaceBundleFormat {
+		return nil, fmt.Errorf("%w: unsupported format %d", ErrInvalidWorkspaceBundle, bundle.Format)
+	}
+	if bundle.Workspace == nil || bundle.Workspace.TenantID == "" {
+		return nil, fmt.Errorf("%w: no workspace", ErrInvalidWorkspaceBundle)
+	}
+	for _, version := range bundle.SchemaVersions {
+		if version == nil || version.Metadata == nil {
+			return nil, fmt.Errorf("%w: schema version without metadata", ErrInvalidWorkspaceBundle)
+		}
+	}
+	return &bundle, nil
+}
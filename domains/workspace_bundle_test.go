@@ -0,0 +1,90 @@
+package domains
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceBundleRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bundle := &WorkspaceBundle{
+		Format:     WorkspaceBundleFormat,
+		ExportedAt: createdAt,
+		Workspace:  &Workspace{TenantID: "sales", Name: "Sales", DataSources: []DataSource{{Name: "orders"}}, Status: StatusDone, ActiveVersion: 1, CreatedAt: createdAt},
+		SchemaVersions: []*SchemaVersion{
+			{TenantID: "sales", Version: 1, Checksum: "abc", Metadata: &DatabaseMetadata{TenantID: "sales", Tables: []Table{{Name: "orders"}}, Checksum: "abc"}, CreatedAt: createdAt},
+		},
+		GlossaryTerms:  []*GlossaryTerm{{ID: 1, TenantID: "sales", Term: "GMV", Definition: "gross merchandise value", CreatedAt: createdAt, UpdatedAt: createdAt}},
+		SemanticModel:  &SemanticModel{Metrics: []Metric{{Name: "revenue", Expression: "sum(total)", Table: "orders"}}},
+		EmbeddingModel: "text-embedding-3-small",
+		Vectors:        []Vector{{ID: "v1", TenantID: "sales", Embedding: []float32{0.1, 0.2}, Metadata: map[string]string{VectorMetadataKind: "table"}, Content: "orders"}},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteWorkspaceBundle(&buffer, bundle); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	read, err := ReadWorkspaceBundle(&buffer)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(read, bundle) {
+		t.Fatalf("expected %+v, got %+v", bundle, read)
+	}
+}
+
+func TestReadWorkspaceBundle(t *testing.T) {
+	compress := func(text string) string {
+		var buffer bytes.Buffer
+		zw := gzip.NewWriter(&buffer)
+		zw.Write([]byte(text))
+		zw.Close()
+		return buffer.String()
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:   "not gzipped",
+			input:  `{"format": 1}`,
+			expect: "invalid workspace bundle: gzip: invalid header",
+		},
+		{
+			name:   "not json",
+			input:  compress("not json"),
+			expect: "invalid workspace bundle: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:   "unsupported format",
+			input:  compress(`{"format": 2, "workspace": {"TenantID": "sales"}}`),
+			expect: "invalid workspace bundle: unsupported format 2",
+		},
+		{
+			name:   "no workspace",
+			input:  compress(`{"format": 1}`),
+			expect: "invalid workspace bundle: no workspace",
+		},
+		{
+			name:   "schema version without metadata",
+			input:  compress(`{"format": 1, "workspace": {"TenantID": "sales"}, "schema_versions": [{"Version": 1}]}`),
+			expect: "invalid workspace bundle: schema version without metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadWorkspaceBundle(strings.NewReader(tt.input))
+			if err == nil || err.Error() != tt.expect || !errors.Is(err, ErrInvalidWorkspaceBundle) {
+				t.Fatalf("expected %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package domains
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type WorkspaceSortField string
+
+const (
+	WorkspaceSortCreatedAt WorkspaceSortField = "created_at"
+	WorkspaceSortUpdatedAt WorkspaceSortField = "updated_at"
+	WorkspaceSortName      WorkspaceSortField = "name"
+	WorkspaceSortTenantID  WorkspaceSortField = "tenant_id"
+)
+
+const (
+	DefaultWorkspacePageSize = 50
+	MaxWorkspacePageSize     = 500
+)
+
+// WorkspaceQuery filters, sorts and pages a workspace listing. Zero fields do
+// not filter; all the set ones must match. Soft-deleted workspaces are only
+// listed with Deleted.
+type WorkspaceQuery struct {
+	// Statuses keeps the workspaces in any of them.
+	Statuses []WorkspaceStatus
+	// Labels keeps the workspaces having every one of these labels.
+	Labels map[string]string
+	// Search keeps the workspaces whose name or ID contains it, ignoring case.
+	Search string
+	// CreatedSince and UpdatedSince are inclusive, CreatedBefore and
+	// UpdatedBefore exclusive.
+	CreatedSince  time.Time
+	CreatedBefore time.Time
+	UpdatedSince  time.Time
+	UpdatedBefore time.Time
+	// Deleted lists the soft-deleted workspaces instead, those deleted before
+	// DeletedBefore when it is set.
+	Deleted       bool
+	DeletedBefore time.Time
+	// SortBy defaults to created_at. Ties are broken by tenant ID.
+	SortBy     WorkspaceSortField
+	Descending bool
+	// Limit is the page size, DefaultWorkspacePageSize when zero and at most
+	// MaxWorkspacePageSize.
+	Limit int
+	// Cursor is the NextCursor of the previous page, empty for the first one.
+	// It must be used with the same sort order.
+	Cursor string
+}
+
+// WorkspacePage is a page of a workspace listing. NextCursor is empty on the
+// last page.
+type WorkspacePage struct {
+	Workspaces []*Workspace
+	NextCursor string
+}
+
+// WorkspaceCursor is the position after the last workspace of a page. Value
+// is the sort key of that workspace, a time.Time for the timestamp fields and
+// a string otherwise.
+type WorkspaceCursor struct {
+	SortBy     WorkspaceSortField
+	Descending bool
+	Value      any
+	TenantID   string
+}
+
+// encodedWorkspaceCursor is the JSON form of a cursor, base64url encoded so
+// callers treat it as opaque.
+type encodedWorkspaceCursor struct {
+	SortBy     WorkspaceSortField `json:"s"`
+	Descending bool               `json:"d,omitempty"`
+	Time       time.Time          `json:"a,omitzero"`
+	Text       string             `json:"v,omitempty"`
+	TenantID   string             `json:"t"`
+}
+
+var ErrInvalidWorkspaceCursor = errors.New("invalid cursor")
+
+// Sort returns the sort field, created_at when unset.
+func (q *WorkspaceQuery) Sort() WorkspaceSortField {
+	if q == nil || q.SortBy == "" {
+		return WorkspaceSortCreatedAt
+	}
+	return q.SortBy
+}
+
+// PageSize returns the number of workspaces of a page.
+func (q *WorkspaceQuery) PageSize() int {
+	if q == nil || q.Limit <= 0 {
+		return DefaultWorkspacePageSize
+	}
+	return min(q.Limit, MaxWorkspacePageSize)
+}
+
+// Validate returns an error naming the first invalid field.
+func (q *WorkspaceQuery) Validate() error {
+	if q == nil {
+		return nil
+	}
+	for _, status := range q.Statuses {
+		switch status {
+		case StatusInProgress, StatusDone, StatusError, StatusWarn:
+		default:
+			return fmt.Errorf("invalid status %q", status)
+		}
+	}
+	for key := range q.Labels {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("label keys must not be empty")
+		}
+	}
+	if !q.CreatedSince.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedSince.Before(q.CreatedBefore) {
+		return errors.New("created since must be before created before")
+	}
+	if !q.UpdatedSince.IsZero() && !q.UpdatedBefore.IsZero() && !q.UpdatedSince.Before(q.UpdatedBefore) {
+		return errors.New("updated since must be before updated before")
+	}
+	if !q.DeletedBefore.IsZero() && !q.Deleted {
+		return errors.New("deleted before only applies to deleted workspaces")
+	}
+	switch q.Sort() {
+	case WorkspaceSortCreatedAt, WorkspaceSortUpdatedAt, WorkspaceSortName, WorkspaceSortTenantID:
+	default:
+		return fmt.Errorf("invalid sort field %q", q.SortBy)
+	}
+	if q.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	_, err := q.DecodeCursor()
+	return err
+}
+
+// DecodeCursor returns the position to start the page after, nil for the
+// first page. A cursor of another sort order is invalid.
+func (q *WorkspaceQuery) DecodeCursor() (*WorkspaceCursor, error) {
+	if q == nil || q.Cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, ErrInvalidWorkspaceCursor
+	}
+	var encoded encodedWorkspaceCursor
+	if err := json.Unmarshal(raw, &encoded); err != nil || encoded.TenantID == "" {
+		return nil, ErrInvalidWorkspaceCursor
+	}
+	if encoded.SortBy != q.Sort() || encoded.Descending != q.Descending {
+		return nil, fmt.Errorf("%w: it was issued for another sort order", ErrInvalidWorkspaceCursor)
+	}
+
+	cursor := &WorkspaceCursor{SortBy: encoded.SortBy, Descending: encoded.Descending, TenantID: encoded.TenantID}
+	switch encoded.SortBy {
+	case WorkspaceSortCreatedAt, WorkspaceSortUpdatedAt:
+		cursor.Value = encoded.Time
+	case WorkspaceSortName:
+		cursor.Value = encoded.Text
+	case WorkspaceSortTenantID:
+		cursor.Value = encoded.TenantID
+	}
+	return cursor, nil
+}
+
+// NextCursor returns the cursor of the page following the one ending with
+// last.
+func (q *WorkspaceQuery) NextCursor(last *Workspace) string {
+	encoded := encodedWorkspaceCursor{SortBy: q.Sort(), TenantID: last.TenantID}
+	if q != nil {
+		encoded.Descending = q.Descending
+	}
+	switch encoded.SortBy {
+	case WorkspaceSortCreatedAt:
+		encoded.Time = last.CreatedAt.UTC()
+	case WorkspaceSortUpdatedAt:
+		encoded.Time = last.UpdatedAt.UTC()
+	case WorkspaceSortName:
+		encoded.Text = last.Name
+	}
+	raw, _ := json.Marshal(encoded)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
@@ -0,0 +1,142 @@
+package domains
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceQueryValidate(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	nameCursor := (&WorkspaceQuery{SortBy: WorkspaceSortName}).NextCursor(&Workspace{TenantID: "sales", Name: "Sales"})
+
+	tests := []struct {
+		name   string
+		query  *WorkspaceQuery
+		expect string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:  "valid",
+			query: &WorkspaceQuery{Statuses: []WorkspaceStatus{StatusDone}, Labels: map[string]string{"team": "sales"}, CreatedSince: since, CreatedBefore: since.Add(time.Hour), SortBy: WorkspaceSortName, Limit: 10, Cursor: nameCursor},
+		},
+		{
+			name:   "invalid status",
+			query:  &WorkspaceQuery{Statuses: []WorkspaceStatus{"PAUSED"}},
+			expect: `invalid status "PAUSED"`,
+		},
+		{
+			name:   "empty label key",
+			query:  &WorkspaceQuery{Labels: map[string]string{"": "sales"}},
+			expect: "label keys must not be empty",
+		},
+		{
+			name:   "empty created range",
+			query:  &WorkspaceQuery{CreatedSince: since, CreatedBefore: since},
+			expect: "created since must be before created before",
+		},
+		{
+			name:   "deleted before without deleted",
+			query:  &WorkspaceQuery{DeletedBefore: since},
+			expect: "deleted before only applies to deleted workspaces",
+		},
+		{
+			name:   "invalid sort field",
+			query:  &WorkspaceQuery{SortBy: "status"},
+			expect: `invalid sort field "status"`,
+		},
+		{
+			name:   "negative limit",
+			query:  &WorkspaceQuery{Limit: -1},
+			expect: "limit must not be negative",
+		},
+		{
+			name:   "malformed cursor",
+			query:  &WorkspaceQuery{Cursor: "not a cursor"},
+			expect: "invalid cursor",
+		},
+		{
+			name:   "cursor of another sort order",
+			query:  &WorkspaceQuery{SortBy: WorkspaceSortName, Descending: true, Cursor: nameCursor},
+			expect: "invalid cursor: it was issued for another sort order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.expect == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expect {
+				t.Fatalf("expected %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceQueryCursor(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 10, 30, 0, 123456000, time.FixedZone("CET", 3600))
+	last := &Workspace{TenantID: "tenant_123", Name: "Sales", CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Hour)}
+
+	tests := []struct {
+		name   string
+		query  *WorkspaceQuery
+		expect *WorkspaceCursor
+	}{
+		{
+			name:   "default sort by creation",
+			query:  &WorkspaceQuery{},
+			expect: &WorkspaceCursor{SortBy: WorkspaceSortCreatedAt, Value: createdAt.UTC(), TenantID: "tenant_123"},
+		},
+		{
+			name:   "descending by update",
+			query:  &WorkspaceQuery{SortBy: WorkspaceSortUpdatedAt, Descending: true},
+			expect: &WorkspaceCursor{SortBy: WorkspaceSortUpdatedAt, Descending: true, Value: createdAt.Add(time.Hour).UTC(), TenantID: "tenant_123"},
+		},
+		{
+			name:   "by name",
+			query:  &WorkspaceQuery{SortBy: WorkspaceSortName},
+			expect: &WorkspaceCursor{SortBy: WorkspaceSortName, Value: "Sales", TenantID: "tenant_123"},
+		},
+		{
+			name:   "by tenant ID",
+			query:  &WorkspaceQuery{SortBy: WorkspaceSortTenantID},
+			expect: &WorkspaceCursor{SortBy: WorkspaceSortTenantID, Value: "tenant_123", TenantID: "tenant_123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Cursor = tt.query.NextCursor(last)
+			cursor, err := tt.query.DecodeCursor()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(cursor, tt.expect) {
+				t.Fatalf("expected %+v, got %+v", tt.expect, cursor)
+			}
+		})
+	}
+
+	t.Run("malformed cursor", func(t *testing.T) {
+		query := &WorkspaceQuery{Cursor: "e30"}
+		if _, err := query.DecodeCursor(); !errors.Is(err, ErrInvalidWorkspaceCursor) {
+			t.Fatalf("expected %v, got %v", ErrInvalidWorkspaceCursor, err)
+		}
+	})
+}
+
+func TestWorkspaceQueryPageSize(t *testing.T) {
+	for limit, expect := range map[int]int{0: DefaultWorkspacePageSize, 10: 10, MaxWorkspacePageSize + 1: MaxWorkspacePageSize} {
+		if got := (&WorkspaceQuery{Limit: limit}).PageSize(); got != expect {
+			t.Fatalf("expected %d for limit %d, got %d", expect, limit, got)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	BackupBundleInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Invalid workspace bundle",
+	}
+	BackupDBURLMissingError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Bundles hold no database URL, give the ones of the workspace",
+	}
+	BackupEmbeddingModelMissingError = model.GoNL2QueryError{
+		StatusCode: 500,
+		Message:    "BackupConfig.EmbeddingModel is required to tell which model the bundled vectors come from",
+	}
+	BackupEmbeddingCountError = model.GoNL2QueryError{
+		StatusCode: 500,
+		Message:    "The embedder returned a different number of embeddings than contents",
+	}
+)
+
+// BackupService exports a workspace to a portable bundle and imports bundles,
+// to move workspaces between installations or restore them after a loss.
+type BackupService interface {
+	// Export writes a bundle of the workspace to w: its record without its
+	// database URLs, schema versions, descriptions, glossary, examples,
+	// semantic model, query history and vectors with their embedding model.
+	Export(ctx context.Context, tenantID string, w io.Writer) error
+	// Import creates the workspace of the bundle read from r, with the
+	// database URLs given in urls. The vectors are stored in this
+	// installation's vector store, re-embedded when the bundle was embedded
+	// with another model. Nothing is kept when the import fails.
+	Import(ctx context.Context, r io.Reader, urls *model.WorkspaceBundleURLs) (*model.Workspace, error)
+}
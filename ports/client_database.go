@@ -12,4 +12,10 @@ type ClientDatabasePort interface {
 	Execute(ctx context.Context, query string) (map[string]any, error)
 	GetDatabaseMetadata(ctx context.Context) (*model.DatabaseMetadata, error)
 	ExecuteDryRun(ctx context.Context, query string) error
+	// ProfileColumn returns the row count, null count, distinct count and
+	// min/max of a column. Values is filled with the distinct values only when
+	// there are at most maxValues of them; maxValues of zero skips sampling.
+	ProfileColumn(ctx context.Context, table, column string, maxValues int) (*model.ColumnProfile, error)
+	// SampleRows returns up to limit rows of a table.
+	SampleRows(ctx context.Context, table string, limit int) ([]map[string]any, error)
 }
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	DescriptionInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Description needs a table and a text",
+	}
+	DescriptionImportInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Documentation could not be imported",
+	}
+)
+
+// DescriptionService lets users review the inferred schema descriptions of a
+// workspace and override them. Changes are embedded by the next sync.
+type DescriptionService interface {
+	List(ctx context.Context, tenantID string) ([]*model.Description, error)
+	Override(ctx context.Context, tenantID, table, column, text string) (*model.Description, error)
+	// Reset removes a description, so it is inferred again by the next sync.
+	Reset(ctx context.Context, tenantID, table, column string) error
+	// Import merges the table and column descriptions of a dbt manifest or
+	// Prisma schema over the workspace's. User overrides are kept.
+	Import(ctx context.Context, tenantID string, format model.SchemaFormat, source string) ([]*model.Description, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+type DriftNotifierPort interface {
+	// NotifyDrift publishes a schema change found by a scheduled sync, e.g. to
+	// a webhook or a message bus.
+	NotifyDrift(ctx context.Context, event *model.DriftEvent) error
+}
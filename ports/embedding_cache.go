@@ -0,0 +1,12 @@
+package ports
+
+import "context"
+
+// EmbeddingCachePort stores embeddings by cache key. Keys are built by the
+// caller and already identify the embedding model and the embedded text.
+type EmbeddingCachePort interface {
+	// GetMany returns the cached embeddings of the given keys. Missing keys
+	// are absent from the result.
+	GetMany(ctx context.Context, keys []string) (map[string][]float32, error)
+	SetMany(ctx context.Context, embeddings map[string][]float32) error
+}
@@ -0,0 +1,49 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	ExampleInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Example needs a question and a query",
+	}
+	ExampleNotFoundError = model.GoNL2QueryError{
+		StatusCode: 404,
+		Message:    "Example not found",
+	}
+	ExampleExistsError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "An example already asks this question",
+	}
+	ExampleUnverifiedError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Only safe, read-only generated queries using the canonical metrics can be promoted to examples",
+	}
+	QueryHistoryEntryNotFoundError = model.GoNL2QueryError{
+		StatusCode: 404,
+		Message:    "Query history entry not found",
+	}
+)
+
+// ExampleService manages the curated question/query pairs of a workspace.
+// Examples are embedded by question, and the ones similar to a prompt are
+// given to the LLM as few-shot pairs.
+type ExampleService interface {
+	List(ctx context.Context, tenantID string) ([]*model.Example, error)
+	Get(ctx context.Context, tenantID string, id int64) (*model.Example, error)
+	Create(ctx context.Context, example *model.Example) (*model.Example, error)
+	Update(ctx context.Context, example *model.Example) (*model.Example, error)
+	Delete(ctx context.Context, tenantID string, id int64) error
+	// Import creates an example from every line of a JSONL stream of
+	// {"question", "query", "notes"} objects. Nothing is imported when a line
+	// is invalid.
+	Import(ctx context.Context, tenantID string, r io.Reader) ([]*model.Example, error)
+	// Promote creates an example from a query history entry, as returned in
+	// Query.HistoryID by QueryService.PromptToQueryData.
+	Promote(ctx context.Context, tenantID string, historyID int64, notes string) (*model.Example, error)
+}
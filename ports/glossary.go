@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	GlossaryTermInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Glossary term needs a term and a definition",
+	}
+	GlossaryTermNotFoundError = model.GoNL2QueryError{
+		StatusCode: 404,
+		Message:    "Glossary term not found",
+	}
+	GlossaryTermExistsError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "Glossary term already exists",
+	}
+)
+
+// GlossaryService manages the business vocabulary of a workspace. Every term
+// is embedded into the tenant's vectors and given to the LLM with every
+// prompt.
+type GlossaryService interface {
+	List(ctx context.Context, tenantID string) ([]*model.GlossaryTerm, error)
+	Get(ctx context.Context, tenantID string, id int64) (*model.GlossaryTerm, error)
+	Create(ctx context.Context, term *model.GlossaryTerm) (*model.GlossaryTerm, error)
+	Update(ctx context.Context, term *model.GlossaryTerm) (*model.GlossaryTerm, error)
+	Delete(ctx context.Context, tenantID string, id int64) error
+}
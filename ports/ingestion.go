@@ -6,6 +6,13 @@ import (
 	model "github.com/kamil5b/go-nl2query-lib/domains"
 )
 
+var (
+	IngestionEmbeddingCountError = model.GoNL2QueryError{
+		StatusCode: 500,
+		Message:    "The embedder returned a different number of embeddings than documents",
+	}
+)
+
 type IngestionService interface {
 	VectorizeAndStore(ctx context.Context, metadata *model.DatabaseMetadata) error
 }
@@ -9,8 +9,69 @@ import (
 type InternalDatabasePort interface {
 	Connect(ctx context.Context, dbURL string) error
 	Close() error
-	ListAllWorkspaces(ctx context.Context) ([]*model.Workspace, error)
+	// ListAllWorkspaces returns a page of the workspaces matching the query,
+	// which has been validated.
+	ListAllWorkspaces(ctx context.Context, query *model.WorkspaceQuery) (*model.WorkspacePage, error)
 	DeleteWorkspaceByTenantID(ctx context.Context, tenantID string) error
 	GetWorkspaceByTenantID(ctx context.Context, tenantID string) (*model.Workspace, error)
 	UpsertWorkspace(ctx context.Context, workspace *model.Workspace) error
+	AppendStatusEvent(ctx context.Context, event *model.StatusEvent) error
+	// AppendStatusTransition appends the event and sets the status of the
+	// tenant's workspace, if there is one, in the same transaction.
+	AppendStatusTransition(ctx context.Context, event *model.StatusEvent, status model.WorkspaceStatus) error
+	ListStatusEventsByTenantID(ctx context.Context, tenantID string) ([]*model.StatusEvent, error)
+	GetLatestStatusEventByTenantID(ctx context.Context, tenantID string, eventTypes []model.StatusEventType) (*model.StatusEvent, error)
+	DeleteStatusEventsByTenantID(ctx context.Context, tenantID string) error
+	AppendQueryHistory(ctx context.Context, entry *model.QueryHistoryEntry) error
+	ListQueryHistoryByTenantID(ctx context.Context, tenantID string) ([]*model.QueryHistoryEntry, error)
+	// GetQueryHistoryEntry returns nil when the tenant has no entry with that ID.
+	GetQueryHistoryEntry(ctx context.Context, tenantID string, id int64) (*model.QueryHistoryEntry, error)
+	DeleteQueryHistoryByTenantID(ctx context.Context, tenantID string) error
+	ListDescriptionsByTenantID(ctx context.Context, tenantID string) ([]*model.Description, error)
+	// UpsertDescription stores the description of description.Table, or of
+	// its column when description.Column is set, replacing any previous one.
+	UpsertDescription(ctx context.Context, description *model.Description) error
+	DeleteDescription(ctx context.Context, tenantID, table, column string) error
+	DeleteDescriptionsByTenantID(ctx context.Context, tenantID string) error
+	ListGlossaryTermsByTenantID(ctx context.Context, tenantID string) ([]*model.GlossaryTerm, error)
+	// GetGlossaryTerm returns nil when the tenant has no term with that ID.
+	GetGlossaryTerm(ctx context.Context, tenantID string, id int64) (*model.GlossaryTerm, error)
+	// UpsertGlossaryTerm inserts the term when term.ID is zero and updates it
+	// otherwise, returning model.ErrGlossaryTermNotFound for an unknown ID.
+	UpsertGlossaryTerm(ctx context.Context, term *model.GlossaryTerm) error
+	DeleteGlossaryTerm(ctx context.Context, tenantID string, id int64) error
+	DeleteGlossaryTermsByTenantID(ctx context.Context, tenantID string) error
+	ListExamplesByTenantID(ctx context.Context, tenantID string) ([]*model.Example, error)
+	// GetExample returns nil when the tenant has no example with that ID.
+	GetExample(ctx context.Context, tenantID string, id int64) (*model.Example, error)
+	// UpsertExample inserts the example when example.ID is zero and updates it
+	// otherwise, returning model.ErrExampleNotFound for an unknown ID.
+	UpsertExample(ctx context.Context, example *model.Example) error
+	DeleteExample(ctx context.Context, tenantID string, id int64) error
+	DeleteExamplesByTenantID(ctx context.Context, tenantID string) error
+	// GetSemanticModel returns nil when the tenant has no semantic model.
+	GetSemanticModel(ctx context.Context, tenantID string) (*model.SemanticModel, error)
+	// UpsertSemanticModel replaces the tenant's semantic model.
+	UpsertSemanticModel(ctx context.Context, semanticModel *model.SemanticModel) error
+	DeleteSemanticModel(ctx context.Context, tenantID string) error
+	// SaveSchemaVersion stores metadata as the tenant's next schema version
+	// and returns it without its metadata. When a version with the same
+	// checksum exists, that version is returned unchanged instead.
+	SaveSchemaVersion(ctx context.Context, metadata *model.DatabaseMetadata) (*model.SchemaVersion, error)
+	// GetSchemaVersion returns nil when the tenant has no such version.
+	GetSchemaVersion(ctx context.Context, tenantID string, version int64) (*model.SchemaVersion, error)
+	// ListSchemaVersionsByTenantID returns the versions without their
+	// metadata, oldest first.
+	ListSchemaVersionsByTenantID(ctx context.Context, tenantID string) ([]*model.SchemaVersion, error)
+	DeleteSchemaVersionsByTenantID(ctx context.Context, tenantID string) error
+	// SaveTenantAlias makes the tenant ID generated from a rotated database
+	// URL resolve to the tenant the URL was rotated into.
+	SaveTenantAlias(ctx context.Context, alias, tenantID string) error
+	// GetTenantIDByAlias returns an empty string when alias is not the alias
+	// of any tenant.
+	GetTenantIDByAlias(ctx context.Context, alias string) (string, error)
+	// ListTenantAliasesByTenantID returns the aliases pointing to the tenant,
+	// sorted.
+	ListTenantAliasesByTenantID(ctx context.Context, tenantID string) ([]string, error)
+	DeleteTenantAliasesByTenantID(ctx context.Context, tenantID string) error
 }
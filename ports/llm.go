@@ -7,5 +7,10 @@ import (
 )
 
 type LLMPort interface {
-	GenerateQuery(ctx context.Context, prompt string, contexts []model.Vector, additionalPrompts ...string) (*string, error)
+	// GenerateQuery writes a query answering the prompt from the schema
+	// contexts, following the examples as few-shot question/query pairs.
+	GenerateQuery(ctx context.Context, prompt string, contexts []model.Vector, examples []*model.Example, additionalPrompts ...string) (*string, error)
+	// DescribeTable writes concise descriptions of a table and its columns
+	// from its shape, the relations it takes part in and a few sampled rows.
+	DescribeTable(ctx context.Context, table model.Table, relations []model.Relation, sampleRows []map[string]any) (*model.TableDescription, error)
 }
@@ -11,6 +11,9 @@ const (
 	QueryServiceWarnUseExistingClientDatabaseError = "Will using existing stored schema because connection to client database could not be established."
 	QueryServiceWarnWontExecuteClientDatabaseError = "Query won't be executed because connection to client database could not be established."
 	QueryServiceWarnQueryGeneratedUnsafe           = "Query generated but not safe and exceeding configured limit"
+	QueryServiceWarnMetricNotCanonical             = "Query does not compute a defined metric with its canonical expression. Query won't be executed."
+	QueryServiceWarnQueryNotAllowed                = "Query reads a table or column excluded from the workspace. Query won't be executed."
+	QueryServiceWarnQueryCrossesDataSources        = "Query reads tables of several data sources. Query won't be executed."
 )
 
 type QueryService interface {
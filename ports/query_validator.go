@@ -1,6 +1,14 @@
 package ports
 
+import (
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
 type QueryValidatorPort interface {
 	IsSafe(query string) (bool, error)
 	ContainsDDLDML(query string) bool
+	// References returns the tables and columns the query reads, with
+	// aliases resolved to their table and CTE names left out. It is only
+	// called for workspaces with sync options.
+	References(query string) ([]model.QueryReference, error)
 }
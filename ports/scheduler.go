@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	SchedulerSyncIncompleteError = model.GoNL2QueryError{
+		StatusCode: 500,
+		Message:    "Some scheduled syncs failed",
+	}
+)
+
+type SchedulerService interface {
+	// Run syncs the due workspaces at every tick until ctx is done.
+	Run(ctx context.Context) error
+	// SyncDue syncs the workspaces whose schedule is due at now, and reports
+	// every failed sync in one error.
+	SyncDue(ctx context.Context, now time.Time) error
+}
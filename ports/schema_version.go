@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	SchemaVersionNotFoundError = model.GoNL2QueryError{
+		StatusCode: 404,
+		Message:    "Schema version not found",
+	}
+)
+
+// SchemaVersionService browses the schema versions ingested for a workspace
+// and rolls its retrieval back to one of them.
+type SchemaVersionService interface {
+	List(ctx context.Context, tenantID string) ([]*model.SchemaVersion, error)
+	Get(ctx context.Context, tenantID string, version int64) (*model.SchemaVersion, error)
+	// Diff returns the changes from one version to another.
+	Diff(ctx context.Context, tenantID string, from, to int64) (*model.SchemaDiff, error)
+	// Rollback pins the workspace to the version and re-ingests it. Syncs
+	// report schema changes without ingesting them until Unpin is called.
+	Rollback(ctx context.Context, tenantID string, version int64) error
+	// Unpin releases the pinned version, so the next sync ingests the current
+	// schema of the client database.
+	Unpin(ctx context.Context, tenantID string) error
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+var (
+	SemanticModelInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Semantic model is invalid",
+	}
+	SemanticModelNotFoundError = model.GoNL2QueryError{
+		StatusCode: 404,
+		Message:    "Semantic model not found",
+	}
+)
+
+// SemanticModelService manages the semantic model of a workspace: the
+// canonical metrics and dimensions, and the approved join paths. The
+// definitions named in a prompt are given to the LLM, and a query computing a
+// named metric any other way is not executed.
+type SemanticModelService interface {
+	Get(ctx context.Context, tenantID string) (*model.SemanticModel, error)
+	// Put replaces the semantic model with a YAML or JSON definition.
+	Put(ctx context.Context, tenantID string, definition []byte) (*model.SemanticModel, error)
+	Delete(ctx context.Context, tenantID string) error
+}
@@ -22,6 +22,9 @@ type StatusPort interface {
 	SetDone(ctx context.Context, tenantID string) error
 	SetError(ctx context.Context, tenantID string, message string) error
 	SetWarn(ctx context.Context, tenantID string, message string) error
+	SetProgress(ctx context.Context, tenantID string, progress model.IngestionProgress) error
 	GetStatus(ctx context.Context, tenantID string) (model.WorkspaceStatus, *string, error)
+	GetProgress(ctx context.Context, tenantID string) (*model.IngestionProgress, error)
 	Clear(ctx context.Context, tenantID string) error
+	GetHistory(ctx context.Context, tenantID string) ([]*model.StatusEvent, error)
 }
@@ -1,7 +1,16 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+)
 
 type TaskQueuePort interface {
 	EnqueueIngestionTask(ctx context.Context, tenantID string, dbURL string) error
+	// EnqueueSchemaIngestionTask ingests the metadata as given, without
+	// reading the client database again: imported, filtered by the sync
+	// options, profiled and described by a sync, or a rolled back version.
+	EnqueueSchemaIngestionTask(ctx context.Context, metadata *model.DatabaseMetadata) error
+	CancelIngestionTasks(ctx context.Context, tenantID string) error
 }
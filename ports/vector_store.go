@@ -9,6 +9,17 @@ import (
 type VectorStorePort interface {
 	Upsert(ctx context.Context, tenantID string, vectors []model.Vector) error
 	Search(ctx context.Context, tenantID string, queryEmbedding []float32, limit int) ([]model.Vector, error)
+	// SearchByFilter searches only the tenant's vectors whose Metadata
+	// contains every key/value pair of filter.
+	SearchByFilter(ctx context.Context, tenantID string, queryEmbedding []float32, filter map[string]string, limit int) ([]model.Vector, error)
 	Delete(ctx context.Context, tenantID string) error
+	// DeleteByFilter deletes the tenant's vectors whose Metadata contains every
+	// key/value pair of filter.
+	DeleteByFilter(ctx context.Context, tenantID string, filter map[string]string) error
+	// DeleteByIDs deletes the tenant's vectors with the given IDs.
+	DeleteByIDs(ctx context.Context, tenantID string, ids []string) error
 	Exists(ctx context.Context, tenantID string) (bool, error)
+	// List returns every vector of the tenant with its embedding, as needed
+	// to back the tenant up.
+	List(ctx context.Context, tenantID string) ([]model.Vector, error)
 }
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	model "github.com/kamil5b/go-nl2query-lib/domains"
 )
@@ -10,9 +11,124 @@ const (
 	WorkspaceServiceWarnUseExistingClientDatabaseError = "Will using existing stored schema because connection to client database could not be established."
 )
 
+var (
+	WorkspaceNotFoundError = model.GoNL2QueryError{
+		StatusCode: 404,
+		Message:    "Workspace not found",
+	}
+	WorkspaceDeleteIncompleteError = model.GoNL2QueryError{
+		StatusCode: 500,
+		Message:    "Workspace deletion is incomplete, retry to finish it",
+	}
+	WorkspaceDeletedError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "Workspace is deleted, restore it first",
+	}
+	WorkspaceNotDeletedError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "Workspace is not deleted",
+	}
+	WorkspaceRetentionExpiredError = model.GoNL2QueryError{
+		StatusCode: 410,
+		Message:    "Workspace was deleted beyond the retention window and can no longer be restored",
+	}
+	WorkspacePurgeIncompleteError = model.GoNL2QueryError{
+		StatusCode: 500,
+		Message:    "Purge of deleted workspaces is incomplete, the next run retries it",
+	}
+	WorkspaceSchemaInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Invalid schema",
+	}
+	WorkspaceSyncOptionsInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Invalid sync options",
+	}
+	WorkspaceSyncScheduleInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Invalid sync schedule",
+	}
+	WorkspaceSchemaOnlyError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Workspace was imported from a schema and has no database URL",
+	}
+	WorkspaceNotSchemaOnlyError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "Workspace syncs a client database, a schema cannot be imported into it",
+	}
+	WorkspaceDBURLUnreachableError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Could not connect to the client database with the new URL",
+	}
+	WorkspaceDBURLConflictError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "The new database URL belongs to another workspace",
+	}
+	WorkspaceDefinitionInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Invalid workspace definition",
+	}
+	WorkspaceAlreadyExistsError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "Workspace already exists",
+	}
+	WorkspaceNotNamedError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Workspace has no named data sources, sync it by its database URL",
+	}
+	WorkspaceNamedError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Workspace has named data sources, update them instead",
+	}
+	WorkspaceQueryInvalidError = model.GoNL2QueryError{
+		StatusCode: 400,
+		Message:    "Invalid workspace query",
+	}
+	WorkspacePinnedError = model.GoNL2QueryError{
+		StatusCode: 409,
+		Message:    "Workspace is pinned to a schema version, unpin it first",
+	}
+)
+
 type WorkspaceService interface {
 	GetByTenantID(ctx context.Context, tenantID string) (*model.Workspace, error)
-	ListAll(ctx context.Context) ([]*model.Workspace, error)
+	// ListAll returns a page of the workspaces matching the query, nil
+	// listing every workspace in pages of the default size.
+	ListAll(ctx context.Context, query *model.WorkspaceQuery) (*model.WorkspacePage, error)
+	// Delete soft-deletes the workspace: it is hidden from ListAll and its
+	// queries and syncs are rejected, but nothing is removed until the
+	// retention window ends and PurgeExpired runs.
 	Delete(ctx context.Context, tenantID string) error
-	SyncClientDatabase(ctx context.Context, dbUrl string) (result *model.DatabaseMetadata, msg *string, err error)
+	// Restore undoes Delete within the retention window.
+	Restore(ctx context.Context, tenantID string) (*model.Workspace, error)
+	// PurgeExpired removes everything stored for the workspaces deleted longer
+	// than the retention window before now.
+	PurgeExpired(ctx context.Context, now time.Time) error
+	SyncClientDatabase(ctx context.Context, dbUrl string) (report *model.SyncReport, msg *string, err error)
+	// ImportSchema creates or updates a schema-only workspace from a DDL
+	// script, dbt manifest or Prisma schema, without connecting to the client
+	// database. Its queries are generated but never executed. The report tells
+	// whether the schema was unchanged or its ingestion enqueued.
+	ImportSchema(ctx context.Context, name string, format model.SchemaFormat, source string) (*model.SyncReport, error)
+	// UpdateSyncOptions replaces the options choosing the tables and columns
+	// the workspace syncs. Generated queries are held to them at once; the
+	// stored schema follows on the next sync. Nil syncs the whole database.
+	UpdateSyncOptions(ctx context.Context, tenantID string, options *model.SyncOptions) (*model.Workspace, error)
+	// UpdateSyncSchedule sets the cron expression of the scheduled syncs of
+	// the workspace. Empty means the scheduler's default schedule.
+	UpdateSyncSchedule(ctx context.Context, tenantID string, schedule string) (*model.Workspace, error)
+	// RotateDBURL replaces the database URL of the workspace, after a password
+	// change or a host failover, keeping its tenant ID, vectors and history.
+	// Syncs with the new URL resolve to the same tenant.
+	RotateDBURL(ctx context.Context, tenantID string, dbUrl string) (*model.Workspace, error)
+	// Create stores a named workspace, identified by the ID of the
+	// definition rather than a database URL, after checking that each of its
+	// data sources connects. SyncWorkspace ingests it.
+	Create(ctx context.Context, definition *model.WorkspaceDefinition) (*model.Workspace, error)
+	// Update replaces the name, labels and data sources of a named workspace.
+	// A data source given without a URL keeps its stored one.
+	Update(ctx context.Context, definition *model.WorkspaceDefinition) (*model.Workspace, error)
+	// SyncWorkspace syncs every data source of a named workspace into it, as
+	// SyncClientDatabase does for a workspace identified by its database URL.
+	SyncWorkspace(ctx context.Context, tenantID string) (report *model.SyncReport, msg *string, err error)
 }
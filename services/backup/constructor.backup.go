@@ -0,0 +1,82 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+type BackupConfig struct {
+	// EmbeddingModel identifies the model of the embedder, such as
+	// "text-embedding-3-small". It is written in the exported bundles, and
+	// imported vectors embedded with another model are re-embedded. Required.
+	EmbeddingModel string
+
+	// EmbedBatchSize caps the contents embedded in one call when an import
+	// re-embeds the vectors. Zero means a single batch.
+	EmbedBatchSize int
+}
+
+func (c *BackupConfig) embeddingModel() string {
+	if c == nil {
+		return ""
+	}
+	return c.EmbeddingModel
+}
+
+func (c *BackupConfig) embedBatchSize(total int) int {
+	if c == nil || c.EmbedBatchSize <= 0 {
+		return max(total, 1)
+	}
+	return c.EmbedBatchSize
+}
+
+type BackupService struct {
+	Config *BackupConfig
+
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
+	encryptAdapter          ports.EncryptPort
+	hashAdapter             ports.HashPort
+	embedderAdapter         ports.EmbedderPort
+	vectorStoreAdapter      ports.VectorStorePort
+}
+
+func NewBackupService(
+	config *BackupConfig,
+
+	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
+	encryptAdapter ports.EncryptPort,
+	hashAdapter ports.HashPort,
+	embedderAdapter ports.EmbedderPort,
+	vectorStoreAdapter ports.VectorStorePort,
+) *BackupService {
+	return &BackupService{
+		Config: config,
+
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+		encryptAdapter:          encryptAdapter,
+		hashAdapter:             hashAdapter,
+		embedderAdapter:         embedderAdapter,
+		vectorStoreAdapter:      vectorStoreAdapter,
+	}
+}
+
+// discard deletes what a failed import stored, ignoring errors since the
+// import error is the one reported.
+func (s *BackupService) discard(ctx context.Context, tenantID string) {
+	for _, remove := range []func(ctx context.Context, tenantID string) error{
+		s.vectorStoreAdapter.Delete,
+		s.internalDatabaseAdapter.DeleteQueryHistoryByTenantID,
+		s.internalDatabaseAdapter.DeleteDescriptionsByTenantID,
+		s.internalDatabaseAdapter.DeleteGlossaryTermsByTenantID,
+		s.internalDatabaseAdapter.DeleteExamplesByTenantID,
+		s.internalDatabaseAdapter.DeleteSemanticModel,
+		s.internalDatabaseAdapter.DeleteSchemaVersionsByTenantID,
+		s.internalDatabaseAdapter.DeleteTenantAliasesByTenantID,
+	} {
+		_ = remove(ctx, tenantID)
+	}
+}
@@ -0,0 +1,102 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *BackupService) Export(ctx context.Context, tenantID string, w io.Writer) error {
+	// Step 1: Refuse to write a bundle that cannot tell the model of its
+	// vectors
+	if s.Config.embeddingModel() == "" {
+		return ports.BackupEmbeddingModelMissingError
+	}
+
+	// Step 2: Check status, since an ingestion rewrites the vectors while they
+	// are read
+	status, _, err := s.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if status == domains.StatusInProgress {
+		return ports.StatusInProgressError
+	}
+
+	// Step 3: Get the workspace
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if workspace == nil {
+		return ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return ports.WorkspaceDeletedError
+	}
+
+	bundle := &domains.WorkspaceBundle{
+		Format:         domains.WorkspaceBundleFormat,
+		ExportedAt:     time.Now().UTC(),
+		Workspace:      withoutURLs(workspace),
+		HasDBURL:       workspace.EncryptedDBURL != "",
+		EmbeddingModel: s.Config.embeddingModel(),
+	}
+
+	// Step 4: Collect the schema versions with their metadata
+	versions, err := s.internalDatabaseAdapter.ListSchemaVersionsByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, listed := range versions {
+		version, err := s.internalDatabaseAdapter.GetSchemaVersion(ctx, tenantID, listed.Version)
+		if err != nil {
+			return err
+		}
+		if version != nil {
+			bundle.SchemaVersions = append(bundle.SchemaVersions, version)
+		}
+	}
+
+	// Step 5: Collect the aliases and the knowledge added to the schema
+	if bundle.Aliases, err = s.internalDatabaseAdapter.ListTenantAliasesByTenantID(ctx, tenantID); err != nil {
+		return err
+	}
+	if bundle.Descriptions, err = s.internalDatabaseAdapter.ListDescriptionsByTenantID(ctx, tenantID); err != nil {
+		return err
+	}
+	if bundle.GlossaryTerms, err = s.internalDatabaseAdapter.ListGlossaryTermsByTenantID(ctx, tenantID); err != nil {
+		return err
+	}
+	if bundle.Examples, err = s.internalDatabaseAdapter.ListExamplesByTenantID(ctx, tenantID); err != nil {
+		return err
+	}
+	if bundle.SemanticModel, err = s.internalDatabaseAdapter.GetSemanticModel(ctx, tenantID); err != nil {
+		return err
+	}
+	if bundle.QueryHistory, err = s.internalDatabaseAdapter.ListQueryHistoryByTenantID(ctx, tenantID); err != nil {
+		return err
+	}
+
+	// Step 6: Collect the vectors
+	if bundle.Vectors, err = s.vectorStoreAdapter.List(ctx, tenantID); err != nil {
+		return err
+	}
+
+	// Step 7: Write the bundle
+	return domains.WriteWorkspaceBundle(w, bundle)
+}
+
+// withoutURLs returns a copy of the workspace without its database URLs.
+func withoutURLs(workspace *domains.Workspace) *domains.Workspace {
+	exported := *workspace
+	exported.EncryptedDBURL = ""
+	exported.DataSources = nil
+	for _, source := range workspace.DataSources {
+		exported.DataSources = append(exported.DataSources, domains.DataSource{Name: source.Name, Preferred: source.Preferred})
+	}
+	return &exported
+}
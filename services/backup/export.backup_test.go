@@ -0,0 +1,26 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	backupTest "github.com/kamil5b/go-nl2query-lib/testsuites/backup"
+)
+
+func TestBackupService_Export(t *testing.T) {
+	backupTest.UnitTestExport(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		vectorStoreAdapter ports.VectorStorePort,
+		embeddingModel string,
+	) ports.BackupService {
+		return NewBackupService(&BackupConfig{EmbeddingModel: embeddingModel},
+			statusAdapter,
+			internalDatabaseAdapter,
+			nil,
+			nil,
+			nil,
+			vectorStoreAdapter,
+		)
+	})
+}
@@ -0,0 +1,268 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// Import keeps the tenant ID of the bundle. The schema versions, glossary
+// terms and examples get new IDs, and the workspace and vectors are pointed
+// to them.
+func (s *BackupService) Import(ctx context.Context, r io.Reader, urls *domains.WorkspaceBundleURLs) (*domains.Workspace, error) {
+	// Step 1: Refuse to import without knowing whether the bundled vectors
+	// have to be re-embedded
+	if s.Config.embeddingModel() == "" {
+		return nil, ports.BackupEmbeddingModelMissingError
+	}
+
+	// Step 2: Read the bundle
+	bundle, err := domains.ReadWorkspaceBundle(r)
+	if err != nil {
+		invalidErr := ports.BackupBundleInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+	workspace := bundle.Workspace
+	tenantID := workspace.TenantID
+
+	// Step 3: Refuse to replace a workspace, or an alias resolving to one
+	existing, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ports.WorkspaceAlreadyExistsError
+	}
+	owner, err := s.internalDatabaseAdapter.GetTenantIDByAlias(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" {
+		return nil, ports.WorkspaceAlreadyExistsError
+	}
+
+	// Step 4: Encrypt the database URLs with this installation's key
+	aliases, err := s.attachURLs(bundle, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Refuse aliases that are the tenant ID of another workspace
+	for _, alias := range aliases {
+		owner, err := s.internalDatabaseAdapter.GetTenantIDByAlias(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		other, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if (owner != "" && owner != tenantID) || other != nil {
+			conflictErr := ports.WorkspaceDBURLConflictError
+			conflictErr.AddAdditionalErrorInfo(alias)
+			return nil, conflictErr
+		}
+	}
+
+	// Step 6: Re-embed the vectors when the bundle was embedded with another
+	// model, since their embeddings are not comparable with this one's
+	if bundle.EmbeddingModel != s.Config.embeddingModel() {
+		if err := s.reembed(ctx, bundle.Vectors); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 7: Store the bundle, discarding what was stored when it fails
+	if err := s.store(ctx, bundle, aliases); err != nil {
+		s.discard(ctx, tenantID)
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// reembed replaces the embeddings of the vectors with the ones of this
+// installation's model, embedding their contents in batches.
+func (s *BackupService) reembed(ctx context.Context, vectors []domains.Vector) error {
+	batchSize := s.Config.embedBatchSize(len(vectors))
+	for start := 0; start < len(vectors); start += batchSize {
+		end := min(start+batchSize, len(vectors))
+		contents := make([]string, 0, end-start)
+		for _, vector := range vectors[start:end] {
+			contents = append(contents, vector.Content)
+		}
+
+		embeddings, err := s.embedderAdapter.EmbedBatch(ctx, contents)
+		if err != nil {
+			return err
+		}
+		if len(embeddings) != len(contents) {
+			countErr := ports.BackupEmbeddingCountError
+			countErr.AddAdditionalErrorInfo(fmt.Sprintf("%d embeddings for %d contents", len(embeddings), len(contents)))
+			return countErr
+		}
+		for i, embedding := range embeddings {
+			vectors[start+i].Embedding = embedding
+		}
+	}
+	return nil
+}
+
+// attachURLs sets the encrypted database URLs of the bundled workspace and
+// returns its aliases, with the tenant ID of a database URL that changed since
+// the export.
+func (s *BackupService) attachURLs(bundle *domains.WorkspaceBundle, urls *domains.WorkspaceBundleURLs) ([]string, error) {
+	if urls == nil {
+		urls = &domains.WorkspaceBundleURLs{}
+	}
+	workspace := bundle.Workspace
+	aliases := bundle.Aliases
+
+	var missing []string
+	if bundle.HasDBURL {
+		if urls.DBURL == "" {
+			missing = append(missing, "database URL")
+		} else {
+			workspace.EncryptedDBURL = s.encryptAdapter.Encrypt(urls.DBURL)
+			if alias := s.hashAdapter.GenerateTenantID(urls.DBURL); alias != workspace.TenantID && !slices.Contains(aliases, alias) {
+				aliases = append(aliases, alias)
+			}
+		}
+	}
+	for i := range workspace.DataSources {
+		source := &workspace.DataSources[i]
+		dbURL := urls.DataSources[source.Name]
+		if dbURL == "" {
+			missing = append(missing, fmt.Sprintf("data source %q", source.Name))
+			continue
+		}
+		source.EncryptedDBURL = s.encryptAdapter.Encrypt(dbURL)
+	}
+
+	if len(missing) > 0 {
+		missingErr := ports.BackupDBURLMissingError
+		missingErr.AddBatchAdditionalErrorInfo(missing)
+		return nil, missingErr
+	}
+	return aliases, nil
+}
+
+// store writes the bundle under its tenant ID, the workspace last so it only
+// shows once everything else is stored.
+func (s *BackupService) store(ctx context.Context, bundle *domains.WorkspaceBundle, aliases []string) error {
+	workspace := bundle.Workspace
+	tenantID := workspace.TenantID
+
+	versions := map[int64]int64{}
+	for _, version := range bundle.SchemaVersions {
+		version.Metadata.TenantID = tenantID
+		saved, err := s.internalDatabaseAdapter.SaveSchemaVersion(ctx, version.Metadata)
+		if err != nil {
+			return err
+		}
+		versions[version.Version] = saved.Version
+	}
+	workspace.ActiveVersion = versions[workspace.ActiveVersion]
+	workspace.PinnedVersion = versions[workspace.PinnedVersion]
+
+	for _, description := range bundle.Descriptions {
+		description.TenantID = tenantID
+		if err := s.internalDatabaseAdapter.UpsertDescription(ctx, description); err != nil {
+			return err
+		}
+	}
+
+	terms := map[string]*domains.GlossaryTerm{}
+	for _, term := range bundle.GlossaryTerms {
+		id := strconv.FormatInt(term.ID, 10)
+		term.ID, term.TenantID = 0, tenantID
+		if err := s.internalDatabaseAdapter.UpsertGlossaryTerm(ctx, term); err != nil {
+			return err
+		}
+		terms[id] = term
+	}
+
+	examples := map[string]*domains.Example{}
+	for _, example := range bundle.Examples {
+		id := strconv.FormatInt(example.ID, 10)
+		example.ID, example.TenantID = 0, tenantID
+		if err := s.internalDatabaseAdapter.UpsertExample(ctx, example); err != nil {
+			return err
+		}
+		examples[id] = example
+	}
+
+	if bundle.SemanticModel != nil {
+		bundle.SemanticModel.TenantID = tenantID
+		if err := s.internalDatabaseAdapter.UpsertSemanticModel(ctx, bundle.SemanticModel); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range bundle.QueryHistory {
+		entry.ID, entry.TenantID = 0, tenantID
+		if err := s.internalDatabaseAdapter.AppendQueryHistory(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	if vectors := remapVectors(tenantID, bundle.Vectors, versions, terms, examples); len(vectors) > 0 {
+		if err := s.vectorStoreAdapter.Upsert(ctx, tenantID, vectors); err != nil {
+			return err
+		}
+	}
+
+	for _, alias := range aliases {
+		if err := s.internalDatabaseAdapter.SaveTenantAlias(ctx, alias, tenantID); err != nil {
+			return err
+		}
+	}
+
+	return s.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+}
+
+// remapVectors points the vectors to the new IDs of their schema version,
+// glossary term or example, keeping their embeddings. Vectors of a glossary
+// term or example missing from the bundle are dropped.
+func remapVectors(
+	tenantID string,
+	vectors []domains.Vector,
+	versions map[int64]int64,
+	terms map[string]*domains.GlossaryTerm,
+	examples map[string]*domains.Example,
+) []domains.Vector {
+	remapped := make([]domains.Vector, 0, len(vectors))
+	for _, vector := range vectors {
+		vector.TenantID = tenantID
+		switch vector.Kind() {
+		case domains.DocumentKindGlossary:
+			term, ok := terms[vector.Metadata[domains.VectorMetadataGlossaryTerm]]
+			if !ok {
+				continue
+			}
+			document := domains.GlossaryDocument(term)
+			document.Embedding = vector.Embedding
+			vector = document
+		case domains.DocumentKindExample:
+			example, ok := examples[vector.Metadata[domains.VectorMetadataExample]]
+			if !ok {
+				continue
+			}
+			document := domains.ExampleDocument(example)
+			document.Embedding = vector.Embedding
+			vector = document
+		default:
+			if old, err := strconv.ParseInt(vector.Metadata[domains.VectorMetadataSchemaVersion], 10, 64); err == nil && versions[old] != 0 {
+				vector.Metadata[domains.VectorMetadataSchemaVersion] = strconv.FormatInt(versions[old], 10)
+			}
+		}
+		remapped = append(remapped, vector)
+	}
+	return remapped
+}
@@ -0,0 +1,29 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	backupTest "github.com/kamil5b/go-nl2query-lib/testsuites/backup"
+)
+
+func TestBackupService_Import(t *testing.T) {
+	backupTest.UnitTestImport(t, func(
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+		hashAdapter ports.HashPort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+		embeddingModel string,
+		embedBatchSize int,
+	) ports.BackupService {
+		return NewBackupService(&BackupConfig{EmbeddingModel: embeddingModel, EmbedBatchSize: embedBatchSize},
+			nil,
+			internalDatabaseAdapter,
+			encryptAdapter,
+			hashAdapter,
+			embedderAdapter,
+			vectorStoreAdapter,
+		)
+	})
+}
@@ -0,0 +1,63 @@
+package description
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+type DescriptionConfig struct{}
+
+type DescriptionService struct {
+	Config *DescriptionConfig
+
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
+}
+
+func NewDescriptionService(
+	config *DescriptionConfig,
+
+	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
+) *DescriptionService {
+	return &DescriptionService{
+		Config: config,
+
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+	}
+}
+
+// editableWorkspace returns the tenant's workspace if its descriptions may be
+// changed: it exists, is not deleted and is not being ingested.
+func (s *DescriptionService) editableWorkspace(ctx context.Context, tenantID string) (*domains.Workspace, error) {
+	status, _, err := s.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if status == domains.StatusInProgress {
+		return nil, ports.StatusInProgressError
+	}
+
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return nil, ports.WorkspaceDeletedError
+	}
+	return workspace, nil
+}
+
+// markStale clears the workspace checksum, so the next sync runs an
+// ingestion even when the schema did not change. Only the tables whose
+// descriptions changed are re-embedded.
+func (s *DescriptionService) markStale(ctx context.Context, workspace *domains.Workspace) error {
+	workspace.Checksum = ""
+	return s.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+}
@@ -0,0 +1,81 @@
+package description
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/services/schema"
+)
+
+func (s *DescriptionService) Import(ctx context.Context, tenantID string, format domains.SchemaFormat, source string) ([]*domains.Description, error) {
+	// Step 1: Read the documentation
+	metadata, err := schema.Parse(format, source)
+	if err != nil {
+		invalidErr := ports.DescriptionImportInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+
+	// Step 2: Check the workspace can be changed
+	workspace, err := s.editableWorkspace(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Keep the user overrides and the descriptions already imported
+	existing, err := s.internalDatabaseAdapter.ListDescriptionsByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[[2]string]*domains.Description, len(existing))
+	for _, description := range existing {
+		stored[[2]string{description.Table, description.Column}] = description
+	}
+
+	// Step 4: Store the documented tables and columns as imported descriptions
+	var imported []*domains.Description
+	for _, table := range metadata.Tables {
+		documented := []*domains.Description{documentation(tenantID, table.Name, "", table.Description, table.Comments)}
+		for _, column := range table.Columns {
+			documented = append(documented, documentation(tenantID, table.Name, column.Name, column.Description, column.Comments))
+		}
+
+		for _, description := range documented {
+			current := stored[[2]string{description.Table, description.Column}]
+			if description.Text == "" || current != nil && (current.Source == domains.DescriptionSourceUser || current.Text == description.Text) {
+				continue
+			}
+			if err := s.internalDatabaseAdapter.UpsertDescription(ctx, description); err != nil {
+				return nil, err
+			}
+			imported = append(imported, description)
+		}
+	}
+
+	// Step 5: Have the next sync embed them
+	if len(imported) > 0 {
+		if err := s.markStale(ctx, workspace); err != nil {
+			return nil, err
+		}
+	}
+
+	return imported, nil
+}
+
+// documentation returns the imported description of a table or column, read
+// from its documentation or else its comment.
+func documentation(tenantID, table, column, description, comments string) *domains.Description {
+	text := strings.TrimSpace(description)
+	if text == "" {
+		text = strings.TrimSpace(comments)
+	}
+	return &domains.Description{
+		TenantID: tenantID,
+		Table:    table,
+		Column:   column,
+		Text:     text,
+		Source:   domains.DescriptionSourceImported,
+	}
+}
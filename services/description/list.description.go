@@ -0,0 +1,11 @@
+package description
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *DescriptionService) List(ctx context.Context, tenantID string) ([]*domains.Description, error) {
+	return s.internalDatabaseAdapter.ListDescriptionsByTenantID(ctx, tenantID)
+}
@@ -0,0 +1,20 @@
+package description
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	descriptionTest "github.com/kamil5b/go-nl2query-lib/testsuites/description"
+)
+
+func TestDescriptionService_List(t *testing.T) {
+	descriptionTest.UnitTestList(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.DescriptionService {
+		return NewDescriptionService(nil,
+			statusAdapter,
+			internalDatabaseAdapter,
+		)
+	})
+}
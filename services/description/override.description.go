@@ -0,0 +1,42 @@
+package description
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *DescriptionService) Override(ctx context.Context, tenantID, table, column, text string) (*domains.Description, error) {
+	// Step 1: Validate the description
+	text = strings.TrimSpace(text)
+	if table == "" || text == "" {
+		return nil, ports.DescriptionInvalidError
+	}
+
+	// Step 2: Check the workspace can be changed
+	workspace, err := s.editableWorkspace(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Store the reviewed description
+	description := &domains.Description{
+		TenantID: tenantID,
+		Table:    table,
+		Column:   column,
+		Text:     text,
+		Source:   domains.DescriptionSourceUser,
+	}
+	if err := s.internalDatabaseAdapter.UpsertDescription(ctx, description); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Have the next sync embed it
+	if err := s.markStale(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
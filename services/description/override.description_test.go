@@ -0,0 +1,20 @@
+package description
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	descriptionTest "github.com/kamil5b/go-nl2query-lib/testsuites/description"
+)
+
+func TestDescriptionService_Override(t *testing.T) {
+	descriptionTest.UnitTestOverride(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.DescriptionService {
+		return NewDescriptionService(nil,
+			statusAdapter,
+			internalDatabaseAdapter,
+		)
+	})
+}
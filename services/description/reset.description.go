@@ -0,0 +1,22 @@
+package description
+
+import (
+	"context"
+)
+
+func (s *DescriptionService) Reset(ctx context.Context, tenantID, table, column string) error {
+	// Step 1: Check the workspace can be changed
+	workspace, err := s.editableWorkspace(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	// Step 2: Remove the description; the next sync infers it again when
+	// describing is enabled
+	if err := s.internalDatabaseAdapter.DeleteDescription(ctx, tenantID, table, column); err != nil {
+		return err
+	}
+
+	// Step 3: Have the next sync embed the change
+	return s.markStale(ctx, workspace)
+}
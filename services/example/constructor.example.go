@@ -0,0 +1,119 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+type ExampleConfig struct{}
+
+type ExampleService struct {
+	Config *ExampleConfig
+
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
+	embedderAdapter         ports.EmbedderPort
+	vectorStoreAdapter      ports.VectorStorePort
+}
+
+func NewExampleService(
+	config *ExampleConfig,
+
+	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
+	embedderAdapter ports.EmbedderPort,
+	vectorStoreAdapter ports.VectorStorePort,
+) *ExampleService {
+	return &ExampleService{
+		Config: config,
+
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+		embedderAdapter:         embedderAdapter,
+		vectorStoreAdapter:      vectorStoreAdapter,
+	}
+}
+
+// normalize trims the example and rejects one without a question or a query.
+func normalize(example *domains.Example) error {
+	example.Question = strings.TrimSpace(example.Question)
+	example.Query = strings.TrimSpace(example.Query)
+	example.Notes = strings.TrimSpace(example.Notes)
+	if example.Question == "" || example.Query == "" {
+		return ports.ExampleInvalidError
+	}
+	return nil
+}
+
+// checkEditable fails when the tenant has no workspace, a deleted one, or is
+// being ingested, since a full ingestion rewrites the example vectors.
+func (s *ExampleService) checkEditable(ctx context.Context, tenantID string) error {
+	status, _, err := s.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if status == domains.StatusInProgress {
+		return ports.StatusInProgressError
+	}
+
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if workspace == nil {
+		return ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return ports.WorkspaceDeletedError
+	}
+	return nil
+}
+
+// checkUnique fails when one of the examples asks the question of another
+// example of the tenant, or of another of the examples, ignoring case.
+func (s *ExampleService) checkUnique(ctx context.Context, tenantID string, examples []*domains.Example) error {
+	existing, err := s.internalDatabaseAdapter.ListExamplesByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	taken := make(map[string]int64, len(existing))
+	for _, example := range existing {
+		taken[strings.ToLower(example.Question)] = example.ID
+	}
+
+	asked := make(map[string]bool, len(examples))
+	for _, example := range examples {
+		question := strings.ToLower(example.Question)
+		if id, ok := taken[question]; (ok && id != example.ID) || asked[question] {
+			err := ports.ExampleExistsError
+			err.AddAdditionalErrorInfo(fmt.Sprintf("question %q", example.Question))
+			return err
+		}
+		asked[question] = true
+	}
+	return nil
+}
+
+// embed stores the documents of the examples in the tenant's vectors,
+// replacing the previous ones of the same examples.
+func (s *ExampleService) embed(ctx context.Context, tenantID string, examples []*domains.Example) error {
+	documents := make([]domains.Vector, len(examples))
+	contents := make([]string, len(examples))
+	for i, example := range examples {
+		documents[i] = domains.ExampleDocument(example)
+		contents[i] = documents[i].Content
+	}
+
+	embeddings, err := s.embedderAdapter.EmbedBatch(ctx, contents)
+	if err != nil {
+		return err
+	}
+	for i := range documents[:min(len(documents), len(embeddings))] {
+		documents[i].Embedding = embeddings[i]
+	}
+	return s.vectorStoreAdapter.Upsert(ctx, tenantID, documents)
+}
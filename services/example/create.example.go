@@ -0,0 +1,37 @@
+package example
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *ExampleService) Create(ctx context.Context, example *domains.Example) (*domains.Example, error) {
+	// Step 1: Validate the example
+	if err := normalize(example); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Check the workspace can be changed
+	if err := s.checkEditable(ctx, example.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Check the question is not asked by another example
+	example.ID = 0
+	if err := s.checkUnique(ctx, example.TenantID, []*domains.Example{example}); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Store the example
+	if err := s.internalDatabaseAdapter.UpsertExample(ctx, example); err != nil {
+		return nil, err
+	}
+
+	// Step 5: Embed the question into the tenant's vectors
+	if err := s.embed(ctx, example.TenantID, []*domains.Example{example}); err != nil {
+		return nil, err
+	}
+
+	return example, nil
+}
@@ -0,0 +1,24 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	exampleTest "github.com/kamil5b/go-nl2query-lib/testsuites/example"
+)
+
+func TestExampleService_Create(t *testing.T) {
+	exampleTest.UnitTestCreate(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService {
+		return NewExampleService(nil,
+			statusAdapter,
+			internalDatabaseAdapter,
+			embedderAdapter,
+			vectorStoreAdapter,
+		)
+	})
+}
@@ -0,0 +1,36 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *ExampleService) Delete(ctx context.Context, tenantID string, id int64) error {
+	// Step 1: Check the workspace can be changed
+	if err := s.checkEditable(ctx, tenantID); err != nil {
+		return err
+	}
+
+	// Step 2: Delete the example's vector first, so a failure leaves the
+	// example in place to retry the deletion
+	if err := s.vectorStoreAdapter.DeleteByFilter(ctx, tenantID, map[string]string{
+		domains.VectorMetadataKind:    string(domains.DocumentKindExample),
+		domains.VectorMetadataExample: strconv.FormatInt(id, 10),
+	}); err != nil {
+		return err
+	}
+
+	// Step 3: Delete the example
+	if err := s.internalDatabaseAdapter.DeleteExample(ctx, tenantID, id); err != nil {
+		if errors.Is(err, domains.ErrExampleNotFound) {
+			return ports.ExampleNotFoundError
+		}
+		return err
+	}
+
+	return nil
+}
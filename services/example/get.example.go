@@ -0,0 +1,19 @@
+package example
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *ExampleService) Get(ctx context.Context, tenantID string, id int64) (*domains.Example, error) {
+	example, err := s.internalDatabaseAdapter.GetExample(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if example == nil {
+		return nil, ports.ExampleNotFoundError
+	}
+	return example, nil
+}
@@ -0,0 +1,98 @@
+package example
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// maxImportLineSize bounds a single JSONL line, which holds a whole query.
+const maxImportLineSize = 1 << 20
+
+// importLine is one line of an examples JSONL file.
+type importLine struct {
+	Question string `json:"question"`
+	Query    string `json:"query"`
+	Notes    string `json:"notes"`
+}
+
+func (s *ExampleService) Import(ctx context.Context, tenantID string, r io.Reader) ([]*domains.Example, error) {
+	// Step 1: Parse and validate every line before storing anything
+	examples, err := parseImport(tenantID, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(examples) == 0 {
+		return examples, nil
+	}
+
+	// Step 2: Check the workspace can be changed
+	if err := s.checkEditable(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Check no question is asked by another example
+	if err := s.checkUnique(ctx, tenantID, examples); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Store the examples
+	for _, example := range examples {
+		if err := s.internalDatabaseAdapter.UpsertExample(ctx, example); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 5: Embed the questions into the tenant's vectors in one batch
+	if err := s.embed(ctx, tenantID, examples); err != nil {
+		return nil, err
+	}
+
+	return examples, nil
+}
+
+// parseImport reads the examples of a JSONL stream, skipping blank lines. An
+// invalid line fails the whole import with its line number.
+func parseImport(tenantID string, r io.Reader) ([]*domains.Example, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxImportLineSize)
+
+	examples := []*domains.Example{}
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var line importLine
+		if err := json.Unmarshal([]byte(text), &line); err != nil {
+			return nil, invalidLine(number, err.Error())
+		}
+		example := &domains.Example{
+			TenantID: tenantID,
+			Question: line.Question,
+			Query:    line.Query,
+			Notes:    line.Notes,
+		}
+		if err := normalize(example); err != nil {
+			return nil, invalidLine(number, "missing question or query")
+		}
+		examples = append(examples, example)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return examples, nil
+}
+
+func invalidLine(number int, reason string) error {
+	err := ports.ExampleInvalidError
+	err.AddAdditionalErrorInfo(fmt.Sprintf("line %d: %s", number, reason))
+	return err
+}
@@ -0,0 +1,11 @@
+package example
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *ExampleService) List(ctx context.Context, tenantID string) ([]*domains.Example, error) {
+	return s.internalDatabaseAdapter.ListExamplesByTenantID(ctx, tenantID)
+}
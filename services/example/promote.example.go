@@ -0,0 +1,60 @@
+package example
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// rejectedWarnings are the query history warnings of queries that must not be
+// taught to the LLM. A query that was only not executed is still promotable.
+var rejectedWarnings = map[string]bool{
+	ports.QueryServiceWarnQueryGeneratedUnsafe: true,
+	ports.QueryServiceWarnDDLDMLDetected:       true,
+	ports.QueryServiceWarnMetricNotCanonical:   true,
+}
+
+func (s *ExampleService) Promote(ctx context.Context, tenantID string, historyID int64, notes string) (*domains.Example, error) {
+	// Step 1: Check the workspace can be changed
+	if err := s.checkEditable(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the generated query from the history
+	entry, err := s.internalDatabaseAdapter.GetQueryHistoryEntry(ctx, tenantID, historyID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ports.QueryHistoryEntryNotFoundError
+	}
+
+	// Step 3: Only promote safe, read-only queries using the canonical metrics
+	example := &domains.Example{
+		TenantID: tenantID,
+		Question: entry.Prompt,
+		Query:    entry.Query,
+		Notes:    notes,
+	}
+	if rejectedWarnings[entry.Warning] || normalize(example) != nil {
+		return nil, ports.ExampleUnverifiedError
+	}
+
+	// Step 4: Check the question is not asked by another example
+	if err := s.checkUnique(ctx, tenantID, []*domains.Example{example}); err != nil {
+		return nil, err
+	}
+
+	// Step 5: Store the example
+	if err := s.internalDatabaseAdapter.UpsertExample(ctx, example); err != nil {
+		return nil, err
+	}
+
+	// Step 6: Embed the question into the tenant's vectors
+	if err := s.embed(ctx, tenantID, []*domains.Example{example}); err != nil {
+		return nil, err
+	}
+
+	return example, nil
+}
@@ -0,0 +1,41 @@
+package example
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *ExampleService) Update(ctx context.Context, example *domains.Example) (*domains.Example, error) {
+	// Step 1: Validate the example
+	if err := normalize(example); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Check the workspace can be changed
+	if err := s.checkEditable(ctx, example.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Check the question is not asked by another example
+	if err := s.checkUnique(ctx, example.TenantID, []*domains.Example{example}); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Store the example
+	if err := s.internalDatabaseAdapter.UpsertExample(ctx, example); err != nil {
+		if errors.Is(err, domains.ErrExampleNotFound) {
+			return nil, ports.ExampleNotFoundError
+		}
+		return nil, err
+	}
+
+	// Step 5: Re-embed the question over its previous vector
+	if err := s.embed(ctx, example.TenantID, []*domains.Example{example}); err != nil {
+		return nil, err
+	}
+
+	return example, nil
+}
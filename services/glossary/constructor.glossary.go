@@ -0,0 +1,108 @@
+package glossary
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+type GlossaryConfig struct{}
+
+type GlossaryService struct {
+	Config *GlossaryConfig
+
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
+	embedderAdapter         ports.EmbedderPort
+	vectorStoreAdapter      ports.VectorStorePort
+}
+
+func NewGlossaryService(
+	config *GlossaryConfig,
+
+	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
+	embedderAdapter ports.EmbedderPort,
+	vectorStoreAdapter ports.VectorStorePort,
+) *GlossaryService {
+	return &GlossaryService{
+		Config: config,
+
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+		embedderAdapter:         embedderAdapter,
+		vectorStoreAdapter:      vectorStoreAdapter,
+	}
+}
+
+// normalize trims the term and drops empty synonyms, and rejects a term
+// without a name or a definition.
+func normalize(term *domains.GlossaryTerm) error {
+	term.Term = strings.TrimSpace(term.Term)
+	term.Definition = strings.TrimSpace(term.Definition)
+	if term.Term == "" || term.Definition == "" {
+		return ports.GlossaryTermInvalidError
+	}
+
+	synonyms := make([]string, 0, len(term.Synonyms))
+	for _, synonym := range term.Synonyms {
+		if synonym = strings.TrimSpace(synonym); synonym != "" {
+			synonyms = append(synonyms, synonym)
+		}
+	}
+	term.Synonyms = synonyms
+	return nil
+}
+
+// checkEditable fails when the tenant has no workspace, a deleted one, or is
+// being ingested, since a full ingestion rewrites the glossary vectors.
+func (s *GlossaryService) checkEditable(ctx context.Context, tenantID string) error {
+	status, _, err := s.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if status == domains.StatusInProgress {
+		return ports.StatusInProgressError
+	}
+
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if workspace == nil {
+		return ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return ports.WorkspaceDeletedError
+	}
+	return nil
+}
+
+// checkUnique fails when another term of the tenant has the same name,
+// ignoring case.
+func (s *GlossaryService) checkUnique(ctx context.Context, term *domains.GlossaryTerm) error {
+	terms, err := s.internalDatabaseAdapter.ListGlossaryTermsByTenantID(ctx, term.TenantID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range terms {
+		if existing.ID != term.ID && strings.EqualFold(existing.Term, term.Term) {
+			return ports.GlossaryTermExistsError
+		}
+	}
+	return nil
+}
+
+// embed stores the term's document in the tenant's vectors, replacing the
+// previous one of the same term.
+func (s *GlossaryService) embed(ctx context.Context, term *domains.GlossaryTerm) error {
+	document := domains.GlossaryDocument(term)
+	embedding, err := s.embedderAdapter.Embed(ctx, document.Content)
+	if err != nil {
+		return err
+	}
+	document.Embedding = embedding
+	return s.vectorStoreAdapter.Upsert(ctx, term.TenantID, []domains.Vector{document})
+}
@@ -0,0 +1,37 @@
+package glossary
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *GlossaryService) Create(ctx context.Context, term *domains.GlossaryTerm) (*domains.GlossaryTerm, error) {
+	// Step 1: Validate the term
+	if err := normalize(term); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Check the workspace can be changed
+	if err := s.checkEditable(ctx, term.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Check the term is not defined yet
+	term.ID = 0
+	if err := s.checkUnique(ctx, term); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Store the term
+	if err := s.internalDatabaseAdapter.UpsertGlossaryTerm(ctx, term); err != nil {
+		return nil, err
+	}
+
+	// Step 5: Embed the term into the tenant's vectors
+	if err := s.embed(ctx, term); err != nil {
+		return nil, err
+	}
+
+	return term, nil
+}
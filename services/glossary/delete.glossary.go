@@ -0,0 +1,36 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *GlossaryService) Delete(ctx context.Context, tenantID string, id int64) error {
+	// Step 1: Check the workspace can be changed
+	if err := s.checkEditable(ctx, tenantID); err != nil {
+		return err
+	}
+
+	// Step 2: Delete the term's vector first, so a failure leaves the term
+	// in place to retry the deletion
+	if err := s.vectorStoreAdapter.DeleteByFilter(ctx, tenantID, map[string]string{
+		domains.VectorMetadataKind:         string(domains.DocumentKindGlossary),
+		domains.VectorMetadataGlossaryTerm: strconv.FormatInt(id, 10),
+	}); err != nil {
+		return err
+	}
+
+	// Step 3: Delete the term
+	if err := s.internalDatabaseAdapter.DeleteGlossaryTerm(ctx, tenantID, id); err != nil {
+		if errors.Is(err, domains.ErrGlossaryTermNotFound) {
+			return ports.GlossaryTermNotFoundError
+		}
+		return err
+	}
+
+	return nil
+}
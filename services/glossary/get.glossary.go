@@ -0,0 +1,19 @@
+package glossary
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *GlossaryService) Get(ctx context.Context, tenantID string, id int64) (*domains.GlossaryTerm, error) {
+	term, err := s.internalDatabaseAdapter.GetGlossaryTerm(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, ports.GlossaryTermNotFoundError
+	}
+	return term, nil
+}
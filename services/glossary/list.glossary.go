@@ -0,0 +1,11 @@
+package glossary
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *GlossaryService) List(ctx context.Context, tenantID string) ([]*domains.GlossaryTerm, error) {
+	return s.internalDatabaseAdapter.ListGlossaryTermsByTenantID(ctx, tenantID)
+}
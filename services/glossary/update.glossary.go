@@ -0,0 +1,41 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *GlossaryService) Update(ctx context.Context, term *domains.GlossaryTerm) (*domains.GlossaryTerm, error) {
+	// Step 1: Validate the term
+	if err := normalize(term); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Check the workspace can be changed
+	if err := s.checkEditable(ctx, term.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Check no other term has the new name
+	if err := s.checkUnique(ctx, term); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Store the term
+	if err := s.internalDatabaseAdapter.UpsertGlossaryTerm(ctx, term); err != nil {
+		if errors.Is(err, domains.ErrGlossaryTermNotFound) {
+			return nil, ports.GlossaryTermNotFoundError
+		}
+		return nil, err
+	}
+
+	// Step 5: Re-embed the term over its previous vector
+	if err := s.embed(ctx, term); err != nil {
+		return nil, err
+	}
+
+	return term, nil
+}
@@ -0,0 +1,24 @@
+package glossary
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	glossaryTest "github.com/kamil5b/go-nl2query-lib/testsuites/glossary"
+)
+
+func TestGlossaryService_Update(t *testing.T) {
+	glossaryTest.UnitTestUpdate(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.GlossaryService {
+		return NewGlossaryService(nil,
+			statusAdapter,
+			internalDatabaseAdapter,
+			embedderAdapter,
+			vectorStoreAdapter,
+		)
+	})
+}
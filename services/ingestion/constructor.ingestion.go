@@ -2,14 +2,24 @@ package ingestion
 
 import "github.com/kamil5b/go-nl2query-lib/ports"
 
-type IngestionConfig struct{}
+type IngestionConfig struct {
+	// EmbedBatchSize and UpsertBatchSize split the work into chunks so progress
+	// can be reported between them. Zero means a single batch.
+	EmbedBatchSize  int
+	UpsertBatchSize int
+
+	// DocumentStrategies decide which documents are embedded for a schema.
+	// Empty means DefaultDocumentStrategies.
+	DocumentStrategies []DocumentStrategy
+}
 
 type IngestionService struct {
 	Config *IngestionConfig
 
-	embedderAdapter    ports.EmbedderPort
-	vectorStoreAdapter ports.VectorStorePort
-	statusAdapter      ports.StatusPort
+	embedderAdapter         ports.EmbedderPort
+	vectorStoreAdapter      ports.VectorStorePort
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
 }
 
 func NewIngestionService(
@@ -18,12 +28,42 @@ func NewIngestionService(
 	embedderAdapter ports.EmbedderPort,
 	vectorStoreAdapter ports.VectorStorePort,
 	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
 ) *IngestionService {
 	return &IngestionService{
 		Config: config,
 
-		embedderAdapter:    embedderAdapter,
-		vectorStoreAdapter: vectorStoreAdapter,
-		statusAdapter:      statusAdapter,
+		embedderAdapter:         embedderAdapter,
+		vectorStoreAdapter:      vectorStoreAdapter,
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+	}
+}
+
+func (c *IngestionConfig) embedBatchSize(total int) int {
+	if c == nil {
+		return batchSize(0, total)
+	}
+	return batchSize(c.EmbedBatchSize, total)
+}
+
+func (c *IngestionConfig) upsertBatchSize(total int) int {
+	if c == nil {
+		return batchSize(0, total)
+	}
+	return batchSize(c.UpsertBatchSize, total)
+}
+
+func (c *IngestionConfig) documentStrategies() []DocumentStrategy {
+	if c == nil || len(c.DocumentStrategies) == 0 {
+		return DefaultDocumentStrategies
+	}
+	return c.DocumentStrategies
+}
+
+func batchSize(configured, total int) int {
+	if configured > 0 {
+		return configured
 	}
+	return max(total, 1)
 }
@@ -0,0 +1,243 @@
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	toon "github.com/toon-format/toon-go"
+)
+
+// DocumentStrategy turns database metadata into the documents to embed. The
+// returned vectors carry ID, Content and Metadata; embeddings are filled in
+// later. Every document must be tagged with the table it belongs to, which is
+// how incremental ingestion finds the vectors of a changed table.
+type DocumentStrategy func(metadata *domains.DatabaseMetadata) []domains.Vector
+
+// DefaultDocumentStrategies is used when IngestionConfig.DocumentStrategies
+// is empty. ValueDocuments emits nothing unless columns were profiled.
+var DefaultDocumentStrategies = []DocumentStrategy{ColumnDocuments, ValueDocuments}
+
+// ColumnDocuments emits one document per column.
+func ColumnDocuments(metadata *domains.DatabaseMetadata) []domains.Vector {
+	if metadata == nil {
+		return nil
+	}
+
+	type Row struct {
+		TenantID    string `toon:"tenant_id"`
+		Table       string `toon:"table"`
+		Column      string `toon:"column"`
+		Type        string `toon:"type"`
+		Nullable    bool   `toon:"nullable"`
+		Default     string `toon:"default"`
+		Primary     bool   `toon:"primary_key"`
+		Foreign     bool   `toon:"foreign_key"`
+		Comment     string `toon:"comment"`
+		Description string `toon:"description"`
+	}
+
+	var docs []domains.Vector
+	for _, t := range metadata.Tables {
+		for _, c := range t.Columns {
+			row := Row{
+				TenantID:    metadata.TenantID,
+				Table:       t.Name,
+				Column:      c.Name,
+				Type:        c.Type,
+				Nullable:    c.Nullable,
+				Default:     c.Default,
+				Primary:     c.IsPrimaryKey,
+				Foreign:     c.IsForeignKey,
+				Comment:     c.Comments,
+				Description: c.Description,
+			}
+
+			encoded, err := toon.MarshalString([]Row{row}) // one column per embedding unit
+			if err != nil {
+				continue
+			}
+			docs = append(docs, document(metadata.TenantID, domains.DocumentKindColumn, encoded, map[string]string{
+				domains.VectorMetadataTable:  t.Name,
+				domains.VectorMetadataColumn: c.Name,
+			}))
+		}
+	}
+
+	return docs
+}
+
+// TableDocuments emits one document per table with its comment and all of
+// its columns.
+func TableDocuments(metadata *domains.DatabaseMetadata) []domains.Vector {
+	if metadata == nil {
+		return nil
+	}
+
+	type Column struct {
+		Name        string `toon:"name"`
+		Type        string `toon:"type"`
+		Nullable    bool   `toon:"nullable"`
+		Default     string `toon:"default"`
+		Primary     bool   `toon:"primary_key"`
+		Foreign     bool   `toon:"foreign_key"`
+		Comment     string `toon:"comment"`
+		Description string `toon:"description"`
+	}
+	type Table struct {
+		TenantID    string   `toon:"tenant_id"`
+		Table       string   `toon:"table"`
+		Comment     string   `toon:"comment"`
+		Description string   `toon:"description"`
+		Columns     []Column `toon:"columns"`
+	}
+
+	var docs []domains.Vector
+	for _, t := range metadata.Tables {
+		table := Table{
+			TenantID:    metadata.TenantID,
+			Table:       t.Name,
+			Comment:     t.Comments,
+			Description: t.Description,
+			Columns:     make([]Column, len(t.Columns)),
+		}
+		for i, c := range t.Columns {
+			table.Columns[i] = Column{
+				Name:        c.Name,
+				Type:        c.Type,
+				Nullable:    c.Nullable,
+				Default:     c.Default,
+				Primary:     c.IsPrimaryKey,
+				Foreign:     c.IsForeignKey,
+				Comment:     c.Comments,
+				Description: c.Description,
+			}
+		}
+
+		encoded, err := toon.MarshalString(table)
+		if err != nil {
+			continue
+		}
+		docs = append(docs, document(metadata.TenantID, domains.DocumentKindTable, encoded, map[string]string{
+			domains.VectorMetadataTable: t.Name,
+		}))
+	}
+
+	return docs
+}
+
+// RelationDocuments emits one join path document per relation, such as
+// "orders.customer_id -> customers.id (MANY_TO_ONE)".
+func RelationDocuments(metadata *domains.DatabaseMetadata) []domains.Vector {
+	if metadata == nil {
+		return nil
+	}
+
+	var docs []domains.Vector
+	for _, r := range metadata.Relations {
+		content := fmt.Sprintf("%s.%s -> %s.%s", r.SourceTable, r.SourceColumn, r.TargetTable, r.TargetColumn)
+		if r.SourceColumn == "" {
+			// A table-level relation, such as a dbt model built from another
+			content = fmt.Sprintf("%s -> %s", r.SourceTable, r.TargetTable)
+		}
+		if r.RelationType != "" {
+			content += " (" + r.RelationType + ")"
+		}
+		docs = append(docs, document(metadata.TenantID, domains.DocumentKindRelation, content, map[string]string{
+			domains.VectorMetadataTable:        r.SourceTable,
+			domains.VectorMetadataColumn:       r.SourceColumn,
+			domains.VectorMetadataTargetTable:  r.TargetTable,
+			domains.VectorMetadataTargetColumn: r.TargetColumn,
+		}))
+	}
+
+	return docs
+}
+
+// IndexDocuments emits one document per index and per constraint.
+func IndexDocuments(metadata *domains.DatabaseMetadata) []domains.Vector {
+	if metadata == nil {
+		return nil
+	}
+
+	var docs []domains.Vector
+	for _, t := range metadata.Tables {
+		for _, idx := range t.Indexes {
+			kind := "index"
+			if idx.Unique {
+				kind = "unique index"
+			}
+			content := fmt.Sprintf("%s %s on %s (%s)", kind, idx.Name, t.Name, strings.Join(idx.Columns, ", "))
+			docs = append(docs, document(metadata.TenantID, domains.DocumentKindIndex, content, map[string]string{
+				domains.VectorMetadataTable: t.Name,
+				domains.VectorMetadataIndex: idx.Name,
+			}))
+		}
+
+		for _, c := range t.Constraints {
+			content := fmt.Sprintf("constraint %s on %s: %s (%s)", c.Name, t.Name, c.Type, strings.Join(c.Columns, ", "))
+			if c.Reference != "" {
+				content += " references " + c.Reference
+			}
+			docs = append(docs, document(metadata.TenantID, domains.DocumentKindConstraint, content, map[string]string{
+				domains.VectorMetadataTable:      t.Name,
+				domains.VectorMetadataConstraint: c.Name,
+			}))
+		}
+	}
+
+	return docs
+}
+
+// ValueDocuments emits one document per profiled column with its statistics
+// and sampled values, such as
+// "customers.city: 1200 rows, 2.0% null, min Bandung, max Surabaya, values: Bandung, Jakarta, Surabaya",
+// so a prompt mentioning a value retrieves the column to filter on.
+func ValueDocuments(metadata *domains.DatabaseMetadata) []domains.Vector {
+	if metadata == nil {
+		return nil
+	}
+
+	var docs []domains.Vector
+	for _, t := range metadata.Tables {
+		for _, c := range t.Columns {
+			p := c.Profile
+			if p == nil {
+				continue
+			}
+
+			content := fmt.Sprintf("%s.%s: %d rows, %.1f%% null", t.Name, c.Name, p.RowCount, p.NullRatio()*100)
+			if p.Min != "" || p.Max != "" {
+				content += fmt.Sprintf(", min %s, max %s", p.Min, p.Max)
+			}
+			if len(p.Values) > 0 {
+				content += ", values: " + strings.Join(p.Values, ", ")
+			}
+			docs = append(docs, document(metadata.TenantID, domains.DocumentKindValue, content, map[string]string{
+				domains.VectorMetadataTable:  t.Name,
+				domains.VectorMetadataColumn: c.Name,
+			}))
+		}
+	}
+
+	return docs
+}
+
+// buildDocuments runs the strategies in order and concatenates their output.
+func buildDocuments(strategies []DocumentStrategy, metadata *domains.DatabaseMetadata) []domains.Vector {
+	var docs []domains.Vector
+	for _, strategy := range strategies {
+		docs = append(docs, strategy(metadata)...)
+	}
+	return docs
+}
+
+func document(tenantID string, kind domains.DocumentKind, content string, metadata map[string]string) domains.Vector {
+	metadata[domains.VectorMetadataKind] = string(kind)
+	return domains.Vector{
+		ID:       domains.DocumentID(tenantID, metadata),
+		TenantID: tenantID,
+		Content:  content,
+		Metadata: metadata,
+	}
+}
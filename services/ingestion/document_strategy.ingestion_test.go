@@ -0,0 +1,145 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentStrategies(t *testing.T) {
+	metadata := &domains.DatabaseMetadata{
+		TenantID: "tenant_abc",
+		Tables: []domains.Table{
+			{
+				Name:        "orders",
+				Comments:    "Customer orders",
+				Description: "One row per checkout",
+				Columns: []domains.Column{
+					{Name: "id", Type: "INT", IsPrimaryKey: true},
+					{Name: "customer_id", Type: "INT", IsForeignKey: true},
+					{Name: "status", Type: "VARCHAR(20)", Default: "'open'", Description: "Fulfilment state", Profile: &domains.ColumnProfile{
+						RowCount:      200,
+						NullCount:     5,
+						DistinctCount: 3,
+						Min:           "cancelled",
+						Max:           "shipped",
+						Values:        []string{"cancelled", "open", "shipped"},
+					}},
+				},
+				Indexes: []domains.Index{
+					{Name: "idx_orders_customer", Columns: []string{"customer_id", "status"}},
+				},
+				Constraints: []domains.Constraint{
+					{Name: "fk_customer", Type: "FOREIGN KEY", Columns: []string{"customer_id"}, Reference: "customers(id)"},
+				},
+			},
+		},
+		Relations: []domains.Relation{
+			{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+		},
+	}
+
+	t.Run("per column", func(t *testing.T) {
+		docs := ColumnDocuments(metadata)
+
+		require.Len(t, docs, 3)
+		for _, doc := range docs {
+			require.Equal(t, "tenant_abc", doc.TenantID)
+			require.Equal(t, domains.DocumentKindColumn, doc.Kind())
+			require.Equal(t, "orders", doc.Metadata[domains.VectorMetadataTable])
+		}
+		require.Equal(t, "status", docs[2].Metadata[domains.VectorMetadataColumn])
+		require.Contains(t, docs[2].Content, "'open'")
+		require.Contains(t, docs[2].Content, "Fulfilment state")
+	})
+
+	t.Run("per table", func(t *testing.T) {
+		docs := TableDocuments(metadata)
+
+		require.Len(t, docs, 1)
+		require.Equal(t, domains.DocumentKindTable, docs[0].Kind())
+		require.Equal(t, "orders", docs[0].Metadata[domains.VectorMetadataTable])
+		require.Contains(t, docs[0].Content, "Customer orders")
+		require.Contains(t, docs[0].Content, "One row per checkout")
+		require.Contains(t, docs[0].Content, "Fulfilment state")
+		require.Contains(t, docs[0].Content, "customer_id")
+	})
+
+	t.Run("per relation", func(t *testing.T) {
+		docs := RelationDocuments(metadata)
+
+		expectMetadata := map[string]string{
+			domains.VectorMetadataKind:         string(domains.DocumentKindRelation),
+			domains.VectorMetadataTable:        "orders",
+			domains.VectorMetadataColumn:       "customer_id",
+			domains.VectorMetadataTargetTable:  "customers",
+			domains.VectorMetadataTargetColumn: "id",
+		}
+		require.Equal(t, []domains.Vector{
+			{
+				ID:       domains.DocumentID("tenant_abc", expectMetadata),
+				TenantID: "tenant_abc",
+				Content:  "orders.customer_id -> customers.id (MANY_TO_ONE)",
+				Metadata: expectMetadata,
+			},
+		}, docs)
+	})
+
+	t.Run("per table-level relation", func(t *testing.T) {
+		docs := RelationDocuments(&domains.DatabaseMetadata{
+			TenantID: "tenant_abc",
+			Relations: []domains.Relation{
+				{SourceTable: "fct_orders", TargetTable: "stg_orders", RelationType: "DERIVED_FROM"},
+			},
+		})
+
+		require.Len(t, docs, 1)
+		require.Equal(t, "fct_orders -> stg_orders (DERIVED_FROM)", docs[0].Content)
+	})
+
+	t.Run("indexes and constraints", func(t *testing.T) {
+		docs := IndexDocuments(metadata)
+
+		require.Len(t, docs, 2)
+		require.Equal(t, domains.DocumentKindIndex, docs[0].Kind())
+		require.Equal(t, "index idx_orders_customer on orders (customer_id, status)", docs[0].Content)
+		require.Equal(t, domains.DocumentKindConstraint, docs[1].Kind())
+		require.Equal(t, "constraint fk_customer on orders: FOREIGN KEY (customer_id) references customers(id)", docs[1].Content)
+	})
+
+	t.Run("per profiled column", func(t *testing.T) {
+		docs := ValueDocuments(metadata)
+
+		require.Len(t, docs, 1)
+		require.Equal(t, domains.DocumentKindValue, docs[0].Kind())
+		require.Equal(t, "orders", docs[0].Metadata[domains.VectorMetadataTable])
+		require.Equal(t, "status", docs[0].Metadata[domains.VectorMetadataColumn])
+		require.Equal(t, "orders.status: 200 rows, 2.5% null, min cancelled, max shipped, values: cancelled, open, shipped", docs[0].Content)
+	})
+
+	t.Run("ids are unique and stable", func(t *testing.T) {
+		strategies := []DocumentStrategy{ColumnDocuments, TableDocuments, RelationDocuments, IndexDocuments, ValueDocuments}
+		first := buildDocuments(strategies, metadata)
+		second := buildDocuments(strategies, metadata)
+
+		seen := map[string]bool{}
+		for i, doc := range first {
+			require.False(t, seen[doc.ID], "duplicate id %s", doc.ID)
+			seen[doc.ID] = true
+			require.Equal(t, doc.ID, second[i].ID)
+		}
+	})
+
+	t.Run("strategies run in order", func(t *testing.T) {
+		docs := buildDocuments([]DocumentStrategy{RelationDocuments, TableDocuments}, metadata)
+
+		require.Len(t, docs, 2)
+		require.Equal(t, domains.DocumentKindRelation, docs[0].Kind())
+		require.Equal(t, domains.DocumentKindTable, docs[1].Kind())
+	})
+
+	t.Run("nil metadata", func(t *testing.T) {
+		require.Empty(t, buildDocuments([]DocumentStrategy{ColumnDocuments, TableDocuments, RelationDocuments, IndexDocuments, ValueDocuments}, nil))
+	})
+}
@@ -0,0 +1,59 @@
+package ingestion
+
+import (
+	"sort"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// ingestionPlan is what an ingestion has to do to bring the stored vectors
+// from the previously ingested schema to the current one.
+type ingestionPlan struct {
+	// full is set when nothing was ingested before, so every vector of the
+	// tenant is replaced.
+	full bool
+	// changed holds the added and altered tables, whose documents are embedded.
+	changed map[string]bool
+	// stale lists the altered and dropped tables, whose old vectors are deleted.
+	stale []string
+}
+
+func planIngestion(previous, current map[string]string) ingestionPlan {
+	plan := ingestionPlan{
+		full:    len(previous) == 0,
+		changed: map[string]bool{},
+	}
+
+	for table, checksum := range current {
+		if plan.full || previous[table] != checksum {
+			plan.changed[table] = true
+		}
+	}
+	if plan.full {
+		return plan
+	}
+
+	for table, checksum := range previous {
+		if current[table] != checksum {
+			plan.stale = append(plan.stale, table)
+		}
+	}
+	sort.Strings(plan.stale)
+
+	return plan
+}
+
+// documentsOf keeps the documents of the tables the plan re-embeds.
+func (p ingestionPlan) documentsOf(documents []domains.Vector) []domains.Vector {
+	if p.full {
+		return documents
+	}
+
+	kept := make([]domains.Vector, 0, len(documents))
+	for _, document := range documents {
+		if p.changed[document.Metadata[domains.VectorMetadataTable]] {
+			kept = append(kept, document)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,58 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanIngestion(t *testing.T) {
+	current := map[string]string{"customers": "c1", "orders": "o2", "payments": "p1"}
+
+	tests := []struct {
+		name          string
+		previous      map[string]string
+		expectFull    bool
+		expectChanged map[string]bool
+		expectStale   []string
+	}{
+		{
+			name:          "nothing ingested before",
+			previous:      nil,
+			expectFull:    true,
+			expectChanged: map[string]bool{"customers": true, "orders": true, "payments": true},
+		},
+		{
+			name:          "added, altered and dropped tables",
+			previous:      map[string]string{"customers": "c1", "orders": "o1", "refunds": "r1"},
+			expectChanged: map[string]bool{"orders": true, "payments": true},
+			expectStale:   []string{"orders", "refunds"},
+		},
+		{
+			name:          "unchanged schema",
+			previous:      map[string]string{"customers": "c1", "orders": "o2", "payments": "p1"},
+			expectChanged: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planIngestion(tt.previous, current)
+
+			require.Equal(t, tt.expectFull, plan.full)
+			require.Equal(t, tt.expectChanged, plan.changed)
+			require.Equal(t, tt.expectStale, plan.stale)
+		})
+	}
+}
+
+func TestIngestionPlan_DocumentsOf(t *testing.T) {
+	documents := []domains.Vector{
+		{ID: "1", Metadata: map[string]string{domains.VectorMetadataTable: "customers"}},
+		{ID: "2", Metadata: map[string]string{domains.VectorMetadataTable: "orders"}},
+	}
+
+	require.Equal(t, documents, ingestionPlan{full: true}.documentsOf(documents))
+	require.Equal(t, documents[1:], ingestionPlan{changed: map[string]bool{"orders": true}}.documentsOf(documents))
+}
@@ -2,9 +2,13 @@ package ingestion
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/kamil5b/go-nl2query-lib/domains"
-	toon "github.com/toon-format/toon-go"
+	"github.com/kamil5b/go-nl2query-lib/ports"
 )
 
 func (s *IngestionService) VectorizeAndStore(ctx context.Context, metadata *domains.DatabaseMetadata) error {
@@ -13,29 +17,102 @@ func (s *IngestionService) VectorizeAndStore(ctx context.Context, metadata *doma
 		return err
 	}
 
-	// Prepare content strings from tables for embedding
-	contents := metadataToTOON(metadata)
+	// Keep the metadata as an immutable schema version, reusing the version
+	// of an identical schema ingested before
+	version, err := s.internalDatabaseAdapter.SaveSchemaVersion(ctx, metadata)
+	if err != nil {
+		// Set error status and return
+		_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
+		return err
+	}
+
+	// Compare the per-table checksums with the last successful ingestion
+	tableChecksums := metadata.TableChecksums()
+	plan, err := s.planIngestion(ctx, metadata.TenantID, tableChecksums)
+	if err != nil {
+		// Set error status and return
+		_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
+		return err
+	}
+
+	// Prepare the documents of the changed tables with the configured
+	// strategies, tagged with the version they are first embedded in
+	documents := plan.documentsOf(buildDocuments(s.Config.documentStrategies(), metadata))
+	for _, document := range documents {
+		document.Metadata[domains.VectorMetadataSchemaVersion] = strconv.FormatInt(version.Version, 10)
+	}
 
-	// Embed the content batch
-	embeddings, err := s.embedderAdapter.EmbedBatch(ctx, contents)
+	// A full ingestion replaces the glossary and example vectors too, so
+	// embed them again
+	curated, err := s.curatedDocuments(ctx, metadata.TenantID, plan)
 	if err != nil {
 		// Set error status and return
 		_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
 		return err
 	}
+	documents = append(documents, curated...)
+
+	contents := make([]string, len(documents))
+	for i, document := range documents {
+		contents[i] = document.Content
+	}
 
-	// Create vector entities from embeddings
-	vectors := make([]domains.Vector, len(embeddings))
+	// Embed the content in batches, reporting progress after each one
+	embeddings := make([][]float32, 0, len(contents))
+	embedBatchSize := s.Config.embedBatchSize(len(contents))
+	startedAt := time.Now()
+	for start := 0; start < len(contents); start += embedBatchSize {
+		end := min(start+embedBatchSize, len(contents))
+		batch, err := s.embedderAdapter.EmbedBatch(ctx, contents[start:end])
+		if err != nil {
+			// Set error status and return
+			_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
+			return err
+		}
+		// A missing embedding would drop its document, and a full ingestion
+		// would then delete the stored vector and commit its checksum
+		if len(batch) != end-start {
+			countErr := ports.IngestionEmbeddingCountError
+			countErr.AddAdditionalErrorInfo(fmt.Sprintf("%d embeddings for %d documents", len(batch), end-start))
+			// Set error status and return
+			_ = s.statusAdapter.SetError(ctx, metadata.TenantID, countErr.Error())
+			return countErr
+		}
+		embeddings = append(embeddings, batch...)
+		s.reportProgress(ctx, metadata.TenantID, domains.IngestionPhaseEmbedding, len(embeddings), len(contents), startedAt)
+	}
+
+	// Attach the embeddings to their documents
+	vectors := documents
 	for i, embedding := range embeddings {
-		vectors[i] = domains.Vector{
-			TenantID:  metadata.TenantID,
-			Embedding: embedding,
-			Content:   contents[i],
+		vectors[i].Embedding = embedding
+	}
+
+	// Upsert vectors to the store in batches, reporting progress after each one
+	upsertBatchSize := s.Config.upsertBatchSize(len(vectors))
+	startedAt = time.Now()
+	for start := 0; start < len(vectors); start += upsertBatchSize {
+		end := min(start+upsertBatchSize, len(vectors))
+		if err := s.vectorStoreAdapter.Upsert(ctx, metadata.TenantID, vectors[start:end]); err != nil {
+			// Set error status and return
+			_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
+			return err
 		}
+		s.reportProgress(ctx, metadata.TenantID, domains.IngestionPhaseUpserting, end, len(vectors), startedAt)
 	}
 
-	// Upsert vectors to the store
-	if err := s.vectorStoreAdapter.Upsert(ctx, metadata.TenantID, vectors); err != nil {
+	// Delete the vectors the new documents did not overwrite. This runs only
+	// now that the new ones are stored, so a failed ingestion leaves the
+	// previous vectors searchable
+	if err := s.deleteStaleVectors(ctx, metadata.TenantID, plan, vectors); err != nil {
+		// Set error status and return
+		_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
+		return err
+	}
+
+	// Commit the checksum only now that the vectors are stored, so a failed
+	// ingestion is picked up again by the next sync
+	if err := s.commitChecksum(ctx, metadata, tableChecksums, version.Version); err != nil {
 		// Set error status and return
 		_ = s.statusAdapter.SetError(ctx, metadata.TenantID, err.Error())
 		return err
@@ -49,44 +126,108 @@ func (s *IngestionService) VectorizeAndStore(ctx context.Context, metadata *doma
 	return nil
 }
 
-func metadataToTOON(meta *domains.DatabaseMetadata) []string {
-	if meta == nil {
+// planIngestion loads the table checksums of the last successful ingestion
+// and plans which tables to re-embed.
+func (s *IngestionService) planIngestion(ctx context.Context, tenantID string, tableChecksums map[string]string) (ingestionPlan, error) {
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return ingestionPlan{}, err
+	}
+
+	var previous map[string]string
+	if workspace != nil {
+		previous = workspace.TableChecksums
+	}
+	return planIngestion(previous, tableChecksums), nil
+}
+
+// deleteStaleVectors removes the stored vectors that were not rewritten: on a
+// full ingestion every other vector of the tenant, and otherwise those of
+// altered and dropped tables.
+func (s *IngestionService) deleteStaleVectors(ctx context.Context, tenantID string, plan ingestionPlan, written []domains.Vector) error {
+	if !plan.full && len(plan.stale) == 0 {
 		return nil
 	}
 
-	type Row struct {
-		TenantID string `json:"tenant_id"`
-		Table    string `json:"table"`
-		Column   string `json:"column"`
-		Type     string `json:"type"`
-		Nullable bool   `json:"nullable"`
-		Primary  bool   `json:"primary_key"`
-		Foreign  bool   `json:"foreign_key"`
-		Comment  string `json:"comment"`
-	}
-
-	var docs []string
-
-	for _, t := range meta.Tables {
-		for _, c := range t.Columns {
-
-			row := Row{
-				TenantID: meta.TenantID,
-				Table:    t.Name,
-				Column:   c.Name,
-				Type:     c.Type,
-				Nullable: c.Nullable,
-				Primary:  c.IsPrimaryKey,
-				Foreign:  c.IsForeignKey,
-				Comment:  c.Comments,
-			}
-
-			encoded, err := toon.MarshalString([]Row{row}) // one column per embedding unit
-			if err == nil {
-				docs = append(docs, encoded)
-			}
+	stored, err := s.vectorStoreAdapter.List(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	rewritten := make(map[string]bool, len(written))
+	for _, vector := range written {
+		rewritten[vector.ID] = true
+	}
+	var ids []string
+	for _, vector := range stored {
+		if rewritten[vector.ID] {
+			continue
 		}
+		if plan.full || slices.Contains(plan.stale, vector.Metadata[domains.VectorMetadataTable]) {
+			ids = append(ids, vector.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.vectorStoreAdapter.DeleteByIDs(ctx, tenantID, ids)
+}
+
+// curatedDocuments returns the documents of the tenant's glossary terms and
+// examples when the plan replaces every vector, and nothing otherwise.
+func (s *IngestionService) curatedDocuments(ctx context.Context, tenantID string, plan ingestionPlan) ([]domains.Vector, error) {
+	if !plan.full {
+		return nil, nil
+	}
+
+	terms, err := s.internalDatabaseAdapter.ListGlossaryTermsByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	examples, err := s.internalDatabaseAdapter.ListExamplesByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]domains.Vector, 0, len(terms)+len(examples))
+	for _, term := range terms {
+		documents = append(documents, domains.GlossaryDocument(term))
+	}
+	for _, example := range examples {
+		documents = append(documents, domains.ExampleDocument(example))
+	}
+	return documents, nil
+}
+
+// commitChecksum records the checksums and schema version of the ingested
+// metadata on the stored workspace. A workspace deleted while the ingestion
+// ran is not recreated.
+func (s *IngestionService) commitChecksum(ctx context.Context, metadata *domains.DatabaseMetadata, tableChecksums map[string]string, version int64) error {
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, metadata.TenantID)
+	if err != nil {
+		return err
+	}
+	if workspace == nil {
+		return nil
+	}
+
+	workspace.Checksum = metadata.Checksum
+	workspace.TableChecksums = tableChecksums
+	workspace.ActiveVersion = version
+	workspace.Status = domains.StatusDone
+	return s.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+}
+
+// reportProgress publishes the progress of the current phase. Progress is
+// informational only, so a failure to record it does not fail the ingestion.
+func (s *IngestionService) reportProgress(ctx context.Context, tenantID string, phase domains.IngestionPhase, done, total int, startedAt time.Time) {
+	progress := domains.IngestionProgress{
+		Phase:     phase,
+		Done:      done,
+		Total:     total,
+		StartedAt: startedAt,
 	}
+	progress.ETA = progress.EstimateETA(time.Now())
 
-	return docs
+	_ = s.statusAdapter.SetProgress(ctx, tenantID, progress)
 }
@@ -3,12 +3,34 @@ package ingestion
 import (
 	"testing"
 
+	"github.com/kamil5b/go-nl2query-lib/domains"
 	"github.com/kamil5b/go-nl2query-lib/ports"
 	ingestionTest "github.com/kamil5b/go-nl2query-lib/testsuites/ingestion"
 )
 
 func TestIngestionService_VectorizeAndStore(t *testing.T) {
-	ingestionTest.UnitTestVectorizeAndStore(t, func(embedderAdapter ports.EmbedderPort, vectorStoreAdapter ports.VectorStorePort, statusAdapter ports.StatusPort) ports.IngestionService {
-		return NewIngestionService(nil, embedderAdapter, vectorStoreAdapter, statusAdapter)
-	}, metadataToTOON)
+	strategies := []DocumentStrategy{ColumnDocuments, TableDocuments, RelationDocuments, IndexDocuments}
+
+	ingestionTest.UnitTestVectorizeAndStore(t, func(
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedBatchSize int,
+		upsertBatchSize int,
+	) ports.IngestionService {
+		return NewIngestionService(
+			&IngestionConfig{
+				EmbedBatchSize:     embedBatchSize,
+				UpsertBatchSize:    upsertBatchSize,
+				DocumentStrategies: strategies,
+			},
+			embedderAdapter,
+			vectorStoreAdapter,
+			statusAdapter,
+			internalDatabaseAdapter,
+		)
+	}, func(metadata *domains.DatabaseMetadata) []domains.Vector {
+		return buildDocuments(strategies, metadata)
+	})
 }
@@ -2,9 +2,16 @@ package query
 
 import "github.com/kamil5b/go-nl2query-lib/ports"
 
+// DefaultMaxExamples is how many similar examples are given to the LLM when
+// QueryConfig.MaxExamples is zero.
+const DefaultMaxExamples = 3
+
 type QueryConfig struct {
 	ExecutionRetryLimit int
 	QueryFixAttempts    int
+	// MaxExamples caps the few-shot examples retrieved for a prompt. Zero
+	// means DefaultMaxExamples and a negative value disables them.
+	MaxExamples int
 }
 
 type QueryService struct {
@@ -45,3 +52,10 @@ func NewQueryService(
 		queryValidatorAdapter:   queryValidatorAdapter,
 	}
 }
+
+func (c *QueryConfig) maxExamples() int {
+	if c == nil || c.MaxExamples == 0 {
+		return DefaultMaxExamples
+	}
+	return max(c.MaxExamples, 0)
+}
@@ -3,6 +3,8 @@ package query
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/kamil5b/go-nl2query-lib/domains"
@@ -10,6 +12,165 @@ import (
 )
 
 func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, prompt string, withData bool) (*domains.Query, *string, error) {
+	result, warn, err := s.promptToQueryData(ctx, tenantID, prompt, withData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.recordHistory(ctx, prompt, result, warn)
+
+	return result, warn, nil
+}
+
+// recordHistory stores the generated query in the tenant's query history.
+// History is informational only, so a failure to record it does not fail the
+// request.
+func (s *QueryService) recordHistory(ctx context.Context, prompt string, result *domains.Query, warn *string) {
+	entry := &domains.QueryHistoryEntry{
+		TenantID:  result.TenantID,
+		Prompt:    prompt,
+		CreatedAt: result.CreatedAt,
+	}
+	if result.ResultQuery != nil {
+		entry.Query = *result.ResultQuery
+	}
+	if warn != nil {
+		entry.Warning = *warn
+	}
+
+	if err := s.internalDatabaseAdapter.AppendQueryHistory(ctx, entry); err == nil {
+		result.HistoryID = entry.ID
+	}
+}
+
+// withGlossary appends the documents of the tenant's glossary terms that are
+// not already among the searched vectors.
+func (s *QueryService) withGlossary(ctx context.Context, tenantID string, vectors []domains.Vector) ([]domains.Vector, error) {
+	terms, err := s.internalDatabaseAdapter.ListGlossaryTermsByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(vectors))
+	for _, vector := range vectors {
+		found[vector.ID] = true
+	}
+	for _, term := range terms {
+		document := domains.GlossaryDocument(term)
+		if !found[document.ID] {
+			vectors = append(vectors, document)
+		}
+	}
+	return vectors, nil
+}
+
+// similarExamples returns the tenant's examples whose question is the most
+// similar to the prompt, most similar first.
+func (s *QueryService) similarExamples(ctx context.Context, tenantID string, vector []float32) ([]*domains.Example, error) {
+	limit := s.Config.maxExamples()
+	if limit == 0 {
+		return nil, nil
+	}
+
+	hits, err := s.vectorStoreAdapter.SearchByFilter(ctx, tenantID, vector, map[string]string{
+		domains.VectorMetadataKind: string(domains.DocumentKindExample),
+	}, limit)
+	if err != nil || len(hits) == 0 {
+		return nil, err
+	}
+
+	stored, err := s.internalDatabaseAdapter.ListExamplesByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domains.Example, len(stored))
+	for _, example := range stored {
+		byID[strconv.FormatInt(example.ID, 10)] = example
+	}
+
+	var examples []*domains.Example
+	for _, hit := range hits {
+		// A vector can outlive its example for a moment while it is deleted
+		if example, ok := byID[hit.Metadata[domains.VectorMetadataExample]]; ok {
+			examples = append(examples, example)
+		}
+	}
+	return examples, nil
+}
+
+// withoutExamples drops the example vectors from the searched context, since
+// examples are given to the LLM as few-shot pairs instead.
+func withoutExamples(vectors []domains.Vector) []domains.Vector {
+	kept := make([]domains.Vector, 0, len(vectors))
+	for _, vector := range vectors {
+		if vector.Kind() != domains.DocumentKindExample {
+			kept = append(kept, vector)
+		}
+	}
+	return kept
+}
+
+// nonCanonicalMetric fails when the query does not compute a metric named in
+// the prompt with its canonical definition, telling the LLM which one to use.
+func nonCanonicalMetric(query string, definitions *domains.SemanticModel) error {
+	if definitions == nil {
+		return nil
+	}
+	for _, metric := range definitions.Metrics {
+		if !metric.UsedIn(query) {
+			return fmt.Errorf("metric %s must be computed as %s", metric.Name, metric.Definition())
+		}
+	}
+	return nil
+}
+
+// checkReferences fails when the query reads a table or column that the
+// workspace's sync options filter out, or tables of several data sources,
+// telling the LLM which ones. Otherwise it returns the data source holding
+// the tables read, empty when there is no choice to make. Queries the
+// validator cannot read the references of are not allowed either.
+func (s *QueryService) checkReferences(query string, options *domains.SyncOptions, tableSources map[string]string) (string, error) {
+	if options.IsEmpty() && len(tableSources) == 0 {
+		return "", nil
+	}
+	references, err := s.queryValidatorAdapter.References(query)
+	if err != nil {
+		return "", err
+	}
+	if err := options.Check(references); err != nil {
+		return "", err
+	}
+	return domains.RouteReferences(tableSources, references)
+}
+
+// tableSources returns the data source of each table of a named workspace,
+// from the schema version its vectors were embedded from.
+func (s *QueryService) tableSources(ctx context.Context, workspace *domains.Workspace) (map[string]string, error) {
+	if workspace == nil || len(workspace.DataSources) < 2 || workspace.ActiveVersion == 0 {
+		return nil, nil
+	}
+	version, err := s.internalDatabaseAdapter.GetSchemaVersion(ctx, workspace.TenantID, workspace.ActiveVersion)
+	if err != nil || version == nil {
+		return nil, err
+	}
+	return version.Metadata.TableSources(), nil
+}
+
+// connectDataSource connects to the data source of a named workspace that the
+// query was routed to, the first one when it was not routed.
+func (s *QueryService) connectDataSource(ctx context.Context, workspace *domains.Workspace, name string) (bool, error) {
+	source := workspace.DataSource(name)
+	if source == nil {
+		return false, nil
+	}
+	dbURL, err := s.encryptAdapter.Decrypt(source.EncryptedDBURL)
+	if err != nil {
+		return false, err
+	}
+	return s.clientDatabaseAdapter.Connect(ctx, dbURL) == nil, nil
+}
+
+func (s *QueryService) promptToQueryData(ctx context.Context, tenantID string, prompt string, withData bool) (*domains.Query, *string, error) {
 	// Step 1: Check tenant status
 	var warn *string
 	workspaceStatus, _, err := s.statusAdapter.GetStatus(ctx, tenantID)
@@ -31,11 +192,19 @@ func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, p
 	if err != nil {
 		return nil, nil, err
 	}
+	if workspace != nil && workspace.IsDeleted() {
+		return nil, nil, ports.WorkspaceDeletedError
+	}
 
-	// Step 4: Decrypt and connect to client database if withData is true
+	// Step 4: Decrypt and connect to client database if withData is true. A
+	// named workspace connects once the query is routed to one of its sources
 	var clientDBConnected bool
 
-	if withData && workspace != nil {
+	if withData && workspace != nil && workspace.IsSchemaOnly() {
+		// A schema-only workspace has no client database to run the query on
+		warnMsg := ports.QueryServiceWarnWontExecuteClientDatabaseError
+		warn = &warnMsg
+	} else if withData && workspace != nil && len(workspace.DataSources) == 0 {
 		decryptedURL, decErr := s.encryptAdapter.Decrypt(workspace.EncryptedDBURL)
 		if decErr != nil {
 			return nil, nil, decErr
@@ -60,7 +229,38 @@ func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, p
 		return nil, nil, err
 	}
 
-	// Step 7: Generate query with nested retry loops for syntax and execution errors
+	// Step 7: Always consider the glossary, even the terms the search missed
+	vectors, err = s.withGlossary(ctx, tenantID, withoutExamples(vectors))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Step 8: Retrieve the examples similar to the prompt as few-shot pairs
+	examples, err := s.similarExamples(ctx, tenantID, vector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Step 9: Give the LLM the semantic definitions named in the prompt
+	semanticModel, err := s.internalDatabaseAdapter.GetSemanticModel(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	definitions := semanticModel.Relevant(prompt)
+	vectors = append(vectors, definitions.Documents()...)
+
+	// Step 10: Find the data source of each table of a named workspace, to
+	// run the query on the one holding the tables it reads
+	tableSources, err := s.tableSources(ctx, workspace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Step 11: Generate query with nested retry loops for syntax and execution errors
+	var syncOptions *domains.SyncOptions
+	if workspace != nil {
+		syncOptions = workspace.SyncOptions
+	}
 	var query *string
 	additionalArgs := []string{}
 	// Outer loop: for execution errors
@@ -70,22 +270,27 @@ func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, p
 		for syntaxIdx := 0; syntaxIdx < s.Config.QueryFixAttempts+1; syntaxIdx++ {
 			// Generate query
 			var genErr error
-			query, genErr = s.LLMAdapter.GenerateQuery(ctx, prompt, vectors, additionalArgs...)
+			query, genErr = s.LLMAdapter.GenerateQuery(ctx, prompt, vectors, examples, additionalArgs...)
 			if genErr != nil {
 				return nil, nil, genErr
 			}
 
-			// Validate safety
+			// Validate safety, then the named metrics, the synced tables and
+			// the data sources read
 			isSafe, safeErr := s.queryValidatorAdapter.IsSafe(*query)
 			if isSafe && safeErr == nil {
-				break
+				if safeErr = nonCanonicalMetric(*query, definitions); safeErr == nil {
+					if _, safeErr = s.checkReferences(*query, syncOptions, tableSources); safeErr == nil {
+						break
+					}
+				}
 			}
 			if safeErr == nil {
 				safeErr = errors.New("query deemed unsafe by validator")
 			}
 			additionalArgs = []string{*query, safeErr.Error()}
 		}
-		query, err := s.LLMAdapter.GenerateQuery(ctx, prompt, vectors, additionalArgs...)
+		query, err := s.LLMAdapter.GenerateQuery(ctx, prompt, vectors, examples, additionalArgs...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -94,7 +299,14 @@ func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, p
 		if !isSafe && safeErr == nil {
 			safeErr = errors.New("query deemed unsafe by validator")
 		}
-		if safeErr != nil || !isSafe || !withData || !clientDBConnected || query == nil {
+		metricErr := nonCanonicalMetric(*query, definitions)
+		source, referenceErr := s.checkReferences(*query, syncOptions, tableSources)
+		if withData && referenceErr == nil && workspace != nil && len(workspace.DataSources) > 0 {
+			if clientDBConnected, err = s.connectDataSource(ctx, workspace, source); err != nil {
+				return nil, nil, err
+			}
+		}
+		if safeErr != nil || !isSafe || metricErr != nil || referenceErr != nil || !withData || !clientDBConnected || query == nil {
 			if safeErr != nil || !isSafe {
 				warnMsg := ports.QueryServiceWarnQueryGeneratedUnsafe
 				warn = &warnMsg
@@ -103,6 +315,17 @@ func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, p
 				warnMsg := ports.QueryServiceWarnWontExecuteClientDatabaseError
 				warn = &warnMsg
 			}
+			if metricErr != nil {
+				warnMsg := ports.QueryServiceWarnMetricNotCanonical
+				warn = &warnMsg
+			}
+			if referenceErr != nil {
+				warnMsg := ports.QueryServiceWarnQueryNotAllowed
+				if errors.Is(referenceErr, domains.ErrCrossDataSource) {
+					warnMsg = ports.QueryServiceWarnQueryCrossesDataSources
+				}
+				warn = &warnMsg
+			}
 			return &domains.Query{
 				TenantID:    tenantID,
 				ResultQuery: query,
@@ -111,7 +334,7 @@ func (s *QueryService) PromptToQueryData(ctx context.Context, tenantID string, p
 			}, warn, nil
 		}
 
-		// Step 8: Check for DDL/DML and execute if applicable
+		// Step 12: Check for DDL/DML and execute if applicable
 		// Check if query contains DDL/DML
 		if s.queryValidatorAdapter.ContainsDDLDML(*query) {
 			// Contains DDL/DML, don't execute, return with warn
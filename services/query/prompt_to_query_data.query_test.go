@@ -19,11 +19,13 @@ func TestQueryService_PromptToQueryData(t *testing.T) {
 		QueryValidator ports.QueryValidatorPort,
 		queryErrorLimit int,
 		executionErrorLimit int,
+		maxExamples int,
 	) ports.QueryService {
 		return NewQueryService(
 			&QueryConfig{
 				ExecutionRetryLimit: executionErrorLimit,
 				QueryFixAttempts:    queryErrorLimit,
+				MaxExamples:         maxExamples,
 			},
 			statusAdapter,
 			clientDatabaseAdapter,
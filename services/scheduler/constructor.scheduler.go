@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+const (
+	defaultTickInterval  = time.Minute
+	defaultPurgeInterval = time.Hour
+)
+
+type SchedulerConfig struct {
+	// DefaultSchedule is the cron expression of the workspaces without their
+	// own SyncSchedule. Empty leaves them unscheduled.
+	DefaultSchedule string
+	// Jitter delays each scheduled sync by up to this duration, so workspaces
+	// on the same schedule do not all hit their databases at once. The delay
+	// is stable for a workspace and a due time.
+	Jitter time.Duration
+	// MaxConcurrency caps the syncs running at once. Defaults to 1.
+	MaxConcurrency int
+	// TickInterval is how often Run looks for due workspaces. Defaults to one
+	// minute, the resolution of cron expressions.
+	TickInterval time.Duration
+	// PurgeInterval is how often Run purges the workspaces deleted beyond
+	// their retention window. Defaults to one hour.
+	PurgeInterval time.Duration
+	// OnError receives the errors of the syncs and purges started by Run. Nil
+	// drops them.
+	OnError func(err error)
+}
+
+func (c *SchedulerConfig) defaultSchedule() string {
+	if c == nil {
+		return ""
+	}
+	return c.DefaultSchedule
+}
+
+func (c *SchedulerConfig) jitter() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.Jitter
+}
+
+func (c *SchedulerConfig) maxConcurrency() int {
+	if c == nil || c.MaxConcurrency <= 0 {
+		return 1
+	}
+	return c.MaxConcurrency
+}
+
+func (c *SchedulerConfig) tickInterval() time.Duration {
+	if c == nil || c.TickInterval <= 0 {
+		return defaultTickInterval
+	}
+	return c.TickInterval
+}
+
+func (c *SchedulerConfig) purgeInterval() time.Duration {
+	if c == nil || c.PurgeInterval <= 0 {
+		return defaultPurgeInterval
+	}
+	return c.PurgeInterval
+}
+
+func (c *SchedulerConfig) onError(err error) {
+	if c != nil && c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+type SchedulerService struct {
+	Config *SchedulerConfig
+
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
+	encryptAdapter          ports.EncryptPort
+	workspaceService        ports.WorkspaceService
+	driftNotifierAdapter    ports.DriftNotifierPort
+
+	mu sync.Mutex
+	// running holds the workspaces being synced, lastRun when each was last
+	// synced by the scheduler, and lastDrift the checksum of the last drift
+	// event sent for each.
+	running   map[string]bool
+	lastRun   map[string]time.Time
+	lastDrift map[string]string
+}
+
+func NewSchedulerService(
+	config *SchedulerConfig,
+
+	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
+	encryptAdapter ports.EncryptPort,
+	workspaceService ports.WorkspaceService,
+	driftNotifierAdapter ports.DriftNotifierPort,
+) *SchedulerService {
+	return &SchedulerService{
+		Config: config,
+
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+		encryptAdapter:          encryptAdapter,
+		workspaceService:        workspaceService,
+		driftNotifierAdapter:    driftNotifierAdapter,
+
+		running:   map[string]bool{},
+		lastRun:   map[string]time.Time{},
+		lastDrift: map[string]string{},
+	}
+}
@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Run checks for due workspaces right away and then at every tick, and
+// purges the expired deleted workspaces every PurgeInterval. Failed syncs and
+// purges are passed to SchedulerConfig.OnError and do not stop it.
+func (s *SchedulerService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.Config.tickInterval())
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		now := time.Now()
+		if err := s.SyncDue(ctx, now); err != nil && ctx.Err() == nil {
+			s.Config.onError(err)
+		}
+
+		if now.Sub(lastPurge) >= s.Config.purgeInterval() {
+			lastPurge = now
+			if err := s.workspaceService.PurgeExpired(ctx, now); err != nil && ctx.Err() == nil {
+				s.Config.onError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
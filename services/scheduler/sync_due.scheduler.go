@@ -0,0 +1,240 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// SyncDue never syncs a workspace twice at once, nor one being ingested,
+// which is left for a later tick.
+func (s *SchedulerService) SyncDue(ctx context.Context, now time.Time) error {
+	// Step 1: List the workspaces, page by page
+	var workspaces []*domains.Workspace
+	query := &domains.WorkspaceQuery{Limit: domains.MaxWorkspacePageSize}
+	for {
+		page, err := s.internalDatabaseAdapter.ListAllWorkspaces(ctx, query)
+		if err != nil {
+			return err
+		}
+		workspaces = append(workspaces, page.Workspaces...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	// Step 2: Pick the due ones
+	var (
+		failures []string
+		mu       sync.Mutex
+	)
+	fail := func(tenantID string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures = append(failures, tenantID+": "+err.Error())
+	}
+
+	var due []*domains.Workspace
+	for _, workspace := range workspaces {
+		isDue, err := s.isDue(ctx, workspace, now)
+		if err != nil {
+			fail(workspace.TenantID, err)
+			continue
+		}
+		if isDue {
+			due = append(due, workspace)
+		}
+	}
+
+	// Step 3: Sync them, at most MaxConcurrency at once
+	slots := make(chan struct{}, s.Config.maxConcurrency())
+	var wg sync.WaitGroup
+launch:
+	for _, workspace := range due {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		if !s.claim(workspace.TenantID) {
+			<-slots
+			continue
+		}
+
+		wg.Add(1)
+		go func(workspace *domains.Workspace) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			defer s.release(workspace.TenantID)
+
+			if err := s.syncWorkspace(ctx, workspace, now); err != nil {
+				fail(workspace.TenantID, err)
+			}
+		}(workspace)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		slices.Sort(failures)
+		syncErr := ports.SchedulerSyncIncompleteError
+		syncErr.AddBatchAdditionalErrorInfo(failures)
+		return syncErr
+	}
+	return nil
+}
+
+// isDue reports whether the schedule of the workspace came due since its
+// last sync. That is its last DONE status event, so a restart does not lose
+// it and other writes to the workspace do not postpone it, or its creation
+// when it never finished one. Schema-only workspaces have no database to sync.
+func (s *SchedulerService) isDue(ctx context.Context, workspace *domains.Workspace, now time.Time) (bool, error) {
+	expression := workspace.SyncSchedule
+	if expression == "" {
+		expression = s.Config.defaultSchedule()
+	}
+	if expression == "" || workspace.IsSchemaOnly() {
+		return false, nil
+	}
+
+	schedule, err := domains.ParseCronSchedule(expression)
+	if err != nil {
+		return false, err
+	}
+
+	history, err := s.statusAdapter.GetHistory(ctx, workspace.TenantID)
+	if err != nil {
+		return false, err
+	}
+	last := workspace.CreatedAt
+	for _, event := range history {
+		if event.Type == domains.StatusEventDone && event.CreatedAt.After(last) {
+			last = event.CreatedAt
+		}
+	}
+	// A sync that found nothing to ingest leaves no DONE event
+	s.mu.Lock()
+	if run, ok := s.lastRun[workspace.TenantID]; ok && run.After(last) {
+		last = run
+	}
+	s.mu.Unlock()
+
+	next := schedule.Next(last.In(now.Location()))
+	if next.IsZero() {
+		return false, nil
+	}
+	return !now.Before(next.Add(s.jitter(workspace.TenantID, next))), nil
+}
+
+// jitter derives the delay from the workspace and due time rather than at
+// random, so it does not change from one tick to the next.
+func (s *SchedulerService) jitter(tenantID string, next time.Time) time.Duration {
+	jitter := s.Config.jitter()
+	if jitter <= 0 {
+		return 0
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(tenantID))
+	hash.Write([]byte(next.UTC().Format(time.RFC3339)))
+	return time.Duration(hash.Sum64() % uint64(jitter))
+}
+
+func (s *SchedulerService) syncWorkspace(ctx context.Context, workspace *domains.Workspace, now time.Time) error {
+	// Skip a workspace being ingested, it is tried again at the next tick
+	status, _, err := s.statusAdapter.GetStatus(ctx, workspace.TenantID)
+	if err != nil {
+		return err
+	}
+	if status == domains.StatusInProgress {
+		return nil
+	}
+
+	// A failed sync waits for the next due time too, rather than retrying at
+	// every tick
+	report, err := s.sync(ctx, workspace)
+	s.mu.Lock()
+	s.lastRun[workspace.TenantID] = now
+	s.mu.Unlock()
+	// An ingestion started since the status check
+	var syncErr domains.GoNL2QueryError
+	if errors.As(err, &syncErr) && syncErr.Message == ports.StatusInProgressError.Message {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.notifyDrift(ctx, workspace, report, now)
+}
+
+// sync syncs a named workspace by its ID, and the others by their URL.
+func (s *SchedulerService) sync(ctx context.Context, workspace *domains.Workspace) (*domains.SyncReport, error) {
+	if len(workspace.DataSources) > 0 {
+		report, _, err := s.workspaceService.SyncWorkspace(ctx, workspace.TenantID)
+		return report, err
+	}
+
+	dbURL, err := s.encryptAdapter.Decrypt(workspace.EncryptedDBURL)
+	if err != nil {
+		return nil, err
+	}
+	report, _, err := s.workspaceService.SyncClientDatabase(ctx, dbURL)
+	return report, err
+}
+
+// notifyDrift sends a drift event when the client schema no longer matches
+// the last ingestion, once per new checksum: a pinned workspace keeps
+// reporting the same change at every sync.
+func (s *SchedulerService) notifyDrift(ctx context.Context, workspace *domains.Workspace, report *domains.SyncReport, now time.Time) error {
+	if report == nil || workspace.Checksum == "" || report.Checksum == "" || report.Checksum == workspace.Checksum {
+		return nil
+	}
+
+	s.mu.Lock()
+	notified := s.lastDrift[workspace.TenantID] == report.Checksum
+	s.mu.Unlock()
+	if notified {
+		return nil
+	}
+
+	if err := s.driftNotifierAdapter.NotifyDrift(ctx, &domains.DriftEvent{
+		TenantID:         workspace.TenantID,
+		PreviousChecksum: workspace.Checksum,
+		Checksum:         report.Checksum,
+		Outcome:          report.Outcome,
+		Diff:             report.Diff,
+		DetectedAt:       now,
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.lastDrift[workspace.TenantID] = report.Checksum
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *SchedulerService) claim(tenantID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[tenantID] {
+		return false
+	}
+	s.running[tenantID] = true
+	return true
+}
+
+func (s *SchedulerService) release(tenantID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, tenantID)
+}
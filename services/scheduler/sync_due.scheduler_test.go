@@ -0,0 +1,20 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	schedulerTest "github.com/kamil5b/go-nl2query-lib/testsuites/scheduler"
+)
+
+func TestSchedulerService_SyncDue(t *testing.T) {
+	schedulerTest.UnitTestSyncDue(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+		workspaceService ports.WorkspaceService,
+		driftNotifierAdapter ports.DriftNotifierPort,
+	) ports.SchedulerService {
+		return NewSchedulerService(nil, statusAdapter, internalDatabaseAdapter, encryptAdapter, workspaceService, driftNotifierAdapter)
+	})
+}
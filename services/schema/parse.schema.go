@@ -0,0 +1,26 @@
+// Package schema reads database metadata from files describing a schema, for
+// workspaces whose database cannot be introspected and for documentation kept
+// outside the database.
+package schema
+
+import (
+	"fmt"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// Parse reads the metadata of a schema in the given format. Documentation,
+// such as dbt or Prisma descriptions, is read into Table.Description and
+// Column.Description; comments of a DDL script into Comments, as the database
+// would report them.
+func Parse(format domains.SchemaFormat, source string) (*domains.DatabaseMetadata, error) {
+	switch format {
+	case domains.SchemaFormatPostgresDDL, domains.SchemaFormatMySQLDDL:
+		return parseDDL(format, source)
+	case domains.SchemaFormatDBTManifest:
+		return parseDBTManifest(source)
+	case domains.SchemaFormatPrisma:
+		return parsePrisma(source)
+	}
+	return nil, fmt.Errorf("unsupported schema format %q", format)
+}
@@ -0,0 +1,25 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("dispatches on the format", func(t *testing.T) {
+		metadata, err := Parse(domains.SchemaFormatMySQLDDL, "CREATE TABLE `t` (`id` INT);")
+		require.NoError(t, err)
+		require.Equal(t, "t", metadata.Tables[0].Name)
+
+		metadata, err = Parse(domains.SchemaFormatPrisma, "model T {\n  id Int @id\n}")
+		require.NoError(t, err)
+		require.Equal(t, "T", metadata.Tables[0].Name)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Parse("oracle", "CREATE TABLE t (id NUMBER);")
+		require.EqualError(t, err, `unsupported schema format "oracle"`)
+	})
+}
@@ -0,0 +1,274 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+type dbtManifest struct {
+	Nodes   map[string]dbtNode `json:"nodes"`
+	Sources map[string]dbtNode `json:"sources"`
+}
+
+type dbtNode struct {
+	UniqueID     string          `json:"unique_id"`
+	ResourceType string          `json:"resource_type"`
+	Name         string          `json:"name"`
+	Alias        string          `json:"alias"`
+	Identifier   string          `json:"identifier"`
+	Description  string          `json:"description"`
+	Columns      dbtColumns      `json:"columns"`
+	Constraints  []dbtConstraint `json:"constraints"`
+	Config       struct {
+		Materialized string `json:"materialized"`
+	} `json:"config"`
+	DependsOn struct {
+		Nodes []string `json:"nodes"`
+	} `json:"depends_on"`
+
+	// Set on tests only
+	AttachedNode string `json:"attached_node"`
+	ColumnName   string `json:"column_name"`
+	TestMetadata *struct {
+		Name      string         `json:"name"`
+		Namespace string         `json:"namespace"`
+		Kwargs    map[string]any `json:"kwargs"`
+	} `json:"test_metadata"`
+}
+
+type dbtColumn struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	DataType    string          `json:"data_type"`
+	Constraints []dbtConstraint `json:"constraints"`
+}
+
+type dbtConstraint struct {
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+}
+
+// dbtColumns keeps the columns of a node in the order of the manifest.
+type dbtColumns []dbtColumn
+
+func (c *dbtColumns) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token == nil {
+		return err
+	}
+	for decoder.More() {
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+		var column dbtColumn
+		if err := decoder.Decode(&column); err != nil {
+			return err
+		}
+		*c = append(*c, column)
+	}
+	return nil
+}
+
+// parseDBTManifest reads the models, seeds, snapshots and sources of a dbt
+// manifest as tables. Generic tests become constraints: unique, not_null,
+// accepted_values and relationships, the latter also a relation. Each ref or
+// source a model is built from becomes a DERIVED_FROM relation between the
+// tables, without columns.
+func parseDBTManifest(source string) (*domains.DatabaseMetadata, error) {
+	var manifest dbtManifest
+	if err := json.Unmarshal([]byte(source), &manifest); err != nil {
+		return nil, fmt.Errorf("invalid dbt manifest: %w", err)
+	}
+
+	var ids []string
+	nodes := make(map[string]dbtNode, len(manifest.Nodes)+len(manifest.Sources))
+	for id, node := range manifest.Sources {
+		nodes[id] = node
+		ids = append(ids, id)
+	}
+	for id, node := range manifest.Nodes {
+		nodes[id] = node
+		if isDBTRelation(node) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	metadata := &domains.DatabaseMetadata{}
+	tables := make(map[string]*domains.Table, len(ids))
+	for _, id := range ids {
+		table := dbtTable(nodes[id])
+		tables[id] = &table
+	}
+
+	// Tests are applied in a stable order, so the constraints are too
+	var tests []string
+	for id, node := range manifest.Nodes {
+		if node.ResourceType == "test" && node.TestMetadata != nil && node.TestMetadata.Namespace == "" {
+			tests = append(tests, id)
+		}
+	}
+	sort.Strings(tests)
+	for _, id := range tests {
+		if relation := applyDBTTest(manifest.Nodes[id], tables); relation != nil {
+			metadata.Relations = append(metadata.Relations, *relation)
+		}
+	}
+
+	for _, id := range ids {
+		table := tables[id]
+		for _, dependency := range nodes[id].DependsOn.Nodes {
+			if target, ok := tables[dependency]; ok && dependency != id {
+				metadata.Relations = append(metadata.Relations, domains.Relation{
+					SourceTable:  table.Name,
+					TargetTable:  target.Name,
+					RelationType: "DERIVED_FROM",
+				})
+			}
+		}
+		metadata.Tables = append(metadata.Tables, *table)
+	}
+	return metadata, nil
+}
+
+// isDBTRelation reports whether the node is built as a table or view.
+func isDBTRelation(node dbtNode) bool {
+	switch node.ResourceType {
+	case "model":
+		return node.Config.Materialized != "ephemeral"
+	case "seed", "snapshot":
+		return true
+	}
+	return false
+}
+
+// dbtTable names the table after the relation dbt builds, without its
+// database and schema, which depend on the dbt target.
+func dbtTable(node dbtNode) domains.Table {
+	table := domains.Table{Name: node.Name, Description: node.Description}
+	if node.Alias != "" {
+		table.Name = node.Alias
+	}
+	if node.Identifier != "" {
+		table.Name = node.Identifier
+	}
+
+	for _, column := range node.Columns {
+		table.Columns = append(table.Columns, domains.Column{
+			Name:        column.Name,
+			Type:        column.DataType,
+			Nullable:    true,
+			Description: column.Description,
+		})
+	}
+
+	// Contract constraints, on a column or the whole model
+	for _, column := range node.Columns {
+		for _, constraint := range column.Constraints {
+			constraint.Columns = []string{column.Name}
+			applyDBTConstraint(&table, constraint)
+		}
+	}
+	for _, constraint := range node.Constraints {
+		applyDBTConstraint(&table, constraint)
+	}
+	return table
+}
+
+func applyDBTConstraint(table *domains.Table, constraint dbtConstraint) {
+	if len(constraint.Columns) == 0 {
+		return
+	}
+	switch constraint.Type {
+	case "primary_key":
+		name := constraint.Name
+		if name == "" {
+			name = table.Name + "_pkey"
+		}
+		for _, column := range constraint.Columns {
+			c := dbtColumnOf(table, column)
+			c.IsPrimaryKey = true
+			c.Nullable = false
+		}
+		table.Constraints = append(table.Constraints, domains.Constraint{Name: name, Type: "PRIMARY KEY", Columns: constraint.Columns})
+	case "unique":
+		name := constraint.Name
+		if name == "" {
+			name = table.Name + "_" + constraint.Columns[0] + "_key"
+		}
+		table.Constraints = append(table.Constraints, domains.Constraint{Name: name, Type: "UNIQUE", Columns: constraint.Columns})
+	case "not_null":
+		for _, column := range constraint.Columns {
+			dbtColumnOf(table, column).Nullable = false
+		}
+	}
+}
+
+// applyDBTTest turns a generic test into a constraint of the table it tests,
+// and returns the relation of a relationships test.
+func applyDBTTest(test dbtNode, tables map[string]*domains.Table) *domains.Relation {
+	table, ok := tables[test.AttachedNode]
+	if !ok {
+		return nil
+	}
+	column := test.ColumnName
+	if column == "" {
+		column, _ = test.TestMetadata.Kwargs["column_name"].(string)
+	}
+	if column == "" {
+		return nil
+	}
+	dbtColumnOf(table, column)
+
+	switch test.TestMetadata.Name {
+	case "unique":
+		table.Constraints = append(table.Constraints, domains.Constraint{Name: test.Name, Type: "UNIQUE", Columns: []string{column}})
+	case "not_null":
+		dbtColumnOf(table, column).Nullable = false
+	case "accepted_values":
+		table.Constraints = append(table.Constraints, domains.Constraint{Name: test.Name, Type: "CHECK", Columns: []string{column}})
+	case "relationships":
+		// The test depends on the tested node and on the one it refers to
+		field, _ := test.TestMetadata.Kwargs["field"].(string)
+		for _, dependency := range test.DependsOn.Nodes {
+			target, ok := tables[dependency]
+			if !ok || dependency == test.AttachedNode || field == "" {
+				continue
+			}
+			dbtColumnOf(table, column).IsForeignKey = true
+			table.Constraints = append(table.Constraints, domains.Constraint{
+				Name:      test.Name,
+				Type:      "FOREIGN KEY",
+				Columns:   []string{column},
+				Reference: target.Name + "(" + field + ")",
+			})
+			return &domains.Relation{
+				SourceTable:  table.Name,
+				SourceColumn: column,
+				TargetTable:  target.Name,
+				TargetColumn: field,
+				RelationType: "MANY_TO_ONE",
+			}
+		}
+	}
+	return nil
+}
+
+// dbtColumnOf returns the column, adding it when the manifest only names it
+// in a test or constraint.
+func dbtColumnOf(table *domains.Table, name string) *domains.Column {
+	index := slices.IndexFunc(table.Columns, func(column domains.Column) bool {
+		return column.Name == name
+	})
+	if index < 0 {
+		table.Columns = append(table.Columns, domains.Column{Name: name, Nullable: true})
+		index = len(table.Columns) - 1
+	}
+	return &table.Columns[index]
+}
@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDBTManifest(t *testing.T) {
+	manifest := `{
+  "metadata": {"dbt_schema_version": "https://schemas.getdbt.com/dbt/manifest/v12.json"},
+  "nodes": {
+    "model.shop.orders": {
+      "unique_id": "model.shop.orders",
+      "resource_type": "model",
+      "name": "orders",
+      "alias": "fct_orders",
+      "description": "One row per order",
+      "config": {"materialized": "table"},
+      "columns": {
+        "order_id": {"name": "order_id", "description": "Order key", "data_type": "integer",
+                     "constraints": [{"type": "primary_key"}]},
+        "customer_id": {"name": "customer_id", "description": "Who ordered"},
+        "status": {"name": "status", "description": ""}
+      },
+      "depends_on": {"nodes": ["macro.dbt.is_incremental", "model.shop.stg_orders", "source.shop.raw.customers"]}
+    },
+    "model.shop.stg_orders": {
+      "unique_id": "model.shop.stg_orders",
+      "resource_type": "model",
+      "name": "stg_orders",
+      "config": {"materialized": "ephemeral"},
+      "columns": {}
+    },
+    "test.shop.not_null_orders_customer_id.a1": {
+      "resource_type": "test",
+      "name": "not_null_orders_customer_id",
+      "attached_node": "model.shop.orders",
+      "column_name": "customer_id",
+      "test_metadata": {"name": "not_null", "kwargs": {"column_name": "customer_id"}}
+    },
+    "test.shop.accepted_values_orders_status.b2": {
+      "resource_type": "test",
+      "name": "accepted_values_orders_status",
+      "attached_node": "model.shop.orders",
+      "column_name": "status",
+      "test_metadata": {"name": "accepted_values", "kwargs": {"column_name": "status", "values": ["placed", "shipped"]}}
+    },
+    "test.shop.relationships_orders_customer_id.c3": {
+      "resource_type": "test",
+      "name": "relationships_orders_customer_id",
+      "attached_node": "model.shop.orders",
+      "column_name": "customer_id",
+      "test_metadata": {"name": "relationships", "kwargs": {"column_name": "customer_id", "to": "source('raw', 'customers')", "field": "id"}},
+      "depends_on": {"nodes": ["source.shop.raw.customers", "model.shop.orders"]}
+    },
+    "test.shop.unique_customers_id.d4": {
+      "resource_type": "test",
+      "name": "source_unique_raw_customers_id",
+      "attached_node": "source.shop.raw.customers",
+      "test_metadata": {"name": "unique", "kwargs": {"column_name": "id"}}
+    },
+    "test.shop.custom.e5": {
+      "resource_type": "test",
+      "name": "dbt_utils_expression_is_true_orders",
+      "attached_node": "model.shop.orders",
+      "test_metadata": {"name": "expression_is_true", "namespace": "dbt_utils", "kwargs": {"column_name": "status"}}
+    }
+  },
+  "sources": {
+    "source.shop.raw.customers": {
+      "unique_id": "source.shop.raw.customers",
+      "resource_type": "source",
+      "name": "customers",
+      "identifier": "raw_customers",
+      "description": "Customers as loaded from the app",
+      "columns": {"name": {"name": "name", "description": "Full name", "data_type": "text"}}
+    }
+  }
+}`
+
+	t.Run("models, sources, tests and refs", func(t *testing.T) {
+		metadata, err := parseDBTManifest(manifest)
+		require.NoError(t, err)
+		require.Equal(t, &domains.DatabaseMetadata{
+			Tables: []domains.Table{
+				{
+					Name:        "fct_orders",
+					Description: "One row per order",
+					Columns: []domains.Column{
+						{Name: "order_id", Type: "integer", IsPrimaryKey: true, Description: "Order key"},
+						{Name: "customer_id", IsForeignKey: true, Description: "Who ordered"},
+						{Name: "status", Nullable: true},
+					},
+					Constraints: []domains.Constraint{
+						{Name: "fct_orders_pkey", Type: "PRIMARY KEY", Columns: []string{"order_id"}},
+						{Name: "accepted_values_orders_status", Type: "CHECK", Columns: []string{"status"}},
+						{Name: "relationships_orders_customer_id", Type: "FOREIGN KEY", Columns: []string{"customer_id"}, Reference: "raw_customers(id)"},
+					},
+				},
+				{
+					Name:        "raw_customers",
+					Description: "Customers as loaded from the app",
+					Columns: []domains.Column{
+						{Name: "name", Type: "text", Nullable: true, Description: "Full name"},
+						{Name: "id", Nullable: true},
+					},
+					Constraints: []domains.Constraint{
+						{Name: "source_unique_raw_customers_id", Type: "UNIQUE", Columns: []string{"id"}},
+					},
+				},
+			},
+			Relations: []domains.Relation{
+				{SourceTable: "fct_orders", SourceColumn: "customer_id", TargetTable: "raw_customers", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+				{SourceTable: "fct_orders", TargetTable: "raw_customers", RelationType: "DERIVED_FROM"},
+			},
+		}, metadata)
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		_, err := parseDBTManifest(`{"nodes": [`)
+		require.EqualError(t, err, "invalid dbt manifest: unexpected end of JSON input")
+	})
+}
@@ -0,0 +1,1128 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+type ddlTokenKind int
+
+const (
+	ddlWord ddlTokenKind = iota
+	ddlIdentifier
+	ddlString
+	ddlSymbol
+)
+
+// ddlToken is a token of a DDL script. Quoted identifiers and strings hold
+// their unquoted text; start and end locate the token in the script.
+type ddlToken struct {
+	kind  ddlTokenKind
+	text  string
+	start int
+	end   int
+	line  int
+}
+
+// parseDDL reads the tables, columns, indexes, constraints, views and
+// comments of a Postgres or MySQL DDL script, such as the output of pg_dump
+// --schema-only or mysqldump --no-data. Statements it does not model, like
+// SET, INSERT or CREATE FUNCTION, are ignored.
+func parseDDL(format domains.SchemaFormat, script string) (*domains.DatabaseMetadata, error) {
+	mysql := format == domains.SchemaFormatMySQLDDL
+	statements, err := splitDDL(script, mysql)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &schemaBuilder{mysql: mysql, byName: map[string]*domains.Table{}}
+	for _, statement := range statements {
+		p := &ddlParser{script: script, tokens: statement, mysql: mysql}
+		if err := builder.statement(p); err != nil {
+			return nil, err
+		}
+	}
+	return builder.metadata(), nil
+}
+
+// splitDDL tokenizes the script and splits it into statements.
+func splitDDL(script string, mysql bool) ([][]ddlToken, error) {
+	var (
+		statements [][]ddlToken
+		statement  []ddlToken
+		line       = 1
+		executable bool
+	)
+
+	for i := 0; i < len(script); {
+		c := script[i]
+		next := byte(0)
+		if i+1 < len(script) {
+			next = script[i+1]
+		}
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case c == '-' && next == '-', c == '#' && mysql:
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+		case c == '/' && next == '*' && mysql && i+2 < len(script) && script[i+2] == '!':
+			// MySQL runs the content of /*!NNNNN ... */ comments
+			i += 3
+			for i < len(script) && script[i] >= '0' && script[i] <= '9' {
+				i++
+			}
+			executable = true
+		case c == '*' && next == '/' && executable:
+			i += 2
+			executable = false
+		case c == '/' && next == '*':
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(script[i:i+2+end], "\n")
+			i += end + 4
+		case c == ';':
+			if len(statement) > 0 {
+				statements = append(statements, statement)
+				statement = nil
+			}
+			i++
+		case c == '\'', c == '"' && mysql:
+			text, end, ok := readQuoted(script, i, mysql)
+			if !ok {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			statement = append(statement, ddlToken{kind: ddlString, text: text, start: i, end: end, line: line})
+			line += strings.Count(script[i:end], "\n")
+			i = end
+		case c == '"', c == '`':
+			text, end, ok := readQuoted(script, i, false)
+			if !ok {
+				return nil, fmt.Errorf("line %d: unterminated identifier", line)
+			}
+			statement = append(statement, ddlToken{kind: ddlIdentifier, text: text, start: i, end: end, line: line})
+			line += strings.Count(script[i:end], "\n")
+			i = end
+		case c == '$' && !mysql && dollarTag(script[i:]) != "":
+			// Postgres dollar-quoted string, such as a function body
+			tag := dollarTag(script[i:])
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			end += i + 2*len(tag)
+			statement = append(statement, ddlToken{kind: ddlString, text: script[i+len(tag) : end-len(tag)], start: i, end: end, line: line})
+			line += strings.Count(script[i:end], "\n")
+			i = end
+		case isWordByte(c):
+			start := i
+			for i < len(script) && isWordByte(script[i]) {
+				i++
+			}
+			statement = append(statement, ddlToken{kind: ddlWord, text: script[start:i], start: start, end: i, line: line})
+		default:
+			statement = append(statement, ddlToken{kind: ddlSymbol, text: string(c), start: i, end: i + 1, line: line})
+			i++
+		}
+	}
+
+	if len(statement) > 0 {
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+// readQuoted reads the quoted text starting at script[start]. A doubled quote
+// is an escaped quote, as is a backslash escape when backslash is set.
+func readQuoted(script string, start int, backslash bool) (string, int, bool) {
+	quote := script[start]
+	var text strings.Builder
+	for i := start + 1; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case backslash && c == '\\' && i+1 < len(script):
+			i++
+			switch script[i] {
+			case 'n':
+				text.WriteByte('\n')
+			case 't':
+				text.WriteByte('\t')
+			default:
+				text.WriteByte(script[i])
+			}
+		case c == quote && i+1 < len(script) && script[i+1] == quote:
+			text.WriteByte(quote)
+			i++
+		case c == quote:
+			return text.String(), i + 1, true
+		default:
+			text.WriteByte(c)
+		}
+	}
+	return "", 0, false
+}
+
+// dollarTag returns the $tag$ opening a dollar-quoted string, if any.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '$':
+			return s[:i+1]
+		case !isWordByte(s[i]) || s[i] == '$' || (i == 1 && s[i] >= '0' && s[i] <= '9'):
+			return ""
+		}
+	}
+	return ""
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// ddlParser walks the tokens of one statement.
+type ddlParser struct {
+	script string
+	tokens []ddlToken
+	pos    int
+	mysql  bool
+}
+
+func (p *ddlParser) current() *ddlToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *ddlParser) at(offset int) *ddlToken {
+	if p.pos+offset >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos+offset]
+}
+
+// isWord reports whether the current token is one of the unquoted words.
+func (p *ddlParser) isWord(words ...string) bool {
+	return isWord(p.current(), words...)
+}
+
+func isWord(token *ddlToken, words ...string) bool {
+	if token == nil || token.kind != ddlWord {
+		return false
+	}
+	for _, word := range words {
+		if strings.EqualFold(token.text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ddlParser) isSymbol(symbol string) bool {
+	token := p.current()
+	return token != nil && token.kind == ddlSymbol && token.text == symbol
+}
+
+// acceptWords consumes the sequence of words, or nothing if it does not
+// follow.
+func (p *ddlParser) acceptWords(words ...string) bool {
+	for i, word := range words {
+		if !isWord(p.at(i), word) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *ddlParser) acceptSymbol(symbol string) bool {
+	if !p.isSymbol(symbol) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *ddlParser) expectWord(word string) error {
+	if !p.acceptWords(word) {
+		return p.unexpected(word)
+	}
+	return nil
+}
+
+func (p *ddlParser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.unexpected(strconv.Quote(symbol))
+	}
+	return nil
+}
+
+func (p *ddlParser) unexpected(expected string) error {
+	token := p.current()
+	if token == nil {
+		last := p.tokens[len(p.tokens)-1]
+		return fmt.Errorf("line %d: expected %s, found end of statement", last.line, expected)
+	}
+	return fmt.Errorf("line %d: expected %s, found %q", token.line, expected, token.text)
+}
+
+// skip moves past the current token, or past the whole parenthesized group
+// it opens.
+func (p *ddlParser) skip() {
+	if p.current() == nil {
+		return
+	}
+	if !p.isSymbol("(") {
+		p.pos++
+		return
+	}
+	for depth := 0; p.current() != nil; p.pos++ {
+		switch {
+		case p.isSymbol("("):
+			depth++
+		case p.isSymbol(")"):
+			depth--
+		}
+		if depth == 0 {
+			p.pos++
+			return
+		}
+	}
+}
+
+// atElementEnd reports whether the current table element or ALTER TABLE
+// action is over.
+func (p *ddlParser) atElementEnd() bool {
+	return p.current() == nil || p.isSymbol(",") || p.isSymbol(")")
+}
+
+func (p *ddlParser) skipElement() {
+	for !p.atElementEnd() {
+		p.skip()
+	}
+}
+
+// raw returns the script text of the tokens from start up to the current one.
+func (p *ddlParser) raw(start int) string {
+	if start >= p.pos {
+		return ""
+	}
+	return p.script[p.tokens[start].start:p.tokens[p.pos-1].end]
+}
+
+// identifier reads one identifier. Postgres folds unquoted identifiers to
+// lower case.
+func (p *ddlParser) identifier() (string, error) {
+	token := p.current()
+	if token == nil || (token.kind != ddlWord && token.kind != ddlIdentifier) {
+		return "", p.unexpected("identifier")
+	}
+	p.pos++
+	if token.kind == ddlWord && !p.mysql {
+		return strings.ToLower(token.text), nil
+	}
+	return token.text, nil
+}
+
+// name reads a possibly qualified name, such as schema.table.column.
+func (p *ddlParser) name() ([]string, error) {
+	var parts []string
+	for {
+		part, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if !p.acceptSymbol(".") {
+			return parts, nil
+		}
+	}
+}
+
+// tableName reads a table name. The default Postgres schema is left out, so
+// public.orders and orders name the same table.
+func (p *ddlParser) tableName() (string, error) {
+	parts, err := p.name()
+	if err != nil {
+		return "", err
+	}
+	return p.qualified(parts), nil
+}
+
+func (p *ddlParser) qualified(parts []string) string {
+	if len(parts) > 1 && !p.mysql && parts[0] == "public" {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, ".")
+}
+
+// columnList reads a parenthesized list of columns. Expressions, as in an
+// index on lower(email), are kept as written.
+func (p *ddlParser) columnList() ([]string, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	for !p.acceptSymbol(")") {
+		start := p.pos
+		token, next := p.current(), p.at(1)
+		if token == nil {
+			return nil, p.unexpected(`")"`)
+		}
+
+		// A MySQL key prefix, as in name(10), still names the column
+		isName := token.kind == ddlWord || token.kind == ddlIdentifier
+		if isName && next != nil && next.kind == ddlSymbol && next.text == "(" {
+			prefix := p.at(2)
+			isName = p.mysql && prefix != nil && prefix.kind == ddlWord && isNumber(prefix.text)
+		}
+		if isName {
+			column, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, column)
+			p.skipElement()
+		} else {
+			p.skipElement()
+			columns = append(columns, p.raw(start))
+		}
+		p.acceptSymbol(",")
+	}
+	return columns, nil
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// stringValue reads a string literal; NULL reads as an empty one.
+func (p *ddlParser) stringValue() (string, error) {
+	if p.acceptWords("NULL") {
+		return "", nil
+	}
+	token := p.current()
+	if token == nil || token.kind != ddlString {
+		return "", p.unexpected("string")
+	}
+	p.pos++
+	return token.text, nil
+}
+
+// isColumnKeyword reports whether the current token starts a column
+// constraint or attribute, which ends the column type or default.
+func (p *ddlParser) isColumnKeyword() bool {
+	switch {
+	case p.isWord("NOT", "NULL", "DEFAULT", "PRIMARY", "UNIQUE", "REFERENCES", "CHECK", "CONSTRAINT",
+		"COMMENT", "COLLATE", "GENERATED", "AS", "AUTO_INCREMENT", "AUTOINCREMENT", "CHARSET"):
+		return true
+	case p.isWord("KEY"):
+		return p.mysql
+	case p.isWord("ON"):
+		return isWord(p.at(1), "UPDATE")
+	case p.isWord("CHARACTER"):
+		return isWord(p.at(1), "SET")
+	}
+	return false
+}
+
+// foreignKey is resolved once every table is known, so a reference to the
+// primary key of a table created later is followed too.
+type foreignKey struct {
+	table       string
+	name        string
+	columns     []string
+	targetTable string
+	targetCols  []string
+}
+
+type schemaBuilder struct {
+	mysql       bool
+	tables      []*domains.Table
+	byName      map[string]*domains.Table
+	foreignKeys []foreignKey
+	// counts numbers the MySQL default constraint names per table
+	counts map[string]int
+}
+
+func (b *schemaBuilder) statement(p *ddlParser) error {
+	switch {
+	case p.acceptWords("CREATE"):
+		return b.create(p)
+	case p.acceptWords("ALTER", "TABLE"):
+		return b.alterTable(p)
+	case p.acceptWords("COMMENT", "ON"):
+		return b.comment(p)
+	}
+	return nil
+}
+
+func (b *schemaBuilder) create(p *ddlParser) error {
+	unique := false
+	for {
+		switch {
+		case p.acceptWords("OR", "REPLACE"), p.acceptWords("TEMP"), p.acceptWords("TEMPORARY"),
+			p.acceptWords("UNLOGGED"), p.acceptWords("GLOBAL"), p.acceptWords("LOCAL"),
+			p.acceptWords("RECURSIVE"), p.acceptWords("MATERIALIZED"), p.acceptWords("FULLTEXT"),
+			p.acceptWords("SPATIAL"):
+		case p.acceptWords("UNIQUE"):
+			unique = true
+		case p.acceptWords("ALGORITHM"), p.acceptWords("DEFINER"), p.acceptWords("SQL", "SECURITY"):
+			// MySQL view options, such as DEFINER=`root`@`%`
+			p.acceptSymbol("=")
+			p.skip()
+			if p.acceptSymbol("@") {
+				p.skip()
+			}
+		default:
+			switch {
+			case p.acceptWords("TABLE"):
+				return b.createTable(p)
+			case p.acceptWords("INDEX"):
+				return b.createIndex(p, unique)
+			case p.acceptWords("VIEW"):
+				return b.createView(p)
+			}
+			return nil
+		}
+	}
+}
+
+func (b *schemaBuilder) createTable(p *ddlParser) error {
+	p.acceptWords("IF", "NOT", "EXISTS")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	// CREATE TABLE ... AS, LIKE or PARTITION OF copies a shape it does not
+	// spell out
+	if !p.acceptSymbol("(") {
+		return nil
+	}
+
+	table := b.newTable(name)
+	for !p.acceptSymbol(")") {
+		if err := b.tableElement(p, table); err != nil {
+			return err
+		}
+		if !p.acceptSymbol(",") && !p.isSymbol(")") {
+			return p.unexpected(`"," or ")"`)
+		}
+	}
+
+	// MySQL table options, such as ENGINE=InnoDB COMMENT='...'
+	for p.current() != nil {
+		if p.acceptWords("COMMENT") {
+			p.acceptSymbol("=")
+			comment, err := p.stringValue()
+			if err != nil {
+				return err
+			}
+			table.Comments = comment
+			continue
+		}
+		p.skip()
+	}
+	return nil
+}
+
+// tableElement reads a column or a table constraint.
+func (b *schemaBuilder) tableElement(p *ddlParser, table *domains.Table) error {
+	var name string
+	if p.acceptWords("CONSTRAINT") {
+		if !p.isWord("PRIMARY", "UNIQUE", "FOREIGN", "CHECK") {
+			parts, err := p.name()
+			if err != nil {
+				return err
+			}
+			name = p.qualified(parts)
+		}
+	}
+
+	switch {
+	case p.acceptWords("PRIMARY", "KEY"):
+		columns, err := b.indexColumns(p)
+		if err != nil {
+			return err
+		}
+		b.primaryKey(table, name, columns)
+	case p.acceptWords("UNIQUE"):
+		p.acceptWords("NULLS", "NOT", "DISTINCT")
+		if p.acceptWords("KEY") || p.acceptWords("INDEX") || (p.mysql && !p.isSymbol("(")) {
+			if index, err := b.optionalIndexName(p); err != nil {
+				return err
+			} else if name == "" {
+				name = index
+			}
+		}
+		columns, err := b.indexColumns(p)
+		if err != nil {
+			return err
+		}
+		b.constraint(table, name, "UNIQUE", columns)
+	case p.acceptWords("FOREIGN", "KEY"):
+		if index, err := b.optionalIndexName(p); err != nil {
+			return err
+		} else if name == "" {
+			name = index
+		}
+		columns, err := p.columnList()
+		if err != nil {
+			return err
+		}
+		if err := p.expectWord("REFERENCES"); err != nil {
+			return err
+		}
+		if err := b.references(p, table, name, columns); err != nil {
+			return err
+		}
+	case p.acceptWords("CHECK"):
+		b.constraint(table, name, "CHECK", nil)
+	case p.mysql && (p.isWord("KEY", "INDEX", "FULLTEXT", "SPATIAL")):
+		p.acceptWords("FULLTEXT")
+		p.acceptWords("SPATIAL")
+		if !p.acceptWords("KEY") {
+			p.acceptWords("INDEX")
+		}
+		index, err := b.optionalIndexName(p)
+		if err != nil {
+			return err
+		}
+		columns, err := b.indexColumns(p)
+		if err != nil {
+			return err
+		}
+		b.index(table, index, columns, false)
+	case p.isWord("LIKE") || (p.isWord("EXCLUDE") && (isWord(p.at(1), "USING") || p.at(1) != nil && p.at(1).text == "(")):
+	default:
+		return b.column(p, table)
+	}
+
+	p.skipElement()
+	return nil
+}
+
+// optionalIndexName reads the name MySQL allows before a key's columns.
+func (b *schemaBuilder) optionalIndexName(p *ddlParser) (string, error) {
+	if p.isSymbol("(") || p.isWord("USING") {
+		return "", nil
+	}
+	return p.identifier()
+}
+
+// indexColumns reads the columns of a key, after an optional USING method.
+func (b *schemaBuilder) indexColumns(p *ddlParser) ([]string, error) {
+	if p.acceptWords("USING") {
+		p.skip()
+	}
+	return p.columnList()
+}
+
+func (b *schemaBuilder) column(p *ddlParser, table *domains.Table) error {
+	name, err := p.identifier()
+	if err != nil {
+		return err
+	}
+	column := domains.Column{Name: name, Nullable: true}
+
+	start := p.pos
+	for !p.atElementEnd() && !p.isColumnKeyword() {
+		p.skip()
+	}
+	if start == p.pos {
+		return p.unexpected("type of column " + name)
+	}
+	column.Type = p.raw(start)
+
+	var constraint string
+	for !p.atElementEnd() {
+		switch {
+		case p.acceptWords("CONSTRAINT"):
+			parts, err := p.name()
+			if err != nil {
+				return err
+			}
+			constraint = p.qualified(parts)
+			continue
+		case p.acceptWords("NOT", "NULL"):
+			column.Nullable = false
+		case p.acceptWords("NULL"):
+			column.Nullable = true
+		case p.acceptWords("DEFAULT"):
+			start := p.pos
+			p.skipExpression()
+			column.Default = p.raw(start)
+		case p.acceptWords("PRIMARY", "KEY"), p.mysql && p.acceptWords("KEY"):
+			column.IsPrimaryKey = true
+			column.Nullable = false
+			b.constraint(table, constraint, "PRIMARY KEY", []string{name})
+		case p.acceptWords("UNIQUE"):
+			p.acceptWords("KEY")
+			b.constraint(table, constraint, "UNIQUE", []string{name})
+		case p.acceptWords("REFERENCES"):
+			if err := b.references(p, table, constraint, []string{name}); err != nil {
+				return err
+			}
+		case p.acceptWords("CHECK"):
+			p.skip()
+			b.constraint(table, constraint, "CHECK", []string{name})
+		case p.acceptWords("COMMENT"):
+			comment, err := p.stringValue()
+			if err != nil {
+				return err
+			}
+			column.Comments = comment
+		case p.acceptWords("ON", "UPDATE"):
+			p.skipExpression()
+		default:
+			p.skip()
+		}
+		constraint = ""
+	}
+
+	table.Columns = append(table.Columns, column)
+	return nil
+}
+
+// skipExpression moves past a default or ON UPDATE expression.
+func (p *ddlParser) skipExpression() {
+	if p.atElementEnd() {
+		return
+	}
+	p.skip()
+	for !p.atElementEnd() && !p.isColumnKeyword() {
+		p.skip()
+	}
+}
+
+// references reads the target of a foreign key, after REFERENCES.
+func (b *schemaBuilder) references(p *ddlParser, table *domains.Table, name string, columns []string) error {
+	target, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	var targetCols []string
+	if p.isSymbol("(") {
+		if targetCols, err = p.columnList(); err != nil {
+			return err
+		}
+	}
+	if name == "" {
+		name = b.constraintName(table, "FOREIGN KEY", columns)
+	}
+	b.foreignKeys = append(b.foreignKeys, foreignKey{
+		table:       table.Name,
+		name:        name,
+		columns:     columns,
+		targetTable: target,
+		targetCols:  targetCols,
+	})
+	return nil
+}
+
+func (b *schemaBuilder) createIndex(p *ddlParser, unique bool) error {
+	p.acceptWords("CONCURRENTLY")
+	p.acceptWords("IF", "NOT", "EXISTS")
+	var name string
+	if !p.isWord("ON") {
+		parts, err := p.name()
+		if err != nil {
+			return err
+		}
+		name = parts[len(parts)-1]
+	}
+	if p.acceptWords("USING") {
+		p.skip()
+	}
+	if err := p.expectWord("ON"); err != nil {
+		return err
+	}
+	p.acceptWords("ONLY")
+	tableName, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	columns, err := b.indexColumns(p)
+	if err != nil {
+		return err
+	}
+
+	if table := b.lookup(tableName); table != nil {
+		b.index(table, name, columns, unique)
+	}
+	return nil
+}
+
+func (b *schemaBuilder) createView(p *ddlParser) error {
+	p.acceptWords("IF", "NOT", "EXISTS")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+	if p.isSymbol("(") {
+		if columns, err = p.columnList(); err != nil {
+			return err
+		}
+	}
+	if p.acceptWords("WITH") {
+		p.skip()
+	}
+	if err := p.expectWord("AS"); err != nil {
+		return err
+	}
+	if columns == nil {
+		columns = p.selectColumns()
+	}
+
+	view := b.newTable(name)
+	for _, column := range columns {
+		view.Columns = append(view.Columns, domains.Column{Name: column, Nullable: true})
+	}
+	return nil
+}
+
+// selectColumns returns the names of the columns of the outermost SELECT: the
+// alias or column name of each item, or the expression as written.
+func (p *ddlParser) selectColumns() []string {
+	for p.current() != nil && !p.isWord("SELECT") {
+		p.skip()
+	}
+	if !p.acceptWords("SELECT") {
+		return nil
+	}
+	if p.acceptWords("DISTINCT") {
+		if p.acceptWords("ON") {
+			p.skip()
+		}
+	}
+	p.acceptWords("ALL")
+
+	var columns []string
+	for p.current() != nil {
+		start := p.pos
+		for p.current() != nil && !p.isSymbol(",") && !p.isWord("FROM", "WHERE", "UNION", "INTO", "INTERSECT", "EXCEPT") {
+			p.skip()
+		}
+		if column := p.selectColumn(start); column != "" {
+			columns = append(columns, column)
+		}
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	return columns
+}
+
+func (p *ddlParser) selectColumn(start int) string {
+	end := p.pos
+	if end == start {
+		return ""
+	}
+	last := p.tokens[end-1]
+	if last.kind == ddlSymbol && last.text == "*" {
+		return ""
+	}
+	if last.kind == ddlWord || last.kind == ddlIdentifier {
+		if end-start == 1 {
+			return p.identifierText(last)
+		}
+		previous := p.tokens[end-2]
+		if previous.kind != ddlSymbol || previous.text == "." || previous.text == ")" {
+			return p.identifierText(last)
+		}
+	}
+	return p.raw(start)
+}
+
+func (p *ddlParser) identifierText(token ddlToken) string {
+	if token.kind == ddlWord && !p.mysql {
+		return strings.ToLower(token.text)
+	}
+	return token.text
+}
+
+func (b *schemaBuilder) alterTable(p *ddlParser) error {
+	p.acceptWords("IF", "EXISTS")
+	p.acceptWords("ONLY")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	table := b.lookup(name)
+	if table == nil {
+		return nil
+	}
+
+	for p.current() != nil {
+		switch {
+		case p.acceptWords("ADD"):
+			p.acceptWords("COLUMN")
+			p.acceptWords("IF", "NOT", "EXISTS")
+			if err := b.tableElement(p, table); err != nil {
+				return err
+			}
+		case p.acceptWords("ALTER"):
+			// pg_dump sets serial defaults this way
+			p.acceptWords("COLUMN")
+			column, err := p.identifier()
+			if err != nil {
+				return err
+			}
+			b.alterColumn(p, table, column)
+		}
+		p.skipElement()
+		if !p.acceptSymbol(",") {
+			p.skip()
+		}
+	}
+	return nil
+}
+
+func (b *schemaBuilder) alterColumn(p *ddlParser, table *domains.Table, name string) {
+	var column *domains.Column
+	for i := range table.Columns {
+		if table.Columns[i].Name == name {
+			column = &table.Columns[i]
+		}
+	}
+	if column == nil {
+		return
+	}
+
+	switch {
+	case p.acceptWords("SET", "DEFAULT"):
+		start := p.pos
+		p.skipElement()
+		column.Default = p.raw(start)
+	case p.acceptWords("DROP", "DEFAULT"):
+		column.Default = ""
+	case p.acceptWords("SET", "NOT", "NULL"):
+		column.Nullable = false
+	case p.acceptWords("DROP", "NOT", "NULL"):
+		column.Nullable = true
+	}
+}
+
+func (b *schemaBuilder) comment(p *ddlParser) error {
+	var column bool
+	switch {
+	case p.acceptWords("TABLE"), p.acceptWords("VIEW"), p.acceptWords("MATERIALIZED", "VIEW"):
+	case p.acceptWords("COLUMN"):
+		column = true
+	default:
+		return nil
+	}
+
+	parts, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expectWord("IS"); err != nil {
+		return err
+	}
+	text, err := p.stringValue()
+	if err != nil {
+		return err
+	}
+
+	if !column {
+		if table := b.lookup(p.qualified(parts)); table != nil {
+			table.Comments = text
+		}
+		return nil
+	}
+	if len(parts) < 2 {
+		return nil
+	}
+	table := b.lookup(p.qualified(parts[:len(parts)-1]))
+	if table == nil {
+		return nil
+	}
+	for i := range table.Columns {
+		if table.Columns[i].Name == parts[len(parts)-1] {
+			table.Columns[i].Comments = text
+		}
+	}
+	return nil
+}
+
+// newTable adds a table, replacing an earlier one of the same name as a
+// script that drops and creates it again would.
+func (b *schemaBuilder) newTable(name string) *domains.Table {
+	table := &domains.Table{Name: name}
+	if existing := b.byName[name]; existing != nil {
+		*existing = *table
+		table = existing
+	} else {
+		b.tables = append(b.tables, table)
+		b.byName[name] = table
+	}
+
+	kept := b.foreignKeys[:0]
+	for _, foreignKey := range b.foreignKeys {
+		if foreignKey.table != name {
+			kept = append(kept, foreignKey)
+		}
+	}
+	b.foreignKeys = kept
+	return table
+}
+
+func (b *schemaBuilder) primaryKey(table *domains.Table, name string, columns []string) {
+	for i := range table.Columns {
+		for _, column := range columns {
+			if table.Columns[i].Name == column {
+				table.Columns[i].IsPrimaryKey = true
+				table.Columns[i].Nullable = false
+			}
+		}
+	}
+	b.constraint(table, name, "PRIMARY KEY", columns)
+}
+
+func (b *schemaBuilder) constraint(table *domains.Table, name string, kind string, columns []string) {
+	if name == "" {
+		name = b.constraintName(table, kind, columns)
+	}
+	table.Constraints = append(table.Constraints, domains.Constraint{
+		Name:    name,
+		Type:    kind,
+		Columns: columns,
+	})
+}
+
+func (b *schemaBuilder) index(table *domains.Table, name string, columns []string, unique bool) {
+	if name == "" {
+		name = b.constraintName(table, "INDEX", columns)
+	}
+	table.Indexes = append(table.Indexes, domains.Index{
+		Name:    name,
+		Columns: columns,
+		Unique:  unique,
+	})
+}
+
+// constraintName returns the name the database would give an unnamed
+// constraint or index.
+func (b *schemaBuilder) constraintName(table *domains.Table, kind string, columns []string) string {
+	tableName := table.Name[strings.LastIndex(table.Name, ".")+1:]
+	if b.mysql {
+		if b.counts == nil {
+			b.counts = map[string]int{}
+		}
+		switch kind {
+		case "PRIMARY KEY":
+			return "PRIMARY"
+		case "FOREIGN KEY", "CHECK":
+			key := table.Name + " " + kind
+			b.counts[key]++
+			suffix := map[string]string{"FOREIGN KEY": "ibfk", "CHECK": "chk"}[kind]
+			return fmt.Sprintf("%s_%s_%d", tableName, suffix, b.counts[key])
+		}
+		if len(columns) > 0 {
+			return columns[0]
+		}
+		return tableName
+	}
+
+	suffix := map[string]string{
+		"PRIMARY KEY": "pkey",
+		"FOREIGN KEY": "fkey",
+		"UNIQUE":      "key",
+		"CHECK":       "check",
+		"INDEX":       "idx",
+	}[kind]
+	if kind == "PRIMARY KEY" || len(columns) == 0 {
+		return tableName + "_" + suffix
+	}
+	return tableName + "_" + strings.Join(columns, "_") + "_" + suffix
+}
+
+// lookup returns the table of the given name. MySQL table names are matched
+// case-insensitively, as on the default macOS and Windows file systems.
+func (b *schemaBuilder) lookup(name string) *domains.Table {
+	if table := b.byName[name]; table != nil || !b.mysql {
+		return table
+	}
+	for _, table := range b.tables {
+		if strings.EqualFold(table.Name, name) {
+			return table
+		}
+	}
+	return nil
+}
+
+// primaryKeyColumns returns the primary key columns of the table, in
+// declaration order.
+func primaryKeyColumns(table *domains.Table) []string {
+	var columns []string
+	for _, column := range table.Columns {
+		if column.IsPrimaryKey {
+			columns = append(columns, column.Name)
+		}
+	}
+	return columns
+}
+
+// metadata resolves the foreign keys into constraints and relations.
+func (b *schemaBuilder) metadata() *domains.DatabaseMetadata {
+	metadata := &domains.DatabaseMetadata{}
+	for _, foreignKey := range b.foreignKeys {
+		table := b.byName[foreignKey.table]
+		targetTable, targetCols := foreignKey.targetTable, foreignKey.targetCols
+		if target := b.lookup(targetTable); target != nil {
+			targetTable = target.Name
+			// Without a column list, REFERENCES targets the primary key.
+			if len(targetCols) == 0 {
+				targetCols = primaryKeyColumns(target)
+			}
+		}
+
+		reference := targetTable
+		if len(targetCols) > 0 {
+			reference += "(" + strings.Join(targetCols, ", ") + ")"
+		}
+		table.Constraints = append(table.Constraints, domains.Constraint{
+			Name:      foreignKey.name,
+			Type:      "FOREIGN KEY",
+			Columns:   foreignKey.columns,
+			Reference: reference,
+		})
+		for i, name := range foreignKey.columns {
+			for j := range table.Columns {
+				if table.Columns[j].Name == name {
+					table.Columns[j].IsForeignKey = true
+				}
+			}
+			if i < len(targetCols) {
+				metadata.Relations = append(metadata.Relations, domains.Relation{
+					SourceTable:  foreignKey.table,
+					SourceColumn: name,
+					TargetTable:  targetTable,
+					TargetColumn: targetCols[i],
+					RelationType: "MANY_TO_ONE",
+				})
+			}
+		}
+	}
+
+	for _, table := range b.tables {
+		metadata.Tables = append(metadata.Tables, *table)
+	}
+	return metadata
+}
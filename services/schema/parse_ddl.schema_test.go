@@ -0,0 +1,280 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDDL(t *testing.T) {
+	postgresScript := `
+-- pg_dump --schema-only
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE FUNCTION public.touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now(); -- not a statement end
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE public.customers (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL,
+    "City" text DEFAULT 'Jakarta'::text,
+    CONSTRAINT customers_email_key UNIQUE (email)
+);
+
+COMMENT ON TABLE public.customers IS 'People who place orders';
+COMMENT ON COLUMN public.customers.email IS 'Login e-mail, it''s unique';
+
+CREATE TABLE Orders (
+    id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    customer_id integer REFERENCES customers ON DELETE CASCADE,
+    total numeric(10,2) DEFAULT 0 NOT NULL CHECK (total >= 0),
+    created_at timestamp with time zone DEFAULT now()
+);
+
+ALTER TABLE ONLY public.customers
+    ADD CONSTRAINT customers_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.customers ALTER COLUMN id SET DEFAULT nextval('public.customers_id_seq'::regclass);
+
+CREATE UNIQUE INDEX orders_created_idx ON public.orders USING btree (created_at DESC, lower((id)::text));
+CREATE INDEX ON orders (customer_id);
+
+CREATE VIEW public.customer_totals AS
+ SELECT c.id, c.email AS login, sum(o.total) total_spent, count(*)
+   FROM customers c JOIN orders o ON o.customer_id = c.id
+  GROUP BY c.id, c.email;
+COMMENT ON VIEW customer_totals IS 'Spend per customer';
+`
+
+	mysqlScript := "/*!40101 SET NAMES utf8mb4 */;\n" +
+		"DROP TABLE IF EXISTS `products`;\n" +
+		"CREATE TABLE `products` (\n" +
+		"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(255) CHARACTER SET utf8mb4 NOT NULL COMMENT 'Display name',\n" +
+		"  `status` enum('active','retired') DEFAULT 'active',\n" +
+		"  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uq_name` (`name`(100))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Catalog \\'items\\'';\n" +
+		"# order lines\n" +
+		"CREATE TABLE order_items (\n" +
+		"  id INT PRIMARY KEY,\n" +
+		"  product_id int unsigned NOT NULL,\n" +
+		"  KEY idx_product (product_id),\n" +
+		"  CONSTRAINT FOREIGN KEY (product_id) REFERENCES products (id)\n" +
+		");\n" +
+		"/*!50001 CREATE ALGORITHM=UNDEFINED */\n" +
+		"/*!50013 DEFINER=`root`@`localhost` SQL SECURITY DEFINER */\n" +
+		"/*!50001 VIEW `active_products` AS select `products`.`id` AS `id`,`products`.`name` AS `name` from `products` */;\n"
+
+	tests := []struct {
+		name        string
+		format      domains.SchemaFormat
+		script      string
+		expected    *domains.DatabaseMetadata
+		expectError error
+	}{
+		{
+			name:   "postgres",
+			format: domains.SchemaFormatPostgresDDL,
+			script: postgresScript,
+			expected: &domains.DatabaseMetadata{
+				Tables: []domains.Table{
+					{
+						Name:     "customers",
+						Comments: "People who place orders",
+						Columns: []domains.Column{
+							{Name: "id", Type: "integer", Default: "nextval('public.customers_id_seq'::regclass)", IsPrimaryKey: true},
+							{Name: "email", Type: "character varying(255)", Comments: "Login e-mail, it's unique"},
+							{Name: "City", Type: "text", Nullable: true, Default: "'Jakarta'::text"},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "customers_email_key", Type: "UNIQUE", Columns: []string{"email"}},
+							{Name: "customers_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+						},
+					},
+					{
+						Name: "orders",
+						Columns: []domains.Column{
+							{Name: "id", Type: "bigint", IsPrimaryKey: true},
+							{Name: "customer_id", Type: "integer", Nullable: true, IsForeignKey: true},
+							{Name: "total", Type: "numeric(10,2)", Default: "0"},
+							{Name: "created_at", Type: "timestamp with time zone", Nullable: true, Default: "now()"},
+						},
+						Indexes: []domains.Index{
+							{Name: "orders_created_idx", Columns: []string{"created_at", "lower((id)::text)"}, Unique: true},
+							{Name: "orders_customer_id_idx", Columns: []string{"customer_id"}},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "orders_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+							{Name: "orders_total_check", Type: "CHECK", Columns: []string{"total"}},
+							{Name: "orders_customer_id_fkey", Type: "FOREIGN KEY", Columns: []string{"customer_id"}, Reference: "customers(id)"},
+						},
+					},
+					{
+						Name:     "customer_totals",
+						Comments: "Spend per customer",
+						Columns: []domains.Column{
+							{Name: "id", Nullable: true},
+							{Name: "login", Nullable: true},
+							{Name: "total_spent", Nullable: true},
+							{Name: "count(*)", Nullable: true},
+						},
+					},
+				},
+				Relations: []domains.Relation{
+					{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+				},
+			},
+		},
+		{
+			name:   "mysql",
+			format: domains.SchemaFormatMySQLDDL,
+			script: mysqlScript,
+			expected: &domains.DatabaseMetadata{
+				Tables: []domains.Table{
+					{
+						Name:     "products",
+						Comments: "Catalog 'items'",
+						Columns: []domains.Column{
+							{Name: "id", Type: "int(11) unsigned", IsPrimaryKey: true},
+							{Name: "name", Type: "varchar(255)", Comments: "Display name"},
+							{Name: "status", Type: "enum('active','retired')", Nullable: true, Default: "'active'"},
+							{Name: "updated_at", Type: "timestamp", Nullable: true, Default: "CURRENT_TIMESTAMP"},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "PRIMARY", Type: "PRIMARY KEY", Columns: []string{"id"}},
+							{Name: "uq_name", Type: "UNIQUE", Columns: []string{"name"}},
+						},
+					},
+					{
+						Name: "order_items",
+						Columns: []domains.Column{
+							{Name: "id", Type: "INT", IsPrimaryKey: true},
+							{Name: "product_id", Type: "int unsigned", IsForeignKey: true},
+						},
+						Indexes: []domains.Index{
+							{Name: "idx_product", Columns: []string{"product_id"}},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "PRIMARY", Type: "PRIMARY KEY", Columns: []string{"id"}},
+							{Name: "order_items_ibfk_1", Type: "FOREIGN KEY", Columns: []string{"product_id"}, Reference: "products(id)"},
+						},
+					},
+					{
+						Name: "active_products",
+						Columns: []domains.Column{
+							{Name: "id", Nullable: true},
+							{Name: "name", Nullable: true},
+						},
+					},
+				},
+				Relations: []domains.Relation{
+					{SourceTable: "order_items", SourceColumn: "product_id", TargetTable: "products", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+				},
+			},
+		},
+		{
+			name:   "postgres column reference to a primary key declared later",
+			format: domains.SchemaFormatPostgresDDL,
+			script: "CREATE TABLE tasks (\n  id int PRIMARY KEY,\n  parent_id int REFERENCES tasks,\n  project_code text NOT NULL REFERENCES public.projects ON DELETE CASCADE,\n  owner_id int REFERENCES users\n);\n" +
+				"CREATE TABLE projects (code text NOT NULL);\n" +
+				"ALTER TABLE ONLY projects ADD CONSTRAINT projects_pkey PRIMARY KEY (code);",
+			expected: &domains.DatabaseMetadata{
+				Tables: []domains.Table{
+					{
+						Name: "tasks",
+						Columns: []domains.Column{
+							{Name: "id", Type: "int", IsPrimaryKey: true},
+							{Name: "parent_id", Type: "int", Nullable: true, IsForeignKey: true},
+							{Name: "project_code", Type: "text", IsForeignKey: true},
+							{Name: "owner_id", Type: "int", Nullable: true, IsForeignKey: true},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "tasks_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+							{Name: "tasks_parent_id_fkey", Type: "FOREIGN KEY", Columns: []string{"parent_id"}, Reference: "tasks(id)"},
+							{Name: "tasks_project_code_fkey", Type: "FOREIGN KEY", Columns: []string{"project_code"}, Reference: "projects(code)"},
+							{Name: "tasks_owner_id_fkey", Type: "FOREIGN KEY", Columns: []string{"owner_id"}, Reference: "users"},
+						},
+					},
+					{
+						Name: "projects",
+						Columns: []domains.Column{
+							{Name: "code", Type: "text", IsPrimaryKey: true},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "projects_pkey", Type: "PRIMARY KEY", Columns: []string{"code"}},
+						},
+					},
+				},
+				Relations: []domains.Relation{
+					{SourceTable: "tasks", SourceColumn: "parent_id", TargetTable: "tasks", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+					{SourceTable: "tasks", SourceColumn: "project_code", TargetTable: "projects", TargetColumn: "code", RelationType: "MANY_TO_ONE"},
+				},
+			},
+		},
+		{
+			name:   "mysql column reference matched case-insensitively",
+			format: domains.SchemaFormatMySQLDDL,
+			script: "CREATE TABLE Users (id INT NOT NULL, PRIMARY KEY (id));\n" +
+				"CREATE TABLE posts (user_id INT REFERENCES users);",
+			expected: &domains.DatabaseMetadata{
+				Tables: []domains.Table{
+					{
+						Name: "Users",
+						Columns: []domains.Column{
+							{Name: "id", Type: "INT", IsPrimaryKey: true},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "PRIMARY", Type: "PRIMARY KEY", Columns: []string{"id"}},
+						},
+					},
+					{
+						Name: "posts",
+						Columns: []domains.Column{
+							{Name: "user_id", Type: "INT", Nullable: true, IsForeignKey: true},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "posts_ibfk_1", Type: "FOREIGN KEY", Columns: []string{"user_id"}, Reference: "Users(id)"},
+						},
+					},
+				},
+				Relations: []domains.Relation{
+					{SourceTable: "posts", SourceColumn: "user_id", TargetTable: "Users", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+				},
+			},
+		},
+		{
+			name:        "unterminated string",
+			format:      domains.SchemaFormatPostgresDDL,
+			script:      "CREATE TABLE t (\n  name text DEFAULT 'x\n);",
+			expectError: errors.New("line 2: unterminated string"),
+		},
+		{
+			name:        "column without type",
+			format:      domains.SchemaFormatMySQLDDL,
+			script:      "CREATE TABLE t (\n  id INT,\n  name\n);",
+			expectError: errors.New(`line 4: expected type of column name, found ")"`),
+		},
+		{
+			name:        "missing closing parenthesis",
+			format:      domains.SchemaFormatPostgresDDL,
+			script:      "CREATE TABLE t (id int NOT NULL",
+			expectError: errors.New(`line 1: expected "," or ")", found end of statement`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := parseDDL(tt.format, tt.script)
+			require.Equal(t, tt.expectError, err)
+			require.Equal(t, tt.expected, metadata)
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// prismaScalars are the Prisma field types stored in a column as they are.
+var prismaScalars = map[string]bool{
+	"String": true, "Int": true, "BigInt": true, "Float": true, "Decimal": true,
+	"Boolean": true, "DateTime": true, "Json": true, "Bytes": true, "Unsupported": true,
+}
+
+type prismaModel struct {
+	name        string
+	table       string
+	description string
+	fields      []prismaField
+	attributes  []prismaAttribute
+}
+
+type prismaField struct {
+	name        string
+	column      string
+	kind        string
+	list        bool
+	optional    bool
+	description string
+	attributes  []prismaAttribute
+	line        int
+}
+
+// prismaAttribute is an attribute such as @default(now()) or
+// @@index([email]). Arguments are kept as written, keyed by their name or by
+// their position when unnamed.
+type prismaAttribute struct {
+	name string
+	args map[string]string
+}
+
+func (a prismaAttribute) arg(name string, position int) string {
+	if value, ok := a.args[name]; ok {
+		return value
+	}
+	return a.args[strconv.Itoa(position)]
+}
+
+// parsePrisma reads the models and views of a schema.prisma file as tables,
+// named after their @@map and @map. Relation fields become foreign keys of
+// the side holding @relation(fields, references), and /// comments become
+// descriptions.
+func parsePrisma(source string) (*domains.DatabaseMetadata, error) {
+	models, types, err := splitPrisma(source)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*prismaModel, len(models))
+	for i := range models {
+		byName[models[i].name] = &models[i]
+	}
+
+	metadata := &domains.DatabaseMetadata{}
+	for _, model := range models {
+		table := domains.Table{Name: model.table, Description: model.description}
+		for _, field := range model.fields {
+			if byName[field.kind] != nil {
+				continue
+			}
+			column := domains.Column{
+				Name:        field.column,
+				Type:        field.kind,
+				Nullable:    field.optional,
+				Description: field.description,
+			}
+			if field.list {
+				column.Type += "[]"
+			}
+			for _, attribute := range field.attributes {
+				switch {
+				case attribute.name == "id":
+					column.IsPrimaryKey = true
+					table.Constraints = append(table.Constraints, domains.Constraint{Name: model.table + "_pkey", Type: "PRIMARY KEY", Columns: []string{field.column}})
+				case attribute.name == "unique":
+					table.Constraints = append(table.Constraints, domains.Constraint{Name: model.table + "_" + field.column + "_key", Type: "UNIQUE", Columns: []string{field.column}})
+				case attribute.name == "default":
+					column.Default = attribute.arg("value", 0)
+				case strings.HasPrefix(attribute.name, "db."):
+					column.Type = strings.TrimPrefix(attribute.name, "db.") + attribute.args["native"]
+				}
+			}
+			if !prismaScalars[field.kind] && !types[field.kind] {
+				return nil, fmt.Errorf("line %d: unknown type %s of field %s", field.line, field.kind, field.name)
+			}
+			table.Columns = append(table.Columns, column)
+		}
+
+		for _, attribute := range model.attributes {
+			columns := model.columns(attribute.arg("fields", 0))
+			if len(columns) == 0 {
+				continue
+			}
+			switch attribute.name {
+			case "id":
+				for i := range table.Columns {
+					if slices.Contains(columns, table.Columns[i].Name) {
+						table.Columns[i].IsPrimaryKey = true
+					}
+				}
+				table.Constraints = append(table.Constraints, domains.Constraint{Name: model.table + "_pkey", Type: "PRIMARY KEY", Columns: columns})
+			case "unique":
+				name := unquote(attribute.arg("map", -1))
+				if name == "" {
+					name = model.table + "_" + strings.Join(columns, "_") + "_key"
+				}
+				table.Constraints = append(table.Constraints, domains.Constraint{Name: name, Type: "UNIQUE", Columns: columns})
+			case "index":
+				name := unquote(attribute.arg("map", -1))
+				if name == "" {
+					name = model.table + "_" + strings.Join(columns, "_") + "_idx"
+				}
+				table.Indexes = append(table.Indexes, domains.Index{Name: name, Columns: columns})
+			}
+		}
+
+		// The side holding @relation(fields: [...], references: [...]) owns
+		// the foreign key
+		for _, field := range model.fields {
+			target := byName[field.kind]
+			if target == nil {
+				continue
+			}
+			for _, attribute := range field.attributes {
+				if attribute.name != "relation" {
+					continue
+				}
+				columns := model.columns(attribute.arg("fields", -1))
+				references := target.columns(attribute.arg("references", -1))
+				if len(columns) == 0 || len(columns) != len(references) {
+					continue
+				}
+				table.Constraints = append(table.Constraints, domains.Constraint{
+					Name:      model.table + "_" + strings.Join(columns, "_") + "_fkey",
+					Type:      "FOREIGN KEY",
+					Columns:   columns,
+					Reference: target.table + "(" + strings.Join(references, ", ") + ")",
+				})
+				for i, column := range columns {
+					for j := range table.Columns {
+						if table.Columns[j].Name == column {
+							table.Columns[j].IsForeignKey = true
+						}
+					}
+					metadata.Relations = append(metadata.Relations, domains.Relation{
+						SourceTable:  model.table,
+						SourceColumn: column,
+						TargetTable:  target.table,
+						TargetColumn: references[i],
+						RelationType: "MANY_TO_ONE",
+					})
+				}
+			}
+		}
+
+		metadata.Tables = append(metadata.Tables, table)
+	}
+	return metadata, nil
+}
+
+// columns maps a field list such as [customerId, createdAt(sort: Desc)] to
+// the column names of the fields.
+func (m *prismaModel) columns(list string) []string {
+	list = strings.TrimSpace(list)
+	if !strings.HasPrefix(list, "[") || !strings.HasSuffix(list, "]") {
+		return nil
+	}
+
+	var columns []string
+	for _, item := range splitPrismaArgs(list[1 : len(list)-1]) {
+		name := item
+		if i := strings.Index(name, "("); i >= 0 {
+			name = name[:i]
+		}
+		name = strings.TrimSpace(name)
+		for _, field := range m.fields {
+			if field.name == name {
+				name = field.column
+			}
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// splitPrisma reads the model and view blocks of the schema and the names of
+// its enums and composite types. Datasource and generator blocks are skipped.
+func splitPrisma(source string) ([]prismaModel, map[string]bool, error) {
+	var (
+		models []prismaModel
+		types  = map[string]bool{}
+		model  *prismaModel
+		block  string
+		docs   []string
+	)
+
+	for number, line := range strings.Split(source, "\n") {
+		code, doc := splitPrismaComment(line)
+		statements := splitPrismaBraces(code)
+		if len(statements) == 0 {
+			if doc != "" {
+				docs = append(docs, doc)
+			}
+			continue
+		}
+
+		for i := 0; i < len(statements); i++ {
+			statement := statements[i]
+			if block == "" {
+				if i+1 < len(statements) && statements[i+1] == "{" {
+					statement += " {"
+					i++
+				}
+				words := strings.Fields(statement)
+				if len(words) < 3 || words[len(words)-1] != "{" {
+					return nil, nil, fmt.Errorf("line %d: expected a block, found %q", number+1, strings.TrimSpace(code))
+				}
+				block = words[0]
+				switch block {
+				case "model", "view":
+					models = append(models, prismaModel{
+						name:        words[1],
+						table:       words[1],
+						description: strings.Join(docs, "\n"),
+					})
+					model = &models[len(models)-1]
+				case "enum", "type":
+					types[words[1]] = true
+				}
+				docs = nil
+				continue
+			}
+
+			if statement == "}" {
+				block, model, docs = "", nil, nil
+				continue
+			}
+			if model == nil {
+				continue
+			}
+
+			if strings.HasPrefix(statement, "@@") {
+				attributes, err := parsePrismaAttributes(statement)
+				if err != nil {
+					return nil, nil, fmt.Errorf("line %d: %w", number+1, err)
+				}
+				for _, attribute := range attributes {
+					if attribute.name == "map" {
+						model.table = unquote(attribute.arg("name", 0))
+					}
+					model.attributes = append(model.attributes, attribute)
+				}
+				docs = nil
+				continue
+			}
+
+			field, err := parsePrismaField(statement)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+			if doc != "" {
+				docs = append(docs, doc)
+			}
+			field.description = strings.Join(docs, "\n")
+			field.line = number + 1
+			model.fields = append(model.fields, field)
+			docs = nil
+		}
+	}
+
+	if block != "" {
+		return nil, nil, fmt.Errorf("unterminated %s block", block)
+	}
+	return models, types, nil
+}
+
+// splitPrismaComment separates the code of a line from its comment, and
+// returns the text of a /// documentation comment.
+// splitPrismaBraces splits the code of a line at the braces outside strings,
+// so single-line blocks such as enum Kind { A B } read like multi-line ones.
+// The braces are kept as statements of their own.
+func splitPrismaBraces(code string) []string {
+	var (
+		statements []string
+		inString   bool
+		start      int
+	)
+	add := func(statement string) {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	for i := 0; i < len(code); i++ {
+		switch {
+		case code[i] == '\\' && inString:
+			i++
+		case code[i] == '"':
+			inString = !inString
+		case !inString && (code[i] == '{' || code[i] == '}'):
+			add(code[start:i])
+			add(code[i : i+1])
+			start = i + 1
+		}
+	}
+	add(code[start:])
+	return statements
+}
+
+func splitPrismaComment(line string) (string, string) {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inString:
+			i++
+		case line[i] == '"':
+			inString = !inString
+		case !inString && strings.HasPrefix(line[i:], "///"):
+			return line[:i], strings.TrimSpace(line[i+3:])
+		case !inString && strings.HasPrefix(line[i:], "//"):
+			return line[:i], ""
+		}
+	}
+	return line, ""
+}
+
+// parsePrismaField reads a field line such as
+// `customerId Int? @map("customer_id") @default(0)`.
+func parsePrismaField(code string) (prismaField, error) {
+	name, rest := code, ""
+	if i := strings.IndexAny(code, " \t"); i >= 0 {
+		name, rest = code[:i], strings.TrimSpace(code[i:])
+	}
+	kind := rest
+	if i := strings.IndexAny(rest, " \t@"); i >= 0 {
+		kind, rest = rest[:i], rest[i:]
+	} else {
+		rest = ""
+	}
+	// Unsupported("tsvector") keeps its argument
+	if strings.HasPrefix(kind, "Unsupported(") && !strings.Contains(kind, ")") {
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			return prismaField{}, fmt.Errorf("field %s: unterminated type", name)
+		}
+		kind, rest = kind+rest[:end+1], rest[end+1:]
+	}
+	if name == "" || kind == "" {
+		return prismaField{}, fmt.Errorf("field %q has no type", code)
+	}
+
+	field := prismaField{name: name, column: name}
+	switch {
+	case strings.HasSuffix(kind, "[]"):
+		field.list = true
+		kind = strings.TrimSuffix(kind, "[]")
+	case strings.HasSuffix(kind, "?"):
+		field.optional = true
+		kind = strings.TrimSuffix(kind, "?")
+	}
+	field.kind = kind
+	if i := strings.Index(kind, "("); i >= 0 {
+		field.kind = kind[:i]
+	}
+
+	attributes, err := parsePrismaAttributes(rest)
+	if err != nil {
+		return prismaField{}, fmt.Errorf("field %s: %w", name, err)
+	}
+	for _, attribute := range attributes {
+		if attribute.name == "map" {
+			field.column = unquote(attribute.arg("name", 0))
+		}
+	}
+	field.attributes = attributes
+	return field, nil
+}
+
+// parsePrismaAttributes reads the attributes of a field or block, such as
+// `@id @default(autoincrement()) @db.VarChar(255)`. A native type attribute
+// keeps its arguments, parentheses included, under "native".
+func parsePrismaAttributes(code string) ([]prismaAttribute, error) {
+	var attributes []prismaAttribute
+	code = strings.TrimSpace(code)
+	for code != "" {
+		if !strings.HasPrefix(code, "@") {
+			return nil, fmt.Errorf("unexpected %q", code)
+		}
+		code = strings.TrimLeft(code, "@")
+		end := strings.IndexAny(code, " (")
+		if end < 0 {
+			end = len(code)
+		}
+		attribute := prismaAttribute{name: code[:end], args: map[string]string{}}
+		code = code[end:]
+
+		if strings.HasPrefix(code, "(") {
+			close := matchingParen(code)
+			if close < 0 {
+				return nil, fmt.Errorf("unterminated @%s", attribute.name)
+			}
+			if strings.HasPrefix(attribute.name, "db.") {
+				attribute.args["native"] = code[:close+1]
+			} else {
+				for i, arg := range splitPrismaArgs(code[1:close]) {
+					key, value, named := strings.Cut(arg, ":")
+					if named && !strings.ContainsAny(key, "\"([") {
+						attribute.args[strings.TrimSpace(key)] = strings.TrimSpace(value)
+					} else {
+						attribute.args[strconv.Itoa(i)] = strings.TrimSpace(arg)
+					}
+				}
+			}
+			code = code[close+1:]
+		}
+		attributes = append(attributes, attribute)
+		code = strings.TrimSpace(code)
+	}
+	return attributes, nil
+}
+
+// matchingParen returns the index of the parenthesis closing s[0].
+func matchingParen(s string) int {
+	depth, inString := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && inString:
+			i++
+		case s[i] == '"':
+			inString = !inString
+		case inString:
+		case s[i] == '(' || s[i] == '[':
+			depth++
+		case s[i] == ')' || s[i] == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitPrismaArgs splits arguments on the commas outside brackets and strings.
+func splitPrismaArgs(s string) []string {
+	var (
+		args     []string
+		depth    int
+		inString bool
+		start    int
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && inString:
+			i++
+		case s[i] == '"':
+			inString = !inString
+		case inString:
+		case s[i] == '(' || s[i] == '[':
+			depth++
+		case s[i] == ')' || s[i] == ']':
+			depth--
+		case s[i] == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		args = append(args, last)
+	}
+	return args
+}
+
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
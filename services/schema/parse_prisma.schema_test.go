@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrisma(t *testing.T) {
+	schema := `
+datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL") // not a model
+}
+
+enum Status {
+  PLACED
+  SHIPPED
+}
+
+/// People who place orders
+model Customer {
+  id        Int      @id @default(autoincrement())
+  /// Login e-mail
+  email     String   @unique @db.VarChar(255)
+  website   String?  @default("https://example.com") // a plain comment
+  tags      String[]
+  orders    Order[]
+
+  @@map("customers")
+}
+
+model Order {
+  id         Int      @id
+  customerId Int      @map("customer_id") /// Who ordered
+  status     Status   @default(PLACED)
+  placedAt   DateTime @default(now()) @map("placed_at")
+  customer   Customer @relation(fields: [customerId], references: [id], onDelete: Cascade)
+
+  @@unique([customerId, placedAt], map: "one_order_at_a_time")
+  @@index([placedAt(sort: Desc)])
+  @@map("orders")
+}
+`
+
+	tests := []struct {
+		name        string
+		schema      string
+		expected    *domains.DatabaseMetadata
+		expectError error
+	}{
+		{
+			name:   "models, relations and documentation",
+			schema: schema,
+			expected: &domains.DatabaseMetadata{
+				Tables: []domains.Table{
+					{
+						Name:        "customers",
+						Description: "People who place orders",
+						Columns: []domains.Column{
+							{Name: "id", Type: "Int", Default: "autoincrement()", IsPrimaryKey: true},
+							{Name: "email", Type: "VarChar(255)", Description: "Login e-mail"},
+							{Name: "website", Type: "String", Nullable: true, Default: `"https://example.com"`},
+							{Name: "tags", Type: "String[]"},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "customers_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+							{Name: "customers_email_key", Type: "UNIQUE", Columns: []string{"email"}},
+						},
+					},
+					{
+						Name: "orders",
+						Columns: []domains.Column{
+							{Name: "id", Type: "Int", IsPrimaryKey: true},
+							{Name: "customer_id", Type: "Int", IsForeignKey: true, Description: "Who ordered"},
+							{Name: "status", Type: "Status", Default: "PLACED"},
+							{Name: "placed_at", Type: "DateTime", Default: "now()"},
+						},
+						Indexes: []domains.Index{
+							{Name: "orders_placed_at_idx", Columns: []string{"placed_at"}},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "orders_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+							{Name: "one_order_at_a_time", Type: "UNIQUE", Columns: []string{"customer_id", "placed_at"}},
+							{Name: "orders_customer_id_fkey", Type: "FOREIGN KEY", Columns: []string{"customer_id"}, Reference: "customers(id)"},
+						},
+					},
+				},
+				Relations: []domains.Relation{
+					{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id", RelationType: "MANY_TO_ONE"},
+				},
+			},
+		},
+		{
+			name: "single-line blocks",
+			schema: "generator client { provider = \"prisma-client-js\" }\n" +
+				"enum Kind { A B }\n" +
+				"model Item { id Int @id }\n" +
+				"model Tag {\n  id   Int  @id\n  kind Kind @default(A) }\n" +
+				"type Note { body String }",
+			expected: &domains.DatabaseMetadata{
+				Tables: []domains.Table{
+					{
+						Name: "Item",
+						Columns: []domains.Column{
+							{Name: "id", Type: "Int", IsPrimaryKey: true},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "Item_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+						},
+					},
+					{
+						Name: "Tag",
+						Columns: []domains.Column{
+							{Name: "id", Type: "Int", IsPrimaryKey: true},
+							{Name: "kind", Type: "Kind", Default: "A"},
+						},
+						Constraints: []domains.Constraint{
+							{Name: "Tag_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "unknown type",
+			schema:      "model A {\n  id Int @id\n  b  Missing\n}",
+			expectError: errors.New("line 3: unknown type Missing of field b"),
+		},
+		{
+			name:        "unterminated block",
+			schema:      "model A {\n  id Int @id\n",
+			expectError: errors.New("unterminated model block"),
+		},
+		{
+			name:        "unterminated attribute",
+			schema:      "model A {\n  id Int @default(now()\n}",
+			expectError: errors.New("line 2: field id: unterminated @default"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := parsePrisma(tt.schema)
+			if tt.expectError != nil {
+				require.EqualError(t, err, tt.expectError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expected, metadata)
+		})
+	}
+}
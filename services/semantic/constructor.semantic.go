@@ -0,0 +1,83 @@
+package semantic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"gopkg.in/yaml.v3"
+)
+
+type SemanticModelConfig struct{}
+
+type SemanticModelService struct {
+	Config *SemanticModelConfig
+
+	internalDatabaseAdapter ports.InternalDatabasePort
+}
+
+func NewSemanticModelService(
+	config *SemanticModelConfig,
+
+	internalDatabaseAdapter ports.InternalDatabasePort,
+) *SemanticModelService {
+	return &SemanticModelService{
+		Config: config,
+
+		internalDatabaseAdapter: internalDatabaseAdapter,
+	}
+}
+
+// parse decodes a YAML or JSON definition, since JSON is valid YAML. Unknown
+// fields are rejected so a misspelled key does not silently drop a filter.
+func parse(tenantID string, definition []byte) (*domains.SemanticModel, error) {
+	semanticModel := &domains.SemanticModel{TenantID: tenantID}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(definition))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(semanticModel); err != nil {
+		invalidErr := ports.SemanticModelInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+	return semanticModel, nil
+}
+
+// validate reports every incomplete definition and every name defined twice,
+// ignoring case, across metrics and dimensions.
+func validate(semanticModel *domains.SemanticModel) error {
+	var problems []string
+	names := map[string]bool{}
+	define := func(kind, name, expression, table string) {
+		switch {
+		case strings.TrimSpace(name) == "":
+			problems = append(problems, kind+" without a name")
+		case strings.TrimSpace(expression) == "" || strings.TrimSpace(table) == "":
+			problems = append(problems, fmt.Sprintf("%s %s needs an expression and a table", kind, name))
+		case names[strings.ToLower(name)]:
+			problems = append(problems, fmt.Sprintf("%s is defined twice", name))
+		}
+		names[strings.ToLower(name)] = true
+	}
+
+	for _, metric := range semanticModel.Metrics {
+		define("metric", metric.Name, metric.Expression, metric.Table)
+	}
+	for _, dimension := range semanticModel.Dimensions {
+		define("dimension", dimension.Name, dimension.Expression, dimension.Table)
+	}
+	for _, join := range semanticModel.Joins {
+		if join.From == "" || join.To == "" || join.On == "" {
+			problems = append(problems, "join needs from, to and on")
+		}
+	}
+
+	if len(problems) > 0 {
+		invalidErr := ports.SemanticModelInvalidError
+		invalidErr.AddBatchAdditionalErrorInfo(problems)
+		return invalidErr
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package semantic
+
+import "context"
+
+func (s *SemanticModelService) Delete(ctx context.Context, tenantID string) error {
+	return s.internalDatabaseAdapter.DeleteSemanticModel(ctx, tenantID)
+}
@@ -0,0 +1,16 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	semanticTest "github.com/kamil5b/go-nl2query-lib/testsuites/semantic"
+)
+
+func TestSemanticModelService_Delete(t *testing.T) {
+	semanticTest.UnitTestDelete(t, func(
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.SemanticModelService {
+		return NewSemanticModelService(nil, internalDatabaseAdapter)
+	})
+}
@@ -0,0 +1,19 @@
+package semantic
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *SemanticModelService) Get(ctx context.Context, tenantID string) (*domains.SemanticModel, error) {
+	semanticModel, err := s.internalDatabaseAdapter.GetSemanticModel(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if semanticModel == nil {
+		return nil, ports.SemanticModelNotFoundError
+	}
+	return semanticModel, nil
+}
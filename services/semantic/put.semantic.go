@@ -0,0 +1,39 @@
+package semantic
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *SemanticModelService) Put(ctx context.Context, tenantID string, definition []byte) (*domains.SemanticModel, error) {
+	// Step 1: Parse and validate the definition
+	semanticModel, err := parse(tenantID, definition)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(semanticModel); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Check the workspace exists and is not deleted. The model is read at query time, so
+	// it can change while an ingestion runs.
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return nil, ports.WorkspaceDeletedError
+	}
+
+	// Step 3: Replace the stored model
+	if err := s.internalDatabaseAdapter.UpsertSemanticModel(ctx, semanticModel); err != nil {
+		return nil, err
+	}
+
+	return semanticModel, nil
+}
@@ -0,0 +1,58 @@
+package version
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+type SchemaVersionConfig struct{}
+
+type SchemaVersionService struct {
+	Config *SchemaVersionConfig
+
+	statusAdapter           ports.StatusPort
+	internalDatabaseAdapter ports.InternalDatabasePort
+	taskQueueService        ports.TaskQueuePort
+}
+
+func NewSchemaVersionService(
+	config *SchemaVersionConfig,
+
+	statusAdapter ports.StatusPort,
+	internalDatabaseAdapter ports.InternalDatabasePort,
+	taskQueueService ports.TaskQueuePort,
+) *SchemaVersionService {
+	return &SchemaVersionService{
+		Config: config,
+
+		statusAdapter:           statusAdapter,
+		internalDatabaseAdapter: internalDatabaseAdapter,
+		taskQueueService:        taskQueueService,
+	}
+}
+
+// idleWorkspace returns the tenant's workspace if it exists, is not deleted
+// and is not being ingested.
+func (s *SchemaVersionService) idleWorkspace(ctx context.Context, tenantID string) (*domains.Workspace, error) {
+	status, _, err := s.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if status == domains.StatusInProgress {
+		return nil, ports.StatusInProgressError
+	}
+
+	workspace, err := s.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return nil, ports.WorkspaceDeletedError
+	}
+	return workspace, nil
+}
@@ -0,0 +1,21 @@
+package version
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *SchemaVersionService) Diff(ctx context.Context, tenantID string, from, to int64) (*domains.SchemaDiff, error) {
+	before, err := s.Get(ctx, tenantID, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.Get(ctx, tenantID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := domains.DiffMetadata(before.Metadata, after.Metadata)
+	return &diff, nil
+}
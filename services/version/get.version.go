@@ -0,0 +1,19 @@
+package version
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (s *SchemaVersionService) Get(ctx context.Context, tenantID string, version int64) (*domains.SchemaVersion, error) {
+	schemaVersion, err := s.internalDatabaseAdapter.GetSchemaVersion(ctx, tenantID, version)
+	if err != nil {
+		return nil, err
+	}
+	if schemaVersion == nil {
+		return nil, ports.SchemaVersionNotFoundError
+	}
+	return schemaVersion, nil
+}
@@ -0,0 +1,18 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	versionTest "github.com/kamil5b/go-nl2query-lib/testsuites/version"
+)
+
+func TestSchemaVersionService_Get(t *testing.T) {
+	versionTest.UnitTestGet(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.SchemaVersionService {
+		return NewSchemaVersionService(nil, statusAdapter, internalDatabaseAdapter, taskQueueService)
+	})
+}
@@ -0,0 +1,11 @@
+package version
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *SchemaVersionService) List(ctx context.Context, tenantID string) ([]*domains.SchemaVersion, error) {
+	return s.internalDatabaseAdapter.ListSchemaVersionsByTenantID(ctx, tenantID)
+}
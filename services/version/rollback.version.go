@@ -0,0 +1,45 @@
+package version
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+func (s *SchemaVersionService) Rollback(ctx context.Context, tenantID string, version int64) error {
+	// Step 1: Check the workspace exists and is not being ingested
+	workspace, err := s.idleWorkspace(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	// Step 2: Load the version to roll back to
+	schemaVersion, err := s.Get(ctx, tenantID, version)
+	if err != nil {
+		return err
+	}
+
+	// Step 3: Pin the workspace, so syncs stop ingesting the client schema
+	workspace.PinnedVersion = schemaVersion.Version
+	workspace.Status = domains.StatusInProgress
+	if err := s.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return err
+	}
+
+	// Step 4: Set the status in progress, so no sync starts before the
+	// ingestion, then re-ingest the version; only the tables that differ from
+	// the active version are embedded again
+	err = s.statusAdapter.SetInProgress(ctx, tenantID)
+	if err == nil {
+		err = s.taskQueueService.EnqueueSchemaIngestionTask(ctx, schemaVersion.Metadata)
+	}
+	if err != nil {
+		// No ingestion will run, so the workspace must not stay in progress
+		workspace.Status = domains.StatusError
+		_ = s.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+		_ = s.statusAdapter.SetError(ctx, tenantID, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package version
+
+import (
+	"context"
+)
+
+// Unpin keeps the vectors of the pinned version until the next sync finds the
+// client schema differs from it.
+func (s *SchemaVersionService) Unpin(ctx context.Context, tenantID string) error {
+	workspace, err := s.idleWorkspace(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if workspace.PinnedVersion == 0 {
+		return nil
+	}
+
+	workspace.PinnedVersion = 0
+	return s.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+}
@@ -1,10 +1,30 @@
 package workspace
 
 import (
+	"time"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
 	"github.com/kamil5b/go-nl2query-lib/ports"
 )
 
-type WorkspaceConfig struct{}
+type WorkspaceConfig struct {
+	// Profiling samples column statistics and categorical values from the
+	// client database during sync, so they are embedded as value documents.
+	// Nil disables profiling.
+	Profiling *ProfilingConfig
+
+	// Describing asks the LLM to describe undocumented tables and columns
+	// during sync. Nil disables it.
+	Describing *DescribingConfig
+
+	// SyncOptions are given to new workspaces, before their first sync. Nil
+	// syncs the whole database until options are set on the workspace.
+	SyncOptions *domains.SyncOptions
+
+	// DeleteRetention is how long a deleted workspace can be restored before
+	// PurgeExpired removes it. Defaults to 30 days.
+	DeleteRetention time.Duration
+}
 
 type WorkspaceService struct {
 	Config                  *WorkspaceConfig
@@ -14,6 +34,8 @@ type WorkspaceService struct {
 	encryptAdapter          ports.EncryptPort
 	hashAdapter             ports.HashPort
 	taskQueueService        ports.TaskQueuePort
+	vectorStoreAdapter      ports.VectorStorePort
+	llmAdapter              ports.LLMPort
 }
 
 func NewWorkspaceService(
@@ -24,6 +46,8 @@ func NewWorkspaceService(
 	encryptAdapter ports.EncryptPort,
 	hashAdapter ports.HashPort,
 	taskQueueService ports.TaskQueuePort,
+	vectorStoreAdapter ports.VectorStorePort,
+	llmAdapter ports.LLMPort,
 ) *WorkspaceService {
 	return &WorkspaceService{
 		Config:                  config,
@@ -33,5 +57,7 @@ func NewWorkspaceService(
 		encryptAdapter:          encryptAdapter,
 		hashAdapter:             hashAdapter,
 		taskQueueService:        taskQueueService,
+		vectorStoreAdapter:      vectorStoreAdapter,
+		llmAdapter:              llmAdapter,
 	}
 }
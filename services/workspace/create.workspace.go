@@ -0,0 +1,89 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// Create does not sync the workspace: SyncWorkspace, or the scheduler, reads
+// its data sources and ingests them.
+func (ws *WorkspaceService) Create(ctx context.Context, definition *model.WorkspaceDefinition) (*model.Workspace, error) {
+	// Step 1: Validate the definition
+	if err := definition.Validate(); err != nil {
+		invalidErr := ports.WorkspaceDefinitionInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+
+	// Step 2: Connect to internal database
+	if err := ws.internalDatabaseAdapter.Connect(ctx, definition.ID); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Reject an ID already in use
+	existingWorkspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, definition.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existingWorkspace != nil {
+		return nil, ports.WorkspaceAlreadyExistsError
+	}
+
+	// Step 4: Check that every data source connects, and encrypt its URL
+	sources, err := ws.dataSources(ctx, definition.DataSources, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Save the workspace
+	workspace := &model.Workspace{
+		TenantID:    definition.ID,
+		Name:        definition.Name,
+		Labels:      definition.Labels,
+		DataSources: sources,
+		SyncOptions: ws.syncOptions(nil),
+	}
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// dataSources checks that the URL of each data source connects and encrypts
+// it. A source without a URL keeps the one stored on the workspace.
+func (ws *WorkspaceService) dataSources(ctx context.Context, definitions []model.DataSourceDefinition, workspace *model.Workspace) ([]model.DataSource, error) {
+	sources := make([]model.DataSource, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition.DBURL == "" {
+			var stored *model.DataSource
+			if workspace != nil {
+				stored = workspace.DataSource(definition.Name)
+			}
+			if stored == nil {
+				invalidErr := ports.WorkspaceDefinitionInvalidError
+				invalidErr.AddAdditionalErrorInfo(fmt.Sprintf("data source %q has no database URL", definition.Name))
+				return nil, invalidErr
+			}
+			source := *stored
+			source.Preferred = definition.Preferred
+			sources = append(sources, source)
+			continue
+		}
+
+		if err := ws.clientDatabaseAdapter.Connect(ctx, definition.DBURL); err != nil {
+			unreachableErr := ports.WorkspaceDBURLUnreachableError
+			unreachableErr.AddAdditionalErrorInfo(definition.Name + ": " + err.Error())
+			return nil, unreachableErr
+		}
+		sources = append(sources, model.DataSource{
+			Name:           definition.Name,
+			EncryptedDBURL: ws.encryptAdapter.Encrypt(definition.DBURL),
+			Preferred:      definition.Preferred,
+		})
+	}
+	return sources, nil
+}
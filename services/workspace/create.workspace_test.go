@@ -0,0 +1,27 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	workspaceTest "github.com/kamil5b/go-nl2query-lib/testsuites/workspace"
+)
+
+func TestWorkspaceService_Create(t *testing.T) {
+	workspaceTest.UnitTestCreate(t, func(
+		clientDatabaseAdapter ports.ClientDatabasePort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+	) ports.WorkspaceService {
+		return NewWorkspaceService(nil,
+			nil,
+			clientDatabaseAdapter,
+			internalDatabaseAdapter,
+			encryptAdapter,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+	})
+}
@@ -2,11 +2,23 @@ package workspace
 
 import (
 	"context"
+	"time"
 
 	"github.com/kamil5b/go-nl2query-lib/domains"
 	"github.com/kamil5b/go-nl2query-lib/ports"
 )
 
+const defaultDeleteRetention = 30 * 24 * time.Hour
+
+func (c *WorkspaceConfig) deleteRetention() time.Duration {
+	if c == nil || c.DeleteRetention <= 0 {
+		return defaultDeleteRetention
+	}
+	return c.DeleteRetention
+}
+
+// Delete only marks the workspace deleted, so a mistaken delete is undone
+// without re-ingesting it. PurgeExpired removes it for good.
 func (ws *WorkspaceService) Delete(ctx context.Context, tenantID string) error {
 	// Step 1: Check status
 	status, _, err := ws.statusAdapter.GetStatus(ctx, tenantID)
@@ -18,10 +30,58 @@ func (ws *WorkspaceService) Delete(ctx context.Context, tenantID string) error {
 		return ports.StatusInProgressError
 	}
 
-	// Step 2: Delete workspace from internal database
-	if err := ws.internalDatabaseAdapter.DeleteWorkspaceByTenantID(ctx, tenantID); err != nil {
+	// Step 2: Get the workspace, a deleted one is already gone for callers
+	workspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if workspace == nil || workspace.IsDeleted() {
+		return ports.WorkspaceNotFoundError
+	}
+
+	// Step 3: Cancel the queued ingestion tasks, so none runs while deleted
+	if err := ws.taskQueueService.CancelIngestionTasks(ctx, tenantID); err != nil {
 		return err
 	}
 
-	return nil
+	// Step 4: Mark the workspace deleted
+	workspace.DeletedAt = time.Now().UTC()
+	return ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+}
+
+// purge removes everything stored for the tenant. Every step runs even if an
+// earlier one failed, and every step is safe to repeat.
+func (ws *WorkspaceService) purge(ctx context.Context, tenantID string) error {
+	steps := []struct {
+		name string
+		run  func(ctx context.Context, tenantID string) error
+	}{
+		{"cancel ingestion tasks", ws.taskQueueService.CancelIngestionTasks},
+		{"delete vectors", ws.vectorStoreAdapter.Delete},
+		{"clear status", ws.statusAdapter.Clear},
+		{"delete status history", ws.internalDatabaseAdapter.DeleteStatusEventsByTenantID},
+		{"delete query history", ws.internalDatabaseAdapter.DeleteQueryHistoryByTenantID},
+		{"delete descriptions", ws.internalDatabaseAdapter.DeleteDescriptionsByTenantID},
+		{"delete glossary", ws.internalDatabaseAdapter.DeleteGlossaryTermsByTenantID},
+		{"delete examples", ws.internalDatabaseAdapter.DeleteExamplesByTenantID},
+		{"delete semantic model", ws.internalDatabaseAdapter.DeleteSemanticModel},
+		{"delete schema versions", ws.internalDatabaseAdapter.DeleteSchemaVersionsByTenantID},
+		{"delete tenant aliases", ws.internalDatabaseAdapter.DeleteTenantAliasesByTenantID},
+	}
+
+	var failures []string
+	for _, step := range steps {
+		if err := step.run(ctx, tenantID); err != nil {
+			failures = append(failures, step.name+": "+err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		deleteErr := ports.WorkspaceDeleteIncompleteError
+		deleteErr.AddBatchAdditionalErrorInfo(failures)
+		return deleteErr
+	}
+
+	// Delete the workspace record last, so an incomplete purge is retried
+	return ws.internalDatabaseAdapter.DeleteWorkspaceByTenantID(ctx, tenantID)
 }
@@ -11,6 +11,8 @@ func TestWorkspaceService_Delete(t *testing.T) {
 	workspaceTest.UnitTestDelete(t, func(
 		statusAdapter ports.StatusPort,
 		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+		vectorStoreAdapter ports.VectorStorePort,
 	) ports.WorkspaceService {
 		return NewWorkspaceService(nil,
 			statusAdapter,
@@ -18,6 +20,8 @@ func TestWorkspaceService_Delete(t *testing.T) {
 			internalDatabaseAdapter,
 			nil,
 			nil,
+			taskQueueService,
+			vectorStoreAdapter,
 			nil,
 		)
 	})
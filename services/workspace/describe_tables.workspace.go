@@ -0,0 +1,144 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+const defaultDescribeSampleRows = 5
+
+type DescribingConfig struct {
+	// SampleRows is the number of rows of each table shown to the LLM.
+	// Defaults to 5; a negative value sends no rows.
+	SampleRows int
+	// MaxTables caps the number of tables described per sync. Zero means no
+	// limit.
+	MaxTables int
+	// ExcludeColumns are removed from the sampled rows, with the same matching
+	// as ProfilingConfig.ExcludeColumns. Nil means DefaultPIIColumnPatterns.
+	ExcludeColumns []string
+}
+
+func (c *DescribingConfig) sampleRows() int {
+	if c == nil || c.SampleRows == 0 {
+		return defaultDescribeSampleRows
+	}
+	return max(c.SampleRows, 0)
+}
+
+func (c *WorkspaceConfig) describing() *DescribingConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Describing
+}
+
+// describeTables applies every stored description, including user overrides,
+// to the metadata. With a config, it first asks the LLM to describe the
+// tables and columns that have no comment in the client database and no
+// description, stored or imported with the schema, and stores the answers as
+// inferred descriptions. A table the LLM fails to describe is skipped and
+// retried on the next sync.
+func (ws *WorkspaceService) describeTables(ctx context.Context, config *DescribingConfig, tenantID string, metadata *domains.DatabaseMetadata) error {
+	descriptions, err := ws.internalDatabaseAdapter.ListDescriptionsByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[[2]string]bool, len(descriptions))
+	for _, description := range descriptions {
+		stored[[2]string{description.Table, description.Column}] = true
+	}
+
+	described := 0
+	for _, table := range metadata.Tables {
+		if config == nil {
+			break
+		}
+
+		// Columns use their name, the table itself an empty one
+		var missing []string
+		if table.Comments == "" && table.Description == "" && !stored[[2]string{table.Name, ""}] {
+			missing = append(missing, "")
+		}
+		for _, column := range table.Columns {
+			if column.Comments == "" && column.Description == "" && !stored[[2]string{table.Name, column.Name}] {
+				missing = append(missing, column.Name)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if config.MaxTables > 0 && described >= config.MaxTables {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		described++
+
+		inferred, err := ws.llmAdapter.DescribeTable(ctx, table, relationsOf(metadata, table.Name), ws.sampleRows(ctx, config, table.Name))
+		if err != nil || inferred == nil {
+			continue
+		}
+
+		for _, column := range missing {
+			text := inferred.Description
+			if column != "" {
+				text = inferred.Columns[column]
+			}
+			if text == "" {
+				continue
+			}
+
+			description := &domains.Description{
+				TenantID: tenantID,
+				Table:    table.Name,
+				Column:   column,
+				Text:     text,
+				Source:   domains.DescriptionSourceInferred,
+			}
+			if err := ws.internalDatabaseAdapter.UpsertDescription(ctx, description); err != nil {
+				return err
+			}
+			descriptions = append(descriptions, description)
+		}
+	}
+
+	metadata.ApplyDescriptions(descriptions)
+	return nil
+}
+
+// sampleRows reads a few rows of the table without the excluded columns.
+// Rows only help the LLM, so a failure to read them sends none.
+func (ws *WorkspaceService) sampleRows(ctx context.Context, config *DescribingConfig, table string) []map[string]any {
+	limit := config.sampleRows()
+	if limit == 0 {
+		return nil
+	}
+
+	rows, err := ws.clientDatabaseAdapter.SampleRows(ctx, table, limit)
+	if err != nil {
+		return nil
+	}
+	for _, row := range rows {
+		for column := range row {
+			if excludedColumn(config.ExcludeColumns, table, column) {
+				delete(row, column)
+			}
+		}
+	}
+	return rows
+}
+
+// relationsOf returns the relations the table is the source or target of.
+func relationsOf(metadata *domains.DatabaseMetadata, table string) []domains.Relation {
+	var relations []domains.Relation
+	for _, relation := range metadata.Relations {
+		if relation.SourceTable == table || relation.TargetTable == table {
+			relations = append(relations, relation)
+		}
+	}
+	return relations
+}
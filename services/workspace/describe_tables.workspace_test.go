@@ -0,0 +1,192 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceService_describeTables(t *testing.T) {
+	mockTenantID := "tenant_123"
+
+	newMetadata := func() *domains.DatabaseMetadata {
+		return &domains.DatabaseMetadata{
+			TenantID: mockTenantID,
+			Tables: []domains.Table{
+				{
+					Name: "customers",
+					Columns: []domains.Column{
+						{Name: "id", Type: "INT", Comments: "Primary key"},
+						{Name: "email", Type: "VARCHAR(255)"},
+						{Name: "city", Type: "VARCHAR(100)"},
+					},
+				},
+				{
+					Name:     "orders",
+					Comments: "Customer orders",
+					Columns: []domains.Column{
+						{Name: "customer_id", Type: "INT"},
+					},
+				},
+			},
+			Relations: []domains.Relation{
+				{SourceTable: "orders", SourceColumn: "customer_id", TargetTable: "customers", TargetColumn: "id"},
+			},
+		}
+	}
+	storedCity := &domains.Description{TenantID: mockTenantID, Table: "customers", Column: "city", Text: "City of residence", Source: domains.DescriptionSourceUser}
+
+	type mocksSet struct {
+		clientDatabase   *mocks.MockClientDatabasePort
+		internalDatabase *mocks.MockInternalDatabasePort
+		llm              *mocks.MockLLMPort
+	}
+
+	tests := []struct {
+		name          string
+		config        *DescribingConfig
+		prepareMock   func(m mocksSet, metadata *domains.DatabaseMetadata)
+		expectError   error
+		expectMutated func(t *testing.T, metadata *domains.DatabaseMetadata)
+	}{
+		{
+			name: "without config only applies stored descriptions",
+			prepareMock: func(m mocksSet, metadata *domains.DatabaseMetadata) {
+				m.internalDatabase.EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return([]*domains.Description{storedCity}, nil)
+			},
+			expectMutated: func(t *testing.T, metadata *domains.DatabaseMetadata) {
+				require.Equal(t, "City of residence", metadata.Tables[0].Columns[2].Description)
+				require.Empty(t, metadata.Tables[0].Description)
+			},
+		},
+		{
+			name:   "infers missing descriptions without PII in the sampled rows",
+			config: &DescribingConfig{},
+			prepareMock: func(m mocksSet, metadata *domains.DatabaseMetadata) {
+				m.internalDatabase.EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return([]*domains.Description{storedCity}, nil)
+
+				m.clientDatabase.EXPECT().
+					SampleRows(gomock.Any(), "customers", defaultDescribeSampleRows).
+					Return([]map[string]any{{"id": 1, "email": "a@example.com", "city": "Jakarta"}}, nil)
+				m.llm.EXPECT().
+					DescribeTable(gomock.Any(), metadata.Tables[0], metadata.Relations, []map[string]any{{"id": 1, "city": "Jakarta"}}).
+					Return(&domains.TableDescription{
+						Description: "Registered customers",
+						Columns:     map[string]string{"id": "ignored", "email": "Contact email", "city": "ignored"},
+					}, nil)
+				m.internalDatabase.EXPECT().
+					UpsertDescription(gomock.Any(), &domains.Description{TenantID: mockTenantID, Table: "customers", Text: "Registered customers", Source: domains.DescriptionSourceInferred}).
+					Return(nil)
+				m.internalDatabase.EXPECT().
+					UpsertDescription(gomock.Any(), &domains.Description{TenantID: mockTenantID, Table: "customers", Column: "email", Text: "Contact email", Source: domains.DescriptionSourceInferred}).
+					Return(nil)
+
+				// A failed description is retried by the next sync
+				m.clientDatabase.EXPECT().
+					SampleRows(gomock.Any(), "orders", defaultDescribeSampleRows).
+					Return(nil, errors.New("permission denied"))
+				m.llm.EXPECT().
+					DescribeTable(gomock.Any(), metadata.Tables[1], metadata.Relations, nil).
+					Return(nil, errors.New("llm error"))
+			},
+			expectMutated: func(t *testing.T, metadata *domains.DatabaseMetadata) {
+				require.Equal(t, "Registered customers", metadata.Tables[0].Description)
+				require.Empty(t, metadata.Tables[0].Columns[0].Description)
+				require.Equal(t, "Contact email", metadata.Tables[0].Columns[1].Description)
+				require.Equal(t, "City of residence", metadata.Tables[0].Columns[2].Description)
+				require.Empty(t, metadata.Tables[1].Columns[0].Description)
+			},
+		},
+		{
+			name:   "limits the tables described per sync",
+			config: &DescribingConfig{SampleRows: -1, MaxTables: 1},
+			prepareMock: func(m mocksSet, metadata *domains.DatabaseMetadata) {
+				m.internalDatabase.EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				m.llm.EXPECT().
+					DescribeTable(gomock.Any(), metadata.Tables[0], metadata.Relations, nil).
+					Return(&domains.TableDescription{}, nil)
+			},
+		},
+		{
+			name: "error list descriptions",
+			prepareMock: func(m mocksSet, metadata *domains.DatabaseMetadata) {
+				m.internalDatabase.EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:   "error upsert description",
+			config: &DescribingConfig{SampleRows: -1},
+			prepareMock: func(m mocksSet, metadata *domains.DatabaseMetadata) {
+				m.internalDatabase.EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				m.llm.EXPECT().
+					DescribeTable(gomock.Any(), metadata.Tables[0], metadata.Relations, nil).
+					Return(&domains.TableDescription{Description: "Registered customers"}, nil)
+				m.internalDatabase.EXPECT().
+					UpsertDescription(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocksSet{
+				clientDatabase:   mocks.NewMockClientDatabasePort(ctrl),
+				internalDatabase: mocks.NewMockInternalDatabasePort(ctrl),
+				llm:              mocks.NewMockLLMPort(ctrl),
+			}
+			metadata := newMetadata()
+			tt.prepareMock(m, newMetadata())
+
+			ws := NewWorkspaceService(nil, nil, m.clientDatabase, m.internalDatabase, nil, nil, nil, nil, m.llm)
+			err := ws.describeTables(context.Background(), tt.config, mockTenantID, metadata)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectError, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectMutated != nil {
+				tt.expectMutated(t, metadata)
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_SyncClientDatabase_enqueuesOverrides(t *testing.T) {
+	metadata := &domains.DatabaseMetadata{
+		Tables: []domains.Table{
+			{Name: "customers", Comments: "customer table", Columns: []domains.Column{{Name: "city", Type: "VARCHAR(100)"}}},
+		},
+	}
+	descriptions := []*domains.Description{
+		{TenantID: "tenant_123", Table: "customers", Text: "People who bought at least once", Source: domains.DescriptionSourceUser},
+		{TenantID: "tenant_123", Table: "customers", Column: "city", Text: "City of the billing address", Source: domains.DescriptionSourceUser},
+	}
+
+	enqueued := syncNewWorkspace(t, nil, metadata, descriptions, nil)
+
+	require.Equal(t, "People who bought at least once", enqueued.Tables[0].Description)
+	require.Equal(t, "City of the billing address", enqueued.Tables[0].Columns[0].Description)
+}
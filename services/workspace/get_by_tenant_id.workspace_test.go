@@ -18,6 +18,8 @@ func TestWorkspaceService_GetByTenantID(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 		)
 	})
 }
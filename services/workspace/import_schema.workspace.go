@@ -0,0 +1,142 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/services/schema"
+)
+
+// ImportSchema creates or updates a schema-only workspace. Its tenant ID is
+// generated from the name the way a synced workspace's is from its database
+// URL, the name is stored as its display name, and it stores no database URL.
+func (ws *WorkspaceService) ImportSchema(ctx context.Context, name string, format model.SchemaFormat, source string) (*model.SyncReport, error) {
+	// Step 1: Parse the schema
+	if name == "" {
+		invalidErr := ports.WorkspaceSchemaInvalidError
+		invalidErr.AddAdditionalErrorInfo("name is required")
+		return nil, invalidErr
+	}
+
+	metadata, err := schema.Parse(format, source)
+	if err == nil && len(metadata.Tables) == 0 {
+		err = errors.New("no tables found")
+	}
+	if err != nil {
+		invalidErr := ports.WorkspaceSchemaInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+
+	// Step 2: Generate tenant ID from the name
+	tenantID := ws.hashAdapter.GenerateTenantID(name)
+	metadata.TenantID = tenantID
+
+	// Step 3: Check status
+	status, _, err := ws.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == domains.StatusInProgress {
+		return nil, ports.StatusInProgressError
+	}
+
+	// Step 4: Connect to internal database
+	if err := ws.internalDatabaseAdapter.Connect(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	// Step 5: Check if workspace already exists
+	existingWorkspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingWorkspace != nil && existingWorkspace.IsDeleted() {
+		return nil, ports.WorkspaceDeletedError
+	}
+
+	// A workspace with a client database is synced from it, never imported
+	if existingWorkspace != nil && !existingWorkspace.IsSchemaOnly() {
+		return nil, ports.WorkspaceNotSchemaOnlyError
+	}
+
+	// A workspace rolled back to a schema version is not ingested again until
+	// it is unpinned
+	if existingWorkspace != nil && existingWorkspace.PinnedVersion != 0 {
+		return nil, ports.WorkspacePinnedError
+	}
+
+	// Keep only the tables and columns the workspace syncs
+	syncOptions := ws.syncOptions(existingWorkspace)
+	metadata = syncOptions.Filter(metadata)
+
+	// Step 6: Generate checksum for the schema
+	newChecksum, err := ws.hashAdapter.GenerateChecksum(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata.Checksum = newChecksum
+
+	// Step 7: Check if checksum has changed
+	if existingWorkspace != nil && newChecksum == existingWorkspace.Checksum {
+		return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomeUnchanged}, nil
+	}
+
+	// Step 8: Compare with the active schema version
+	var active *model.DatabaseMetadata
+	if existingWorkspace != nil && existingWorkspace.ActiveVersion != 0 {
+		version, err := ws.internalDatabaseAdapter.GetSchemaVersion(ctx, tenantID, existingWorkspace.ActiveVersion)
+		if err != nil {
+			return nil, err
+		}
+		if version != nil {
+			active = version.Metadata
+		}
+	}
+	diff := model.DiffMetadata(active, metadata)
+
+	// Step 9: Apply the stored descriptions; there are no rows to sample
+	describing := ws.Config.describing()
+	if describing != nil {
+		withoutRows := *describing
+		withoutRows.SampleRows = -1
+		describing = &withoutRows
+	}
+	if err := ws.describeTables(ctx, describing, tenantID, metadata); err != nil {
+		return nil, err
+	}
+
+	// Step 10: Save the workspace under its name, without a database URL
+	workspace := existingWorkspace
+	if workspace == nil {
+		workspace = &model.Workspace{
+			TenantID:    tenantID,
+			SyncOptions: syncOptions,
+		}
+	}
+	workspace.Name = name
+	workspace.Status = domains.StatusInProgress
+
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	// Step 11: Enqueue the ingestion of the imported metadata
+	if err := ws.enqueueIngestion(ctx, workspace, metadata); err != nil {
+		return nil, err
+	}
+
+	return &model.SyncReport{
+		TenantID: tenantID,
+		Outcome:  model.SyncOutcomeEnqueued,
+		Checksum: newChecksum,
+		Metadata: metadata,
+		Diff:     diff,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	workspaceTest "github.com/kamil5b/go-nl2query-lib/testsuites/workspace"
+)
+
+func TestWorkspaceService_ImportSchema(t *testing.T) {
+	workspaceTest.UnitTestImportSchema(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		hashAdapter ports.HashPort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.WorkspaceService {
+		return NewWorkspaceService(nil,
+			statusAdapter,
+			nil,
+			internalDatabaseAdapter,
+			nil,
+			hashAdapter,
+			taskQueueService,
+			nil,
+			nil,
+		)
+	})
+}
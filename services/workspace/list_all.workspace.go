@@ -4,8 +4,17 @@ import (
 	"context"
 
 	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
 )
 
-func (ws *WorkspaceService) ListAll(ctx context.Context) ([]*model.Workspace, error) {
-	return ws.internalDatabaseAdapter.ListAllWorkspaces(ctx)
+func (ws *WorkspaceService) ListAll(ctx context.Context, query *model.WorkspaceQuery) (*model.WorkspacePage, error) {
+	// Step 1: Validate the query
+	if err := query.Validate(); err != nil {
+		invalidErr := ports.WorkspaceQueryInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+
+	// Step 2: List the page
+	return ws.internalDatabaseAdapter.ListAllWorkspaces(ctx, query)
 }
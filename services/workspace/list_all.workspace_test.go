@@ -18,6 +18,8 @@ func TestWorkspaceService_ListAll(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 		)
 	})
 }
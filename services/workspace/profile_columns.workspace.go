@@ -0,0 +1,163 @@
+package workspace
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// DefaultPIIColumnPatterns exclude columns that commonly hold personal or
+// secret data from profiling, so their values never reach the vector store
+// or the LLM.
+var DefaultPIIColumnPatterns = []string{
+	"*email*",
+	"*phone*",
+	"*password*",
+	"*passwd*",
+	"*secret*",
+	"*token*",
+	"*ssn*",
+	"*tax_id*",
+	"*national_id*",
+	"*passport*",
+	"*birth*",
+	"*address*",
+	"*card_number*",
+	"*iban*",
+	"*salary*",
+	"first_name",
+	"last_name",
+	"full_name",
+}
+
+const (
+	defaultMaxDistinctValues = 50
+	defaultMaxValueLength    = 100
+)
+
+type ProfilingConfig struct {
+	// MaxDistinctValues is the highest number of distinct values a text column
+	// may have for its values to be sampled. Defaults to 50.
+	MaxDistinctValues int
+	// MaxValueLength truncates sampled values. Defaults to 100 bytes.
+	MaxValueLength int
+	// MaxColumns caps the number of columns profiled per sync. Zero means no
+	// limit.
+	MaxColumns int
+	// ExcludeColumns are case-insensitive path.Match patterns, matched against
+	// both "table.column" and the bare column name, of columns that are never
+	// profiled. Nil means DefaultPIIColumnPatterns; append to it to extend the
+	// defaults.
+	ExcludeColumns []string
+}
+
+func (c *ProfilingConfig) maxDistinctValues() int {
+	if c == nil || c.MaxDistinctValues <= 0 {
+		return defaultMaxDistinctValues
+	}
+	return c.MaxDistinctValues
+}
+
+func (c *ProfilingConfig) maxValueLength() int {
+	if c == nil || c.MaxValueLength <= 0 {
+		return defaultMaxValueLength
+	}
+	return c.MaxValueLength
+}
+
+func (c *ProfilingConfig) excluded(table, column string) bool {
+	if c == nil {
+		return excludedColumn(nil, table, column)
+	}
+	return excludedColumn(c.ExcludeColumns, table, column)
+}
+
+// excludedColumn reports whether the column matches one of the patterns, or
+// one of DefaultPIIColumnPatterns when patterns is nil.
+func excludedColumn(patterns []string, table, column string) bool {
+	if patterns == nil {
+		patterns = DefaultPIIColumnPatterns
+	}
+
+	qualified := strings.ToLower(table + "." + column)
+	column = strings.ToLower(column)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if ok, _ := path.Match(pattern, qualified); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, column); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *WorkspaceConfig) profiling() *ProfilingConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Profiling
+}
+
+// profileColumns attaches a profile to every column that is not a key and not
+// excluded. Values are only sampled from text columns. Profiling enriches the
+// documents but is not required for them, so a column that fails to profile
+// is left without one.
+func (ws *WorkspaceService) profileColumns(ctx context.Context, config *ProfilingConfig, metadata *domains.DatabaseMetadata) {
+	profiled := 0
+	for i := range metadata.Tables {
+		table := &metadata.Tables[i]
+		for j := range table.Columns {
+			column := &table.Columns[j]
+			if column.IsPrimaryKey || column.IsForeignKey || config.excluded(table.Name, column.Name) {
+				continue
+			}
+			if config.MaxColumns > 0 && profiled >= config.MaxColumns {
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			profiled++
+
+			maxValues := 0
+			if isTextType(column.Type) {
+				maxValues = config.maxDistinctValues()
+			}
+			profile, err := ws.clientDatabaseAdapter.ProfileColumn(ctx, table.Name, column.Name, maxValues)
+			if err != nil || profile == nil {
+				continue
+			}
+
+			if maxValues == 0 || profile.DistinctCount > int64(maxValues) {
+				profile.Values = nil
+			}
+			profile.Min = truncate(profile.Min, config.maxValueLength())
+			profile.Max = truncate(profile.Max, config.maxValueLength())
+			for k, value := range profile.Values {
+				profile.Values[k] = truncate(value, config.maxValueLength())
+			}
+			column.Profile = profile
+		}
+	}
+}
+
+func isTextType(columnType string) bool {
+	columnType = strings.ToLower(columnType)
+	for _, text := range []string{"char", "text", "string", "enum", "citext"} {
+		if strings.Contains(columnType, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return strings.ToValidUTF8(value[:length], "")
+}
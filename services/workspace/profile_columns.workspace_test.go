@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceService_profileColumns(t *testing.T) {
+	newMetadata := func() *domains.DatabaseMetadata {
+		return &domains.DatabaseMetadata{
+			TenantID: "tenant_123",
+			Tables: []domains.Table{
+				{
+					Name: "customers",
+					Columns: []domains.Column{
+						{Name: "id", Type: "INT", IsPrimaryKey: true},
+						{Name: "email", Type: "VARCHAR(255)"},
+						{Name: "city", Type: "VARCHAR(100)"},
+						{Name: "created_at", Type: "TIMESTAMP"},
+					},
+				},
+				{
+					Name: "orders",
+					Columns: []domains.Column{
+						{Name: "customer_id", Type: "INT", IsForeignKey: true},
+						{Name: "note", Type: "TEXT"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("profiles non-key, non-PII columns and samples text values", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clientDatabaseAdapter := mocks.NewMockClientDatabasePort(ctrl)
+		clientDatabaseAdapter.EXPECT().
+			ProfileColumn(gomock.Any(), "customers", "city", defaultMaxDistinctValues).
+			Return(&domains.ColumnProfile{RowCount: 10, DistinctCount: 2, Min: "Bandung", Max: "Jakarta", Values: []string{"Bandung", "Jakarta"}}, nil)
+		clientDatabaseAdapter.EXPECT().
+			ProfileColumn(gomock.Any(), "customers", "created_at", 0).
+			Return(&domains.ColumnProfile{RowCount: 10, NullCount: 1, DistinctCount: 9, Min: "2024-01-01", Max: "2024-12-31"}, nil)
+		clientDatabaseAdapter.EXPECT().
+			ProfileColumn(gomock.Any(), "orders", "note", defaultMaxDistinctValues).
+			Return(nil, errors.New("permission denied"))
+
+		ws := NewWorkspaceService(nil, nil, clientDatabaseAdapter, nil, nil, nil, nil, nil, nil)
+		metadata := newMetadata()
+		ws.profileColumns(context.Background(), &ProfilingConfig{}, metadata)
+
+		customers := metadata.Tables[0].Columns
+		require.Nil(t, customers[0].Profile)
+		require.Nil(t, customers[1].Profile)
+		require.Equal(t, []string{"Bandung", "Jakarta"}, customers[2].Profile.Values)
+		require.Equal(t, "2024-12-31", customers[3].Profile.Max)
+		require.Nil(t, metadata.Tables[1].Columns[1].Profile)
+	})
+
+	t.Run("drops values above the distinct limit and truncates long values", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clientDatabaseAdapter := mocks.NewMockClientDatabasePort(ctrl)
+		clientDatabaseAdapter.EXPECT().
+			ProfileColumn(gomock.Any(), "customers", "city", 2).
+			Return(&domains.ColumnProfile{RowCount: 10, DistinctCount: 3, Min: strings.Repeat("a", 10), Values: []string{"a", "b", "c"}}, nil)
+
+		ws := NewWorkspaceService(nil, nil, clientDatabaseAdapter, nil, nil, nil, nil, nil, nil)
+		metadata := newMetadata()
+		ws.profileColumns(context.Background(), &ProfilingConfig{
+			MaxDistinctValues: 2,
+			MaxValueLength:    4,
+			MaxColumns:        1,
+		}, metadata)
+
+		profile := metadata.Tables[0].Columns[2].Profile
+		require.Nil(t, profile.Values)
+		require.Equal(t, "aaaa", profile.Min)
+		require.Nil(t, metadata.Tables[0].Columns[3].Profile)
+	})
+
+	t.Run("custom exclusions replace the defaults", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clientDatabaseAdapter := mocks.NewMockClientDatabasePort(ctrl)
+		clientDatabaseAdapter.EXPECT().
+			ProfileColumn(gomock.Any(), "customers", "email", defaultMaxDistinctValues).
+			Return(&domains.ColumnProfile{RowCount: 10, DistinctCount: 10}, nil)
+
+		ws := NewWorkspaceService(nil, nil, clientDatabaseAdapter, nil, nil, nil, nil, nil, nil)
+		metadata := newMetadata()
+		ws.profileColumns(context.Background(), &ProfilingConfig{
+			ExcludeColumns: []string{"customers.c*", "ORDERS.*"},
+		}, metadata)
+
+		require.NotNil(t, metadata.Tables[0].Columns[1].Profile)
+	})
+}
+
+func TestWorkspaceService_SyncClientDatabase_enqueuesProfiles(t *testing.T) {
+	metadata := &domains.DatabaseMetadata{
+		Tables: []domains.Table{
+			{Name: "customers", Columns: []domains.Column{{Name: "city", Type: "VARCHAR(100)"}}},
+		},
+	}
+	profile := &domains.ColumnProfile{RowCount: 10, DistinctCount: 2, Values: []string{"Bandung", "Jakarta"}}
+
+	enqueued := syncNewWorkspace(t, &WorkspaceConfig{Profiling: &ProfilingConfig{}}, metadata, nil, func(clientDatabaseAdapter *mocks.MockClientDatabasePort) {
+		clientDatabaseAdapter.EXPECT().
+			ProfileColumn(gomock.Any(), "customers", "city", defaultMaxDistinctValues).
+			Return(profile, nil)
+	})
+
+	require.Equal(t, profile, enqueued.Tables[0].Columns[0].Profile)
+}
@@ -0,0 +1,54 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// PurgeExpired keeps purging the other workspaces when one fails, and reports
+// every failure; the failed ones stay deleted and are retried next time.
+func (ws *WorkspaceService) PurgeExpired(ctx context.Context, now time.Time) error {
+	// Step 1: List the workspaces deleted before the retention window, page by
+	// page, before purging any so the pages do not shift
+	var tenantIDs []string
+	query := &model.WorkspaceQuery{
+		Deleted:       true,
+		DeletedBefore: now.Add(-ws.Config.deleteRetention()),
+		SortBy:        model.WorkspaceSortTenantID,
+		Limit:         model.MaxWorkspacePageSize,
+	}
+	for {
+		page, err := ws.internalDatabaseAdapter.ListAllWorkspaces(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, workspace := range page.Workspaces {
+			tenantIDs = append(tenantIDs, workspace.TenantID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	// Step 2: Purge them
+	var failures []string
+	for _, tenantID := range tenantIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := ws.purge(ctx, tenantID); err != nil {
+			failures = append(failures, tenantID+": "+err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		purgeErr := ports.WorkspacePurgeIncompleteError
+		purgeErr.AddBatchAdditionalErrorInfo(failures)
+		return purgeErr
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	workspaceTest "github.com/kamil5b/go-nl2query-lib/testsuites/workspace"
+)
+
+func TestWorkspaceService_PurgeExpired(t *testing.T) {
+	workspaceTest.UnitTestPurgeExpired(t, func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.WorkspaceService {
+		return NewWorkspaceService(nil,
+			statusAdapter,
+			nil,
+			internalDatabaseAdapter,
+			nil,
+			nil,
+			taskQueueService,
+			vectorStoreAdapter,
+			nil,
+		)
+	})
+}
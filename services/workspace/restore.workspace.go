@@ -0,0 +1,37 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (ws *WorkspaceService) Restore(ctx context.Context, tenantID string) (*model.Workspace, error) {
+	// Step 1: Get the workspace
+	workspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, ports.WorkspaceNotFoundError
+	}
+	if !workspace.IsDeleted() {
+		return nil, ports.WorkspaceNotDeletedError
+	}
+
+	// Step 2: Check the retention window, past which PurgeExpired may already
+	// have removed part of it
+	if !time.Now().Before(workspace.DeletedAt.Add(ws.Config.deleteRetention())) {
+		return nil, ports.WorkspaceRetentionExpiredError
+	}
+
+	// Step 3: Clear the deletion
+	workspace.DeletedAt = time.Time{}
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
@@ -0,0 +1,66 @@
+package workspace
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// RotateDBURL keeps the tenant ID, which is the hash of the original URL, and
+// records the hash of the new URL as an alias of it, so a sync with the new URL
+// updates the same workspace instead of creating another one.
+func (ws *WorkspaceService) RotateDBURL(ctx context.Context, tenantID string, dbUrl string) (*model.Workspace, error) {
+	// Step 1: Get the workspace, unless it is being ingested
+	workspace, err := ws.idleWorkspace(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workspace.DataSources) > 0 {
+		return nil, ports.WorkspaceNamedError
+	}
+	if workspace.IsSchemaOnly() {
+		return nil, ports.WorkspaceSchemaOnlyError
+	}
+
+	// Step 2: Verify the new URL connects
+	if err := ws.clientDatabaseAdapter.Connect(ctx, dbUrl); err != nil {
+		unreachableErr := ports.WorkspaceDBURLUnreachableError
+		unreachableErr.AddAdditionalErrorInfo(err.Error())
+		return nil, unreachableErr
+	}
+
+	// Step 3: Point the tenant ID of the new URL to the workspace, unless it
+	// is the tenant ID of another workspace
+	alias := ws.hashAdapter.GenerateTenantID(dbUrl)
+	if alias != tenantID {
+		owner, err := ws.internalDatabaseAdapter.GetTenantIDByAlias(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if owner != "" && owner != tenantID {
+			return nil, ports.WorkspaceDBURLConflictError
+		}
+
+		other, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if other != nil {
+			return nil, ports.WorkspaceDBURLConflictError
+		}
+
+		if err := ws.internalDatabaseAdapter.SaveTenantAlias(ctx, alias, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 4: Save the encrypted URL
+	workspace.EncryptedDBURL = ws.encryptAdapter.Encrypt(dbUrl)
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
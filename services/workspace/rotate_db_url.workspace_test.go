@@ -0,0 +1,29 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	workspaceTest "github.com/kamil5b/go-nl2query-lib/testsuites/workspace"
+)
+
+func TestWorkspaceService_RotateDBURL(t *testing.T) {
+	workspaceTest.UnitTestRotateDBURL(t, func(
+		statusAdapter ports.StatusPort,
+		clientDatabaseAdapter ports.ClientDatabasePort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+		hashAdapter ports.HashPort,
+	) ports.WorkspaceService {
+		return NewWorkspaceService(nil,
+			statusAdapter,
+			clientDatabaseAdapter,
+			internalDatabaseAdapter,
+			encryptAdapter,
+			hashAdapter,
+			nil,
+			nil,
+			nil,
+		)
+	})
+}
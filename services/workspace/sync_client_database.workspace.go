@@ -8,11 +8,25 @@ import (
 	"github.com/kamil5b/go-nl2query-lib/ports"
 )
 
-func (ws *WorkspaceService) SyncClientDatabase(ctx context.Context, dbUrl string) (*model.DatabaseMetadata, *string, error) {
+func (ws *WorkspaceService) SyncClientDatabase(ctx context.Context, dbUrl string) (*model.SyncReport, *string, error) {
 	// Step 1: Generate tenant ID from database URL
 	tenantID := ws.hashAdapter.GenerateTenantID(dbUrl)
 
-	// Step 2: Check status
+	// Step 2: Connect to internal database
+	if err := ws.internalDatabaseAdapter.Connect(ctx, tenantID); err != nil {
+		return nil, nil, err
+	}
+
+	// Step 3: Follow a credential rotation to the workspace of the original URL
+	rotatedTenantID, err := ws.internalDatabaseAdapter.GetTenantIDByAlias(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rotatedTenantID != "" {
+		tenantID = rotatedTenantID
+	}
+
+	// Step 4: Check status
 	status, _, err := ws.statusAdapter.GetStatus(ctx, tenantID)
 	if err != nil {
 		return nil, nil, err
@@ -22,39 +36,44 @@ func (ws *WorkspaceService) SyncClientDatabase(ctx context.Context, dbUrl string
 		return nil, nil, ports.StatusInProgressError
 	}
 
-	// Step 3: Encrypt the database URL
+	// Step 5: Encrypt the database URL
 	encryptedDBUrl := ws.encryptAdapter.Encrypt(dbUrl)
 
-	// Step 4: Connect to internal database
-	if err := ws.internalDatabaseAdapter.Connect(ctx, encryptedDBUrl); err != nil {
-		return nil, nil, err
-	}
-
-	// Step 5: Check if workspace already exists
+	// Step 6: Check if workspace already exists
 	existingWorkspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if existingWorkspace != nil && existingWorkspace.IsDeleted() {
+		return nil, nil, ports.WorkspaceDeletedError
+	}
+
 	var existingChecksum string
 	if existingWorkspace != nil {
 		existingChecksum = existingWorkspace.Checksum
 	}
 
-	// Step 6: Connect to client database (treat connection error as warning)
+	// Step 7: Connect to client database (treat connection error as warning)
 	if err := ws.clientDatabaseAdapter.Connect(ctx, dbUrl); err != nil && existingWorkspace != nil {
-		// Connection error is treated as a warning, return nil
+		// Connection error is treated as a warning, the existing schema stays
 		msg := ports.WorkspaceServiceWarnUseExistingClientDatabaseError
-		return nil, &msg, nil
+		return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomeUsedCachedSchema}, &msg, nil
 	}
 
-	// Step 7: Execute query to get database metadata
+	// Step 8: Execute query to get database metadata
 	metadata, err := ws.clientDatabaseAdapter.GetDatabaseMetadata(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Step 9: Generate checksum for the database
+	// Step 9: Keep only the tables and columns the workspace syncs, so the
+	// filtered ones never reach the checksum, the LLM or the vector store
+	syncOptions := ws.syncOptions(existingWorkspace)
+	metadata = syncOptions.Filter(metadata)
+	metadata.TenantID = tenantID
+
+	// Step 10: Generate checksum for the database
 	newChecksum, err := ws.hashAdapter.GenerateChecksum(metadata)
 	if err != nil {
 		return nil, nil, err
@@ -62,16 +81,87 @@ func (ws *WorkspaceService) SyncClientDatabase(ctx context.Context, dbUrl string
 
 	metadata.Checksum = newChecksum
 
-	// Step 10: Check if checksum has changed
+	// Step 11: Check if checksum has changed
 	if existingWorkspace != nil && newChecksum == existingChecksum {
 		// No changes detected, return success without enqueueing task
-		return nil, nil, nil
+		return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomeUnchanged}, nil, nil
+	}
+
+	// Step 12: Compare with the active schema version. A workspace pinned by
+	// a rollback only reports the changes
+	var active *model.DatabaseMetadata
+	if existingWorkspace != nil && existingWorkspace.ActiveVersion != 0 {
+		version, err := ws.internalDatabaseAdapter.GetSchemaVersion(ctx, tenantID, existingWorkspace.ActiveVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		if version != nil {
+			active = version.Metadata
+		}
 	}
+	diff := model.DiffMetadata(active, metadata)
 
-	// Step 11: Enqueue ingestion task if checksum changed or workspace is new
-	if err := ws.taskQueueService.EnqueueIngestionTask(ctx, tenantID, dbUrl); err != nil {
+	if existingWorkspace != nil && existingWorkspace.PinnedVersion != 0 {
+		return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomePinned, Checksum: newChecksum, Diff: diff}, nil, nil
+	}
+
+	// Step 13: Profile column values, now that an ingestion will run
+	if profiling := ws.Config.profiling(); profiling != nil {
+		ws.profileColumns(ctx, profiling, metadata)
+	}
+
+	// Step 14: Apply the stored descriptions, describing undocumented tables
+	// first when enabled
+	if err := ws.describeTables(ctx, ws.Config.describing(), tenantID, metadata); err != nil {
+		return nil, nil, err
+	}
+
+	// Step 15: Save the workspace; the previous checksum is kept until the
+	// ingestion succeeds, so a failed ingestion is retried on the next sync
+	workspace := existingWorkspace
+	if workspace == nil {
+		workspace = &model.Workspace{
+			TenantID:    tenantID,
+			SyncOptions: syncOptions,
+		}
+	}
+	workspace.EncryptedDBURL = encryptedDBUrl
+	workspace.Status = domains.StatusInProgress
+
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
 		return nil, nil, err
 	}
 
-	return metadata, nil, nil
+	// Step 16: Enqueue the ingestion of the filtered metadata if checksum
+	// changed or workspace is new, rather than of the URL, which the worker
+	// would read unfiltered
+	if err := ws.enqueueIngestion(ctx, workspace, metadata); err != nil {
+		return nil, nil, err
+	}
+
+	return &model.SyncReport{
+		TenantID: tenantID,
+		Outcome:  model.SyncOutcomeEnqueued,
+		Checksum: newChecksum,
+		Metadata: metadata,
+		Diff:     diff,
+	}, nil, nil
+}
+
+// enqueueIngestion sets the status of the workspace in progress before
+// enqueueing the ingestion of metadata, so no other sync starts meanwhile.
+// When nothing is enqueued the workspace is set to error, as no ingestion
+// will run to finish it.
+func (ws *WorkspaceService) enqueueIngestion(ctx context.Context, workspace *model.Workspace, metadata *model.DatabaseMetadata) error {
+	err := ws.statusAdapter.SetInProgress(ctx, workspace.TenantID)
+	if err == nil {
+		err = ws.taskQueueService.EnqueueSchemaIngestionTask(ctx, metadata)
+	}
+	if err != nil {
+		workspace.Status = domains.StatusError
+		_ = ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace)
+		_ = ws.statusAdapter.SetError(ctx, workspace.TenantID, err.Error())
+		return err
+	}
+	return nil
 }
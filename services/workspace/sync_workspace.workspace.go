@@ -0,0 +1,164 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// SyncWorkspace merges the metadata of the data sources in order, each table
+// tagged with its source and duplicate tables read from the preferred source,
+// and ingests it as one schema.
+func (ws *WorkspaceService) SyncWorkspace(ctx context.Context, tenantID string) (*model.SyncReport, *string, error) {
+	// Step 1: Connect to internal database
+	if err := ws.internalDatabaseAdapter.Connect(ctx, tenantID); err != nil {
+		return nil, nil, err
+	}
+
+	// Step 2: Check status
+	status, _, err := ws.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if status == domains.StatusInProgress {
+		return nil, nil, ports.StatusInProgressError
+	}
+
+	// Step 3: Get the workspace
+	workspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if workspace == nil {
+		return nil, nil, ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return nil, nil, ports.WorkspaceDeletedError
+	}
+	if len(workspace.DataSources) == 0 {
+		return nil, nil, ports.WorkspaceNotNamedError
+	}
+
+	// Step 4: Read the metadata of every data source. A workspace ingested
+	// before keeps its schema while one of them is unreachable
+	dbURLs := make([]string, len(workspace.DataSources))
+	sources := make([]*model.DatabaseMetadata, len(workspace.DataSources))
+	for i, source := range workspace.DataSources {
+		if dbURLs[i], err = ws.encryptAdapter.Decrypt(source.EncryptedDBURL); err != nil {
+			return nil, nil, err
+		}
+
+		if err := ws.clientDatabaseAdapter.Connect(ctx, dbURLs[i]); err != nil {
+			if workspace.Checksum == "" {
+				return nil, nil, err
+			}
+			msg := ports.WorkspaceServiceWarnUseExistingClientDatabaseError
+			return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomeUsedCachedSchema}, &msg, nil
+		}
+
+		if sources[i], err = ws.clientDatabaseAdapter.GetDatabaseMetadata(ctx); err != nil {
+			return nil, nil, err
+		}
+		for j := range sources[i].Tables {
+			sources[i].Tables[j].Source = source.Name
+		}
+	}
+
+	// Step 5: Merge the sources and keep only the tables and columns the
+	// workspace syncs
+	metadata := model.MergeMetadata(tenantID, workspace.PreferredDataSource(), sources...)
+	metadata = workspace.SyncOptions.Filter(metadata)
+
+	// Step 6: Generate checksum for the workspace
+	newChecksum, err := ws.hashAdapter.GenerateChecksum(metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata.Checksum = newChecksum
+
+	// Step 7: Check if checksum has changed
+	if newChecksum == workspace.Checksum {
+		return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomeUnchanged}, nil, nil
+	}
+
+	// Step 8: Compare with the active schema version. A workspace pinned by
+	// a rollback only reports the changes
+	var active *model.DatabaseMetadata
+	if workspace.ActiveVersion != 0 {
+		version, err := ws.internalDatabaseAdapter.GetSchemaVersion(ctx, tenantID, workspace.ActiveVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		if version != nil {
+			active = version.Metadata
+		}
+	}
+	diff := model.DiffMetadata(active, metadata)
+
+	if workspace.PinnedVersion != 0 {
+		return &model.SyncReport{TenantID: tenantID, Outcome: model.SyncOutcomePinned, Checksum: newChecksum, Diff: diff}, nil, nil
+	}
+
+	// Step 9: Profile and describe the tables of each data source while
+	// connected to it
+	for i, source := range workspace.DataSources {
+		tables := sourceTables(metadata, source.Name)
+		if len(tables.Tables) == 0 {
+			continue
+		}
+		if err := ws.clientDatabaseAdapter.Connect(ctx, dbURLs[i]); err != nil {
+			return nil, nil, err
+		}
+		if profiling := ws.Config.profiling(); profiling != nil {
+			ws.profileColumns(ctx, profiling, tables)
+		}
+		if err := ws.describeTables(ctx, ws.Config.describing(), tenantID, tables); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Step 10: Save the workspace; the previous checksum is kept until the
+	// ingestion succeeds, so a failed ingestion is retried on the next sync
+	workspace.Status = domains.StatusInProgress
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, nil, err
+	}
+
+	// Step 11: Enqueue the ingestion of the merged metadata
+	if err := ws.enqueueIngestion(ctx, workspace, metadata); err != nil {
+		return nil, nil, err
+	}
+
+	return &model.SyncReport{
+		TenantID: tenantID,
+		Outcome:  model.SyncOutcomeEnqueued,
+		Checksum: newChecksum,
+		Metadata: metadata,
+		Diff:     diff,
+	}, nil, nil
+}
+
+// sourceTables returns the tables of the data source as metadata sharing
+// their storage, so profiles and descriptions set on it land on the merged
+// metadata. Merging keeps the tables of each source together.
+func sourceTables(metadata *model.DatabaseMetadata, source string) *model.DatabaseMetadata {
+	start, end := 0, 0
+	for i, table := range metadata.Tables {
+		if table.Source != source {
+			continue
+		}
+		if end == 0 {
+			start = i
+		}
+		end = i + 1
+	}
+	return &model.DatabaseMetadata{
+		TenantID:  metadata.TenantID,
+		Tables:    metadata.Tables[start:end],
+		Relations: metadata.Relations,
+	}
+}
@@ -0,0 +1,44 @@
+package workspace
+
+import (
+	"context"
+
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// Update does not re-sync: the next sync reads the new data sources.
+func (ws *WorkspaceService) Update(ctx context.Context, definition *model.WorkspaceDefinition) (*model.Workspace, error) {
+	// Step 1: Validate the definition
+	if err := definition.Validate(); err != nil {
+		invalidErr := ports.WorkspaceDefinitionInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+
+	// Step 2: Get the workspace, unless it is being ingested
+	workspace, err := ws.idleWorkspace(ctx, definition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workspace.DataSources) == 0 {
+		return nil, ports.WorkspaceNotNamedError
+	}
+
+	// Step 3: Check that every new data source URL connects, and encrypt it
+	sources, err := ws.dataSources(ctx, definition.DataSources, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 4: Save the workspace
+	workspace.Name = definition.Name
+	workspace.Labels = definition.Labels
+	workspace.DataSources = sources
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
@@ -0,0 +1,28 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	workspaceTest "github.com/kamil5b/go-nl2query-lib/testsuites/workspace"
+)
+
+func TestWorkspaceService_Update(t *testing.T) {
+	workspaceTest.UnitTestUpdate(t, func(
+		statusAdapter ports.StatusPort,
+		clientDatabaseAdapter ports.ClientDatabasePort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+	) ports.WorkspaceService {
+		return NewWorkspaceService(nil,
+			statusAdapter,
+			clientDatabaseAdapter,
+			internalDatabaseAdapter,
+			encryptAdapter,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+	})
+}
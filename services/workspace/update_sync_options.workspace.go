@@ -0,0 +1,70 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+// UpdateSyncOptions does not re-sync: the queries are held to the new options
+// at once, and the next sync drops or adds the tables and columns they change.
+func (ws *WorkspaceService) UpdateSyncOptions(ctx context.Context, tenantID string, options *model.SyncOptions) (*model.Workspace, error) {
+	// Step 1: Validate the patterns
+	if err := options.Validate(); err != nil {
+		invalidErr := ports.WorkspaceSyncOptionsInvalidError
+		invalidErr.AddAdditionalErrorInfo(err.Error())
+		return nil, invalidErr
+	}
+
+	// Step 2: Get the workspace, unless it is being ingested
+	workspace, err := ws.idleWorkspace(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Save the options
+	workspace.SyncOptions = options
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// idleWorkspace returns the tenant's workspace if it exists, is not deleted
+// and is not being ingested.
+func (ws *WorkspaceService) idleWorkspace(ctx context.Context, tenantID string) (*model.Workspace, error) {
+	status, _, err := ws.statusAdapter.GetStatus(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if status == domains.StatusInProgress {
+		return nil, ports.StatusInProgressError
+	}
+
+	workspace, err := ws.internalDatabaseAdapter.GetWorkspaceByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, ports.WorkspaceNotFoundError
+	}
+	if workspace.IsDeleted() {
+		return nil, ports.WorkspaceDeletedError
+	}
+	return workspace, nil
+}
+
+// syncOptions returns the options of the workspace, or those configured for
+// new workspaces when it does not exist yet.
+func (ws *WorkspaceService) syncOptions(workspace *model.Workspace) *model.SyncOptions {
+	if workspace != nil {
+		return workspace.SyncOptions
+	}
+	if ws.Config == nil {
+		return nil
+	}
+	return ws.Config.SyncOptions
+}
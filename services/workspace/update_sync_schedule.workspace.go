@@ -0,0 +1,34 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	model "github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+)
+
+func (ws *WorkspaceService) UpdateSyncSchedule(ctx context.Context, tenantID string, schedule string) (*model.Workspace, error) {
+	// Step 1: Validate the cron expression
+	if schedule != "" {
+		if _, err := domains.ParseCronSchedule(schedule); err != nil {
+			invalidErr := ports.WorkspaceSyncScheduleInvalidError
+			invalidErr.AddAdditionalErrorInfo(err.Error())
+			return nil, invalidErr
+		}
+	}
+
+	// Step 2: Get the workspace, unless it is being ingested
+	workspace, err := ws.idleWorkspace(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Save the schedule
+	workspace.SyncSchedule = schedule
+	if err := ws.internalDatabaseAdapter.UpsertWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
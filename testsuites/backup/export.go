@@ -0,0 +1,296 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestBackupService_Export(t *testing.T) {
+//	    backup.UnitTestExport(t, NewBackupService(config, statusAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestExport(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		vectorStoreAdapter ports.VectorStorePort,
+		embeddingModel string,
+	) ports.BackupService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockEmbeddingModel := "text-embedding-3-small"
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockWorkspace := &domains.Workspace{
+		TenantID:       mockTenantID,
+		EncryptedDBURL: "encrypted_url",
+		Status:         domains.StatusDone,
+		Checksum:       "checksum_2",
+		ActiveVersion:  2,
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+	mockNamedWorkspace := &domains.Workspace{
+		TenantID:    mockTenantID,
+		Name:        "Sales",
+		DataSources: []domains.DataSource{{Name: "orders", EncryptedDBURL: "encrypted_orders"}},
+		Status:      domains.StatusDone,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+	mockVersions := []*domains.SchemaVersion{
+		{TenantID: mockTenantID, Version: 1, Checksum: "checksum_1", Metadata: &domains.DatabaseMetadata{TenantID: mockTenantID, Checksum: "checksum_1"}, CreatedAt: createdAt},
+		{TenantID: mockTenantID, Version: 2, Checksum: "checksum_2", Metadata: &domains.DatabaseMetadata{TenantID: mockTenantID, Tables: []domains.Table{{Name: "orders"}}, Checksum: "checksum_2"}, CreatedAt: createdAt},
+	}
+	mockDescriptions := []*domains.Description{{TenantID: mockTenantID, Table: "orders", Text: "Customer orders", Source: domains.DescriptionSourceUser, CreatedAt: createdAt, UpdatedAt: createdAt}}
+	mockTerms := []*domains.GlossaryTerm{{ID: 7, TenantID: mockTenantID, Term: "GMV", Definition: "gross merchandise value", CreatedAt: createdAt, UpdatedAt: createdAt}}
+	mockExamples := []*domains.Example{{ID: 9, TenantID: mockTenantID, Question: "How many orders?", Query: "SELECT count(*) FROM orders", CreatedAt: createdAt, UpdatedAt: createdAt}}
+	mockSemanticModel := &domains.SemanticModel{Metrics: []domains.Metric{{Name: "revenue", Expression: "sum(total)", Table: "orders"}}}
+	mockHistory := []*domains.QueryHistoryEntry{{ID: 5, TenantID: mockTenantID, Prompt: "How many orders?", Query: "SELECT count(*) FROM orders", CreatedAt: createdAt}}
+	mockVectors := []domains.Vector{
+		{ID: "v1", TenantID: mockTenantID, Embedding: []float32{0.1, 0.2}, Metadata: map[string]string{domains.VectorMetadataKind: "table", domains.VectorMetadataSchemaVersion: "2"}, Content: "orders"},
+	}
+
+	expectStatus := func(status domains.WorkspaceStatus) {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(status, nil, nil)
+	}
+
+	expectCollected := func(workspace *domains.Workspace) {
+		expectStatus(domains.StatusDone)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(workspace, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListSchemaVersionsByTenantID(gomock.Any(), mockTenantID).
+			Return([]*domains.SchemaVersion{
+				{TenantID: mockTenantID, Version: 1, Checksum: "checksum_1", CreatedAt: createdAt},
+				{TenantID: mockTenantID, Version: 2, Checksum: "checksum_2", CreatedAt: createdAt},
+			}, nil)
+		for _, version := range mockVersions {
+			mockInternalDatabaseAdapter.
+				EXPECT().
+				GetSchemaVersion(gomock.Any(), mockTenantID, version.Version).
+				Return(version, nil)
+		}
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListTenantAliasesByTenantID(gomock.Any(), mockTenantID).
+			Return([]string{"tenant_old"}, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+			Return(mockDescriptions, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+			Return(mockTerms, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListExamplesByTenantID(gomock.Any(), mockTenantID).
+			Return(mockExamples, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetSemanticModel(gomock.Any(), mockTenantID).
+			Return(mockSemanticModel, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListQueryHistoryByTenantID(gomock.Any(), mockTenantID).
+			Return(mockHistory, nil)
+	}
+
+	tests := []struct {
+		name                  string
+		missingEmbeddingModel bool
+		prepareMock           func()
+		expectError           error
+		expectData            *domains.WorkspaceBundle
+	}{
+		{
+			name: "success bundle without the database URL",
+			prepareMock: func() {
+				expectCollected(mockWorkspace)
+				mockVectorStoreAdapter.
+					EXPECT().
+					List(gomock.Any(), mockTenantID).
+					Return(mockVectors, nil)
+			},
+			expectData: &domains.WorkspaceBundle{
+				Format:         domains.WorkspaceBundleFormat,
+				EmbeddingModel: mockEmbeddingModel,
+				Workspace: &domains.Workspace{
+					TenantID:      mockTenantID,
+					Status:        domains.StatusDone,
+					Checksum:      "checksum_2",
+					ActiveVersion: 2,
+					CreatedAt:     createdAt,
+					UpdatedAt:     createdAt,
+				},
+				HasDBURL:       true,
+				Aliases:        []string{"tenant_old"},
+				SchemaVersions: mockVersions,
+				Descriptions:   mockDescriptions,
+				GlossaryTerms:  mockTerms,
+				Examples:       mockExamples,
+				SemanticModel:  mockSemanticModel,
+				QueryHistory:   mockHistory,
+				Vectors:        mockVectors,
+			},
+		},
+		{
+			name: "success bundle without the data source URLs",
+			prepareMock: func() {
+				expectCollected(mockNamedWorkspace)
+				mockVectorStoreAdapter.
+					EXPECT().
+					List(gomock.Any(), mockTenantID).
+					Return(mockVectors, nil)
+			},
+			expectData: &domains.WorkspaceBundle{
+				Format:         domains.WorkspaceBundleFormat,
+				EmbeddingModel: mockEmbeddingModel,
+				Workspace: &domains.Workspace{
+					TenantID:    mockTenantID,
+					Name:        "Sales",
+					DataSources: []domains.DataSource{{Name: "orders"}},
+					Status:      domains.StatusDone,
+					CreatedAt:   createdAt,
+					UpdatedAt:   createdAt,
+				},
+				Aliases:        []string{"tenant_old"},
+				SchemaVersions: mockVersions,
+				Descriptions:   mockDescriptions,
+				GlossaryTerms:  mockTerms,
+				Examples:       mockExamples,
+				SemanticModel:  mockSemanticModel,
+				QueryHistory:   mockHistory,
+				Vectors:        mockVectors,
+			},
+		},
+		{
+			name:                  "error embedding model not configured",
+			missingEmbeddingModel: true,
+			expectError:           ports.BackupEmbeddingModelMissingError,
+		},
+		{
+			name: "error status in progress",
+			prepareMock: func() {
+				expectStatus(domains.StatusInProgress)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "error workspace not found",
+			prepareMock: func() {
+				expectStatus(domains.StatusDone)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name: "error workspace deleted",
+			prepareMock: func() {
+				expectStatus(domains.StatusDone)
+				deleted := *mockWorkspace
+				deleted.DeletedAt = createdAt
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(&deleted, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name: "error list schema versions",
+			prepareMock: func() {
+				expectStatus(domains.StatusDone)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListSchemaVersionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error list vectors",
+			prepareMock: func() {
+				expectCollected(mockWorkspace)
+				mockVectorStoreAdapter.
+					EXPECT().
+					List(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("vector store error"))
+			},
+			expectError: errors.New("vector store error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			embeddingModel := mockEmbeddingModel
+			if tt.missingEmbeddingModel {
+				embeddingModel = ""
+			}
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockVectorStoreAdapter,
+				embeddingModel,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			var buffer bytes.Buffer
+			err := svc.Export(context.Background(), mockTenantID, &buffer)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				bundle, err := domains.ReadWorkspaceBundle(&buffer)
+				require.NoError(t, err)
+				require.False(t, bundle.ExportedAt.IsZero())
+				bundle.ExportedAt = time.Time{}
+				require.Equal(t, tt.expectData, bundle)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package description
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestDescriptionService_Import(t *testing.T) {
+//	    description.UnitTestImport(t, NewDescriptionService(config, statusAdapter, internalDatabaseAdapter))
+//	}
+func UnitTestImport(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.DescriptionService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockTenantID,
+			Status:   domains.StatusDone,
+			Checksum: "checksum_abc",
+		}
+	}
+	// The checksum is cleared so the next sync embeds the imported descriptions
+	mockStaleWorkspace := &domains.Workspace{
+		TenantID: mockTenantID,
+		Status:   domains.StatusDone,
+	}
+	mockSchema := `
+/// People who place orders
+model Customer {
+  id    Int    @id
+  /// Login e-mail
+  email String
+  /// City of residence
+  city  String
+  name  String
+
+  @@map("customers")
+}`
+	mockImported := func(column, text string) *domains.Description {
+		return &domains.Description{
+			TenantID: mockTenantID,
+			Table:    "customers",
+			Column:   column,
+			Text:     text,
+			Source:   domains.DescriptionSourceImported,
+		}
+	}
+	// The user override of email is kept, the inferred city description is
+	// replaced and the table description was already imported
+	mockStored := []*domains.Description{
+		mockImported("", "People who place orders"),
+		{TenantID: mockTenantID, Table: "customers", Column: "email", Text: "Contact address", Source: domains.DescriptionSourceUser},
+		{TenantID: mockTenantID, Table: "customers", Column: "city", Text: "Town", Source: domains.DescriptionSourceInferred},
+	}
+
+	invalidErr := ports.DescriptionImportInvalidError
+	invalidErr.AddAdditionalErrorInfo("line 3: unknown type Missing of field b")
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(), nil)
+	}
+
+	tests := []struct {
+		name        string
+		schema      string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.Description
+	}{
+		{
+			name: "success merge over stored descriptions",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockStored, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertDescription(gomock.Any(), mockImported("city", "City of residence")).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockStaleWorkspace).
+					Return(nil)
+			},
+			expectData: []*domains.Description{mockImported("city", "City of residence")},
+		},
+		{
+			name: "success nothing new keeps the checksum",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(append(mockStored, mockImported("city", "City of residence")), nil)
+			},
+		},
+		{
+			name:        "error invalid schema",
+			schema:      "model A {\n  id Int @id\n  b  Missing\n}",
+			expectError: invalidErr,
+		},
+		{
+			name: "error status in progress",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "error workspace not found",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name: "error list descriptions",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error upsert description",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockStored, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertDescription(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error upsert workspace",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockStored, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertDescription(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockStaleWorkspace).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			schema := tt.schema
+			if schema == "" {
+				schema = mockSchema
+			}
+
+			result, err := svc.Import(context.Background(), mockTenantID, domains.SchemaFormatPrisma, schema)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
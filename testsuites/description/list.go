@@ -0,0 +1,94 @@
+package description
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestDescriptionService_List(t *testing.T) {
+//	    description.UnitTestList(t, NewDescriptionService(config, statusAdapter, internalDatabaseAdapter))
+//	}
+func UnitTestList(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.DescriptionService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockDescriptions := []*domains.Description{
+		{TenantID: mockTenantID, Table: "customers", Text: "Registered customers", Source: domains.DescriptionSourceInferred},
+		{TenantID: mockTenantID, Table: "customers", Column: "city", Text: "City of residence", Source: domains.DescriptionSourceUser},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.Description
+	}{
+		{
+			name: "success list descriptions",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockDescriptions, nil)
+			},
+			expectData: mockDescriptions,
+		},
+		{
+			name: "error list descriptions",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.List(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package description
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestDescriptionService_Override(t *testing.T) {
+//	    description.UnitTestOverride(t, NewDescriptionService(config, statusAdapter, internalDatabaseAdapter))
+//	}
+func UnitTestOverride(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.DescriptionService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockTenantID,
+			Status:   domains.StatusDone,
+			Checksum: "checksum_abc",
+		}
+	}
+	// The checksum is cleared so the next sync embeds the override
+	mockStaleWorkspace := &domains.Workspace{
+		TenantID: mockTenantID,
+		Status:   domains.StatusDone,
+	}
+	mockDescription := &domains.Description{
+		TenantID: mockTenantID,
+		Table:    "customers",
+		Column:   "city",
+		Text:     "City of residence",
+		Source:   domains.DescriptionSourceUser,
+	}
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(), nil)
+	}
+
+	tests := []struct {
+		name        string
+		table       string
+		text        string
+		prepareMock func()
+		expectError error
+		expectData  *domains.Description
+	}{
+		{
+			name:  "success override description",
+			table: "customers",
+			text:  "  City of residence\n",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertDescription(gomock.Any(), mockDescription).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockStaleWorkspace).
+					Return(nil)
+			},
+			expectData: mockDescription,
+		},
+		{
+			name:        "error empty text",
+			table:       "customers",
+			text:        "   ",
+			expectError: ports.DescriptionInvalidError,
+		},
+		{
+			name:        "error empty table",
+			text:        "City of residence",
+			expectError: ports.DescriptionInvalidError,
+		},
+		{
+			name:  "error status in progress",
+			table: "customers",
+			text:  "City of residence",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:  "error workspace not found",
+			table: "customers",
+			text:  "City of residence",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:  "error workspace deleted",
+			table: "customers",
+			text:  "City of residence",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(&domains.Workspace{TenantID: mockTenantID, DeletedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name:  "error upsert description",
+			table: "customers",
+			text:  "City of residence",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertDescription(gomock.Any(), mockDescription).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error upsert workspace",
+			table: "customers",
+			text:  "City of residence",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertDescription(gomock.Any(), mockDescription).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockStaleWorkspace).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Override(context.Background(), mockTenantID, tt.table, "city", tt.text)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package description
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestDescriptionService_Reset(t *testing.T) {
+//	    description.UnitTestReset(t, NewDescriptionService(config, statusAdapter, internalDatabaseAdapter))
+//	}
+func UnitTestReset(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.DescriptionService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockTenantID,
+			Status:   domains.StatusDone,
+			Checksum: "checksum_abc",
+		}
+	}
+	mockStaleWorkspace := &domains.Workspace{
+		TenantID: mockTenantID,
+		Status:   domains.StatusDone,
+	}
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(), nil)
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success reset description",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteDescription(gomock.Any(), mockTenantID, "customers", "city").
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockStaleWorkspace).
+					Return(nil)
+			},
+		},
+		{
+			name: "error get status",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, errors.New("status error"))
+			},
+			expectError: errors.New("status error"),
+		},
+		{
+			name: "error get workspace",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error delete description",
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteDescription(gomock.Any(), mockTenantID, "customers", "city").
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := svc.Reset(context.Background(), mockTenantID, "customers", "city")
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package embeddingcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestLRUEmbeddingCacheAdapter(t *testing.T) {
+//	    embeddingcache.ContractTestEmbeddingCache(t, func(t *testing.T) ports.EmbeddingCachePort {
+//	        return NewLRUEmbeddingCacheAdapter(nil)
+//	    })
+//	}
+func ContractTestEmbeddingCache(
+	t *testing.T,
+	newCache func(t *testing.T) ports.EmbeddingCachePort,
+) {
+	ctx := context.Background()
+
+	t.Run("missing keys are absent", func(t *testing.T) {
+		cache := newCache(t)
+
+		result, err := cache.GetMany(ctx, []string{"model:missing"})
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("stored embeddings are returned", func(t *testing.T) {
+		cache := newCache(t)
+
+		require.NoError(t, cache.SetMany(ctx, map[string][]float32{
+			"model:a": {0.1, 0.2, 0.3},
+			"model:b": {-1.5, 2.5},
+		}))
+
+		result, err := cache.GetMany(ctx, []string{"model:a", "model:missing", "model:b"})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]float32{
+			"model:a": {0.1, 0.2, 0.3},
+			"model:b": {-1.5, 2.5},
+		}, result)
+	})
+
+	t.Run("storing a key again replaces it", func(t *testing.T) {
+		cache := newCache(t)
+
+		require.NoError(t, cache.SetMany(ctx, map[string][]float32{"model:a": {0.1}}))
+		require.NoError(t, cache.SetMany(ctx, map[string][]float32{"model:a": {0.9}}))
+
+		result, err := cache.GetMany(ctx, []string{"model:a"})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]float32{"model:a": {0.9}}, result)
+	})
+
+	t.Run("empty calls", func(t *testing.T) {
+		cache := newCache(t)
+
+		require.NoError(t, cache.SetMany(ctx, map[string][]float32{}))
+		result, err := cache.GetMany(ctx, []string{})
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+}
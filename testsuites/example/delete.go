@@ -0,0 +1,154 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestExampleService_Delete(t *testing.T) {
+//	    example.UnitTestDelete(t, NewExampleService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestDelete(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockFilter := map[string]string{
+		domains.VectorMetadataKind:    string(domains.DocumentKindExample),
+		domains.VectorMetadataExample: "5",
+	}
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success delete example and its vector",
+			prepareMock: func() {
+				expectEditable()
+				mockVectorStoreAdapter.
+					EXPECT().
+					DeleteByFilter(gomock.Any(), mockTenantID, mockFilter).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteExample(gomock.Any(), mockTenantID, int64(5)).
+					Return(nil)
+			},
+		},
+		{
+			name: "error status in progress",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "error delete vector keeps the example",
+			prepareMock: func() {
+				expectEditable()
+				mockVectorStoreAdapter.
+					EXPECT().
+					DeleteByFilter(gomock.Any(), mockTenantID, mockFilter).
+					Return(errors.New("vector store error"))
+			},
+			expectError: errors.New("vector store error"),
+		},
+		{
+			name: "error example not found",
+			prepareMock: func() {
+				expectEditable()
+				mockVectorStoreAdapter.
+					EXPECT().
+					DeleteByFilter(gomock.Any(), mockTenantID, mockFilter).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteExample(gomock.Any(), mockTenantID, int64(5)).
+					Return(domains.ErrExampleNotFound)
+			},
+			expectError: ports.ExampleNotFoundError,
+		},
+		{
+			name: "error delete example",
+			prepareMock: func() {
+				expectEditable()
+				mockVectorStoreAdapter.
+					EXPECT().
+					DeleteByFilter(gomock.Any(), mockTenantID, mockFilter).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteExample(gomock.Any(), mockTenantID, int64(5)).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mocks.NewMockEmbedderPort(ctrl),
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := svc.Delete(context.Background(), mockTenantID, 5)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestExampleService_Get(t *testing.T) {
+//	    example.UnitTestGet(t, NewExampleService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestGet(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockExample := &domains.Example{ID: 2, TenantID: mockTenantID, Question: "Top clients", Query: "SELECT name FROM customers LIMIT 10"}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  *domains.Example
+	}{
+		{
+			name: "success get example",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetExample(gomock.Any(), mockTenantID, int64(2)).
+					Return(mockExample, nil)
+			},
+			expectData: mockExample,
+		},
+		{
+			name: "error example not found",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetExample(gomock.Any(), mockTenantID, int64(2)).
+					Return(nil, nil)
+			},
+			expectError: ports.ExampleNotFoundError,
+		},
+		{
+			name: "error get example",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetExample(gomock.Any(), mockTenantID, int64(2)).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mocks.NewMockStatusPort(ctrl),
+				mockInternalDatabaseAdapter,
+				mocks.NewMockEmbedderPort(ctrl),
+				mocks.NewMockVectorStorePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Get(context.Background(), mockTenantID, 2)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestExampleService_Import(t *testing.T) {
+//	    example.UnitTestImport(t, NewExampleService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestImport(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEmbedderAdapter         *mocks.MockEmbedderPort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+	}
+
+	// Another example asks a different question
+	mockExisting := []*domains.Example{
+		{ID: 9, TenantID: mockTenantID, Question: "How many customers?", Query: "SELECT count(*) FROM customers"},
+	}
+	expectExisting := func(examples []*domains.Example, err error) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListExamplesByTenantID(gomock.Any(), mockTenantID).
+			Return(examples, err)
+	}
+	existsError := func(question string) error {
+		err := ports.ExampleExistsError
+		err.AddAdditionalErrorInfo(fmt.Sprintf("question %q", question))
+		return err
+	}
+
+	mockInput := `{"question": "How many orders?", "query": "SELECT count(*) FROM orders"}
+
+{"question": "Top clients", "query": "SELECT name FROM customers LIMIT 10", "notes": "by revenue"}
+`
+	mockImported := []*domains.Example{
+		{ID: 1, TenantID: mockTenantID, Question: "How many orders?", Query: "SELECT count(*) FROM orders"},
+		{ID: 2, TenantID: mockTenantID, Question: "Top clients", Query: "SELECT name FROM customers LIMIT 10", Notes: "by revenue"},
+	}
+	mockEmbeddings := [][]float32{{0.1, 0.2}, {0.3, 0.4}}
+	mockDocuments := make([]domains.Vector, len(mockImported))
+	for i, example := range mockImported {
+		mockDocuments[i] = domains.ExampleDocument(example)
+		mockDocuments[i].Embedding = mockEmbeddings[i]
+	}
+
+	expectStored := func(err error) {
+		id := int64(0)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			UpsertExample(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, example *domains.Example) error {
+				id++
+				example.ID = id
+				return err
+			}).
+			Times(len(mockImported))
+	}
+
+	invalidLine := func(info string) error {
+		err := ports.ExampleInvalidError
+		err.AddAdditionalErrorInfo(info)
+		return err
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.Example
+	}{
+		{
+			name:  "success import every line in one batch",
+			input: mockInput,
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				expectStored(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					EmbedBatch(gomock.Any(), []string{"How many orders?", "Top clients"}).
+					Return(mockEmbeddings, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Upsert(gomock.Any(), mockTenantID, mockDocuments).
+					Return(nil)
+			},
+			expectData: mockImported,
+		},
+		{
+			name:       "success empty input",
+			input:      "\n",
+			expectData: []*domains.Example{},
+		},
+		{
+			name:        "error invalid json",
+			input:       mockInput + "{not json}\n",
+			expectError: invalidLine("line 4: invalid character 'n' looking for beginning of object key string"),
+		},
+		{
+			name:        "error missing query",
+			input:       `{"question": "How many orders?"}` + "\n" + mockInput,
+			expectError: invalidLine("line 1: missing question or query"),
+		},
+		{
+			name:  "error status in progress",
+			input: mockInput,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:  "error question already asked",
+			input: mockInput,
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(append(mockExisting, &domains.Example{ID: 7, TenantID: mockTenantID, Question: "TOP CLIENTS", Query: "SELECT 1"}), nil)
+			},
+			expectError: existsError("Top clients"),
+		},
+		{
+			name:  "error list examples",
+			input: mockInput,
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error store example",
+			input: mockInput,
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertExample(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error embed examples",
+			input: mockInput,
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				expectStored(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					EmbedBatch(gomock.Any(), []string{"How many orders?", "Top clients"}).
+					Return(nil, errors.New("embedder error"))
+			},
+			expectError: errors.New("embedder error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEmbedderAdapter = mocks.NewMockEmbedderPort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockEmbedderAdapter,
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Import(context.Background(), mockTenantID, strings.NewReader(tt.input))
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestExampleService_List(t *testing.T) {
+//	    example.UnitTestList(t, NewExampleService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestList(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockExamples := []*domains.Example{
+		{ID: 1, TenantID: mockTenantID, Question: "How many orders?", Query: "SELECT count(*) FROM orders"},
+		{ID: 2, TenantID: mockTenantID, Question: "Top clients", Query: "SELECT name FROM customers LIMIT 10", Notes: "by revenue"},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.Example
+	}{
+		{
+			name: "success list examples",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListExamplesByTenantID(gomock.Any(), mockTenantID).
+					Return(mockExamples, nil)
+			},
+			expectData: mockExamples,
+		},
+		{
+			name: "error list examples",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListExamplesByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mocks.NewMockStatusPort(ctrl),
+				mockInternalDatabaseAdapter,
+				mocks.NewMockEmbedderPort(ctrl),
+				mocks.NewMockVectorStorePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.List(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestExampleService_Promote(t *testing.T) {
+//	    example.UnitTestPromote(t, NewExampleService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestPromote(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEmbedderAdapter         *mocks.MockEmbedderPort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockEmbedding := []float32{0.1, 0.2, 0.3}
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+	}
+
+	// Another example asks a different question
+	mockExisting := []*domains.Example{
+		{ID: 9, TenantID: mockTenantID, Question: "How many customers?", Query: "SELECT count(*) FROM customers"},
+	}
+	expectExisting := func(examples []*domains.Example, err error) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListExamplesByTenantID(gomock.Any(), mockTenantID).
+			Return(examples, err)
+	}
+	existsError := func(question string) error {
+		err := ports.ExampleExistsError
+		err.AddAdditionalErrorInfo(fmt.Sprintf("question %q", question))
+		return err
+	}
+
+	// A query generated without client database access was not executed,
+	// but is still a verified answer to the prompt
+	mockEntry := func(warning string) *domains.QueryHistoryEntry {
+		return &domains.QueryHistoryEntry{
+			ID:       42,
+			TenantID: mockTenantID,
+			Prompt:   "How many orders shipped?",
+			Query:    "SELECT count(*) FROM orders WHERE status = 'shipped'",
+			Warning:  warning,
+		}
+	}
+	mockPromoted := &domains.Example{
+		ID:       5,
+		TenantID: mockTenantID,
+		Question: "How many orders shipped?",
+		Query:    "SELECT count(*) FROM orders WHERE status = 'shipped'",
+		Notes:    "from the query history",
+	}
+	mockDocument := domains.ExampleDocument(mockPromoted)
+	mockDocument.Embedding = mockEmbedding
+
+	expectEntry := func(entry *domains.QueryHistoryEntry, err error) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetQueryHistoryEntry(gomock.Any(), mockTenantID, int64(42)).
+			Return(entry, err)
+	}
+	expectStored := func(err error) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			UpsertExample(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, example *domains.Example) error {
+				example.ID = 5
+				return err
+			})
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  *domains.Example
+	}{
+		{
+			name: "success promote query generated without data",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(ports.QueryServiceWarnWontExecuteClientDatabaseError), nil)
+				expectExisting(mockExisting, nil)
+				expectStored(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					EmbedBatch(gomock.Any(), []string{mockDocument.Content}).
+					Return([][]float32{mockEmbedding}, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Upsert(gomock.Any(), mockTenantID, []domains.Vector{mockDocument}).
+					Return(nil)
+			},
+			expectData: mockPromoted,
+		},
+		{
+			name: "error status in progress",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "error history entry not found",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(nil, nil)
+			},
+			expectError: ports.QueryHistoryEntryNotFoundError,
+		},
+		{
+			name: "error get history entry",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error unsafe query",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(ports.QueryServiceWarnQueryGeneratedUnsafe), nil)
+			},
+			expectError: ports.ExampleUnverifiedError,
+		},
+		{
+			name: "error DDL/DML query",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(ports.QueryServiceWarnDDLDMLDetected), nil)
+			},
+			expectError: ports.ExampleUnverifiedError,
+		},
+		{
+			name: "error improvised metric",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(ports.QueryServiceWarnMetricNotCanonical), nil)
+			},
+			expectError: ports.ExampleUnverifiedError,
+		},
+		{
+			name: "error no query generated",
+			prepareMock: func() {
+				expectEditable()
+				entry := mockEntry("")
+				entry.Query = ""
+				expectEntry(entry, nil)
+			},
+			expectError: ports.ExampleUnverifiedError,
+		},
+		{
+			name: "error question already asked",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(""), nil)
+				expectExisting(append(mockExisting, &domains.Example{ID: 7, TenantID: mockTenantID, Question: "how many orders SHIPPED?", Query: "SELECT 1"}), nil)
+			},
+			expectError: existsError("How many orders shipped?"),
+		},
+		{
+			name: "error list examples",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(""), nil)
+				expectExisting(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error store example",
+			prepareMock: func() {
+				expectEditable()
+				expectEntry(mockEntry(""), nil)
+				expectExisting(mockExisting, nil)
+				expectStored(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEmbedderAdapter = mocks.NewMockEmbedderPort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockEmbedderAdapter,
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Promote(context.Background(), mockTenantID, 42, " from the query history ")
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestExampleService_Update(t *testing.T) {
+//	    example.UnitTestUpdate(t, NewExampleService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestUpdate(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.ExampleService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEmbedderAdapter         *mocks.MockEmbedderPort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockEmbedding := []float32{0.1, 0.2, 0.3}
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+	}
+
+	// The updated example keeps its own question
+	mockExisting := []*domains.Example{
+		{ID: 9, TenantID: mockTenantID, Question: "How many customers?", Query: "SELECT count(*) FROM customers"},
+		{ID: 5, TenantID: mockTenantID, Question: "How many orders shipped?", Query: "SELECT count(*) FROM orders WHERE status = 'shipped'"},
+	}
+	expectExisting := func(examples []*domains.Example, err error) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListExamplesByTenantID(gomock.Any(), mockTenantID).
+			Return(examples, err)
+	}
+	existsError := func(question string) error {
+		err := ports.ExampleExistsError
+		err.AddAdditionalErrorInfo(fmt.Sprintf("question %q", question))
+		return err
+	}
+
+	mockInput := func() *domains.Example {
+		return &domains.Example{
+			ID:       5,
+			TenantID: mockTenantID,
+			Question: "How many orders shipped?",
+			Query:    "SELECT count(id) FROM orders WHERE status = 'shipped'",
+			Notes:    " count ids ",
+		}
+	}
+	mockUpdated := mockInput()
+	mockUpdated.Notes = "count ids"
+	// The document keeps the ID of the previous vector, which it overwrites
+	mockDocument := domains.ExampleDocument(mockUpdated)
+	mockDocument.Embedding = mockEmbedding
+
+	tests := []struct {
+		name        string
+		input       *domains.Example
+		prepareMock func()
+		expectError error
+		expectData  *domains.Example
+	}{
+		{
+			name:  "success update and re-embed example",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertExample(gomock.Any(), mockUpdated).
+					Return(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					EmbedBatch(gomock.Any(), []string{mockDocument.Content}).
+					Return([][]float32{mockEmbedding}, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Upsert(gomock.Any(), mockTenantID, []domains.Vector{mockDocument}).
+					Return(nil)
+			},
+			expectData: mockUpdated,
+		},
+		{
+			name:        "error invalid example",
+			input:       &domains.Example{ID: 5, TenantID: mockTenantID, Query: "SELECT 1"},
+			expectError: ports.ExampleInvalidError,
+		},
+		{
+			name:  "error status in progress",
+			input: mockInput(),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:  "error example not found",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertExample(gomock.Any(), mockUpdated).
+					Return(domains.ErrExampleNotFound)
+			},
+			expectError: ports.ExampleNotFoundError,
+		},
+		{
+			name:  "error question already asked",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(append(mockExisting, &domains.Example{ID: 7, TenantID: mockTenantID, Question: "how many orders SHIPPED?", Query: "SELECT 1"}), nil)
+			},
+			expectError: existsError("How many orders shipped?"),
+		},
+		{
+			name:  "error list examples",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error store example",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertExample(gomock.Any(), mockUpdated).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error embed example",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectExisting(mockExisting, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertExample(gomock.Any(), mockUpdated).
+					Return(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					EmbedBatch(gomock.Any(), []string{mockDocument.Content}).
+					Return(nil, errors.New("embedder error"))
+			},
+			expectError: errors.New("embedder error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEmbedderAdapter = mocks.NewMockEmbedderPort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockEmbedderAdapter,
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Update(context.Background(), tt.input)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestGlossaryService_Create(t *testing.T) {
+//	    glossary.UnitTestCreate(t, NewGlossaryService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestCreate(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.GlossaryService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEmbedderAdapter         *mocks.MockEmbedderPort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockEmbedding := []float32{0.1, 0.2, 0.3}
+
+	mockInput := func() *domains.GlossaryTerm {
+		return &domains.GlossaryTerm{
+			TenantID:   mockTenantID,
+			Term:       " GMV ",
+			Definition: "sum(order_items.price*qty) excluding refunds",
+			Synonyms:   []string{"gross merchandise value", " "},
+		}
+	}
+	mockStored := &domains.GlossaryTerm{
+		ID:         5,
+		TenantID:   mockTenantID,
+		Term:       "GMV",
+		Definition: "sum(order_items.price*qty) excluding refunds",
+		Synonyms:   []string{"gross merchandise value"},
+	}
+	mockDocument := domains.GlossaryDocument(mockStored)
+	mockDocument.Embedding = mockEmbedding
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+	}
+	expectStored := func(err error) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+			Return([]*domains.GlossaryTerm{{ID: 2, TenantID: mockTenantID, Term: "client"}}, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			UpsertGlossaryTerm(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, term *domains.GlossaryTerm) error {
+				if err == nil {
+					term.ID = 5
+				}
+				return err
+			})
+	}
+
+	tests := []struct {
+		name        string
+		input       *domains.GlossaryTerm
+		prepareMock func()
+		expectError error
+		expectData  *domains.GlossaryTerm
+	}{
+		{
+			name:  "success create and embed term",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectStored(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockDocument.Content).
+					Return(mockEmbedding, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Upsert(gomock.Any(), mockTenantID, []domains.Vector{mockDocument}).
+					Return(nil)
+			},
+			expectData: mockStored,
+		},
+		{
+			name:        "error invalid term",
+			input:       &domains.GlossaryTerm{TenantID: mockTenantID, Term: "GMV", Definition: " "},
+			expectError: ports.GlossaryTermInvalidError,
+		},
+		{
+			name:  "error workspace not found",
+			input: mockInput(),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:  "error workspace deleted",
+			input: mockInput(),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(&domains.Workspace{TenantID: mockTenantID, DeletedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name:  "error status in progress",
+			input: mockInput(),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:  "error term exists",
+			input: &domains.GlossaryTerm{TenantID: mockTenantID, Term: "Client", Definition: "customers table"},
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return([]*domains.GlossaryTerm{{ID: 2, TenantID: mockTenantID, Term: "client"}}, nil)
+			},
+			expectError: ports.GlossaryTermExistsError,
+		},
+		{
+			name:  "error store term",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectStored(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error embed term",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectStored(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockDocument.Content).
+					Return(nil, errors.New("embedder error"))
+			},
+			expectError: errors.New("embedder error"),
+		},
+		{
+			name:  "error upsert vector",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				expectStored(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockDocument.Content).
+					Return(mockEmbedding, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Upsert(gomock.Any(), mockTenantID, []domains.Vector{mockDocument}).
+					Return(errors.New("vector store error"))
+			},
+			expectError: errors.New("vector store error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEmbedderAdapter = mocks.NewMockEmbedderPort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockEmbedderAdapter,
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Create(context.Background(), tt.input)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestGlossaryService_List(t *testing.T) {
+//	    glossary.UnitTestList(t, NewGlossaryService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestList(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.GlossaryService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockTerms := []*domains.GlossaryTerm{
+		{ID: 1, TenantID: mockTenantID, Term: "GMV", Definition: "sum(order_items.price*qty) excluding refunds"},
+		{ID: 2, TenantID: mockTenantID, Term: "client", Definition: "customers table", Synonyms: []string{"customer"}},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.GlossaryTerm
+	}{
+		{
+			name: "success list glossary",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockTerms, nil)
+			},
+			expectData: mockTerms,
+		},
+		{
+			name: "error list glossary",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mocks.NewMockStatusPort(ctrl),
+				mockInternalDatabaseAdapter,
+				mocks.NewMockEmbedderPort(ctrl),
+				mocks.NewMockVectorStorePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.List(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
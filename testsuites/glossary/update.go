@@ -0,0 +1,186 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestGlossaryService_Update(t *testing.T) {
+//	    glossary.UnitTestUpdate(t, NewGlossaryService(config, statusAdapter, internalDatabaseAdapter, embedderAdapter, vectorStoreAdapter))
+//	}
+func UnitTestUpdate(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedderAdapter ports.EmbedderPort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.GlossaryService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEmbedderAdapter         *mocks.MockEmbedderPort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockEmbedding := []float32{0.1, 0.2, 0.3}
+	mockExisting := []*domains.GlossaryTerm{
+		{ID: 2, TenantID: mockTenantID, Term: "client", Definition: "customers table"},
+		{ID: 5, TenantID: mockTenantID, Term: "GMV", Definition: "sum(order_items.price*qty)"},
+	}
+
+	mockInput := func() *domains.GlossaryTerm {
+		return &domains.GlossaryTerm{
+			ID:         5,
+			TenantID:   mockTenantID,
+			Term:       "gmv",
+			Definition: "sum(order_items.price*qty) excluding refunds",
+		}
+	}
+	mockUpdated := mockInput()
+	mockUpdated.Synonyms = []string{}
+	// The document keeps the ID of the previous vector, which it overwrites
+	mockDocument := domains.GlossaryDocument(mockUpdated)
+	mockDocument.Embedding = mockEmbedding
+
+	expectEditable := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+			Return(mockExisting, nil)
+	}
+
+	tests := []struct {
+		name        string
+		input       *domains.GlossaryTerm
+		prepareMock func()
+		expectError error
+		expectData  *domains.GlossaryTerm
+	}{
+		{
+			name:  "success update and re-embed term",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertGlossaryTerm(gomock.Any(), mockUpdated).
+					Return(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockDocument.Content).
+					Return(mockEmbedding, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Upsert(gomock.Any(), mockTenantID, []domains.Vector{mockDocument}).
+					Return(nil)
+			},
+			expectData: mockUpdated,
+		},
+		{
+			name:        "error invalid term",
+			input:       &domains.GlossaryTerm{ID: 5, TenantID: mockTenantID, Definition: "revenue"},
+			expectError: ports.GlossaryTermInvalidError,
+		},
+		{
+			name:  "error renamed to an existing term",
+			input: &domains.GlossaryTerm{ID: 5, TenantID: mockTenantID, Term: "CLIENT", Definition: "customers table"},
+			prepareMock: func() {
+				expectEditable()
+			},
+			expectError: ports.GlossaryTermExistsError,
+		},
+		{
+			name:  "error term not found",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertGlossaryTerm(gomock.Any(), mockUpdated).
+					Return(domains.ErrGlossaryTermNotFound)
+			},
+			expectError: ports.GlossaryTermNotFoundError,
+		},
+		{
+			name:  "error store term",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertGlossaryTerm(gomock.Any(), mockUpdated).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:  "error embed term",
+			input: mockInput(),
+			prepareMock: func() {
+				expectEditable()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertGlossaryTerm(gomock.Any(), mockUpdated).
+					Return(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockDocument.Content).
+					Return(nil, errors.New("embedder error"))
+			},
+			expectError: errors.New("embedder error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEmbedderAdapter = mocks.NewMockEmbedderPort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockEmbedderAdapter,
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Update(context.Background(), tt.input)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package ingestion
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -12,6 +13,26 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// progressMatcher matches the reported phase and counters, ignoring the
+// wall-clock fields.
+type progressMatcher struct {
+	phase domains.IngestionPhase
+	done  int
+	total int
+}
+
+func (m progressMatcher) Matches(x interface{}) bool {
+	progress, ok := x.(domains.IngestionProgress)
+	if !ok {
+		return false
+	}
+	return progress.Phase == m.phase && progress.Done == m.done && progress.Total == m.total && !progress.StartedAt.IsZero()
+}
+
+func (m progressMatcher) String() string {
+	return fmt.Sprintf("%s progress %d/%d", m.phase, m.done, m.total)
+}
+
 // @example usage:
 //
 //	func TestIngestionService_VectorizeAndStore(t *testing.T) {
@@ -23,13 +44,17 @@ func UnitTestVectorizeAndStore(
 		embedderAdapter ports.EmbedderPort,
 		vectorStoreAdapter ports.VectorStorePort,
 		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		embedBatchSize int,
+		upsertBatchSize int,
 	) ports.IngestionService,
-	metadataToContentUtil func(metadata *domains.DatabaseMetadata) []string,
+	documentsUtil func(metadata *domains.DatabaseMetadata) []domains.Vector,
 ) {
 	var (
-		mockEmbedderAdapter    *mocks.MockEmbedderPort
-		mockVectorStoreAdapter *mocks.MockVectorStorePort
-		mockStatusAdapter      *mocks.MockStatusPort
+		mockEmbedderAdapter         *mocks.MockEmbedderPort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
 	)
 
 	mockMetaData := &domains.DatabaseMetadata{
@@ -93,26 +118,161 @@ func UnitTestVectorizeAndStore(
 		},
 	}
 
-	mockContents := metadataToContentUtil(mockMetaData)
-	mockVector := make([][]float32, len(mockContents))
-	for i := range mockContents {
-		mockVector[i] = []float32{0.1 * float32(i+1), 0.2 * float32(i+1), 0.3 * float32(i+1)}
+	// Glossary terms and examples are re-embedded along with the schema on a
+	// full ingestion
+	mockGlossaryTerms := []*domains.GlossaryTerm{
+		{ID: 1, TenantID: mockMetaData.TenantID, Term: "staff", Definition: "rows of the employees table", Synonyms: []string{"personnel"}},
 	}
-
-	mockVectorEntities := make([]domains.Vector, len(mockContents))
-	for i := range mockContents {
+	mockExamples := []*domains.Example{
+		{ID: 1, TenantID: mockMetaData.TenantID, Question: "Who manages Ada?", Query: "SELECT m.name FROM employees e JOIN employees m ON e.manager_id = m.emp_id"},
+	}
+	mockDocuments := documentsUtil(mockMetaData)
+	for _, term := range mockGlossaryTerms {
+		mockDocuments = append(mockDocuments, domains.GlossaryDocument(term))
+	}
+	for _, example := range mockExamples {
+		mockDocuments = append(mockDocuments, domains.ExampleDocument(example))
+	}
+	mockSchemaDocuments := len(mockDocuments) - len(mockGlossaryTerms) - len(mockExamples)
+	mockContents := make([]string, len(mockDocuments))
+	mockVector := make([][]float32, len(mockDocuments))
+	mockVectorEntities := make([]domains.Vector, len(mockDocuments))
+	for i, document := range mockDocuments {
+		mockContents[i] = document.Content
+		mockVector[i] = []float32{0.1 * float32(i+1), 0.2 * float32(i+1), 0.3 * float32(i+1)}
 		mockVectorEntities[i] = domains.Vector{
+			ID:        document.ID,
 			TenantID:  mockMetaData.TenantID,
 			Embedding: mockVector[i],
-			Content:   mockContents[i],
+			Metadata:  document.Metadata,
+			Content:   document.Content,
+		}
+		// Schema documents are tagged with the version they are first embedded in
+		if i < mockSchemaDocuments {
+			metadata := map[string]string{domains.VectorMetadataSchemaVersion: "3"}
+			for key, value := range document.Metadata {
+				metadata[key] = value
+			}
+			mockVectorEntities[i].Metadata = metadata
+		}
+	}
+
+	mockVersion := &domains.SchemaVersion{
+		TenantID: mockMetaData.TenantID,
+		Version:  3,
+		Checksum: mockMetaData.Checksum,
+	}
+	expectVersionSaved := func() {
+		mockInternalDatabaseAdapter.EXPECT().
+			SaveSchemaVersion(gomock.Any(), mockMetaData).
+			Return(mockVersion, nil)
+	}
+
+	mockStoredWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID:       mockMetaData.TenantID,
+			EncryptedDBURL: "encrypted_url",
+			Status:         domains.StatusInProgress,
+			Checksum:       "sha256:previous",
+		}
+	}
+
+	mockTableChecksums := mockMetaData.TableChecksums()
+
+	mockCommittedWorkspace := mockStoredWorkspace()
+	mockCommittedWorkspace.Status = domains.StatusDone
+	mockCommittedWorkspace.Checksum = mockMetaData.Checksum
+	mockCommittedWorkspace.TableChecksums = mockTableChecksums
+	mockCommittedWorkspace.ActiveVersion = mockVersion.Version
+
+	// Nothing ingested before: every vector of the tenant is replaced
+	expectFullIngestionPlanned := func() {
+		mockInternalDatabaseAdapter.EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+			Return(mockStoredWorkspace(), nil)
+
+		mockInternalDatabaseAdapter.EXPECT().
+			ListGlossaryTermsByTenantID(gomock.Any(), mockMetaData.TenantID).
+			Return(mockGlossaryTerms, nil)
+
+		mockInternalDatabaseAdapter.EXPECT().
+			ListExamplesByTenantID(gomock.Any(), mockMetaData.TenantID).
+			Return(mockExamples, nil)
+	}
+
+	// A previous ingestion where project_assignments differed and a dropped
+	// legacy table existed; employees is unchanged
+	mockPreviousWorkspace := mockStoredWorkspace()
+	mockPreviousWorkspace.TableChecksums = map[string]string{
+		"employees":           mockTableChecksums["employees"],
+		"project_assignments": "sha256:outdated",
+		"legacy_orders":       "sha256:dropped",
+	}
+
+	var mockChangedContents []string
+	var mockChangedVectors []domains.Vector
+	for i, vector := range mockVectorEntities {
+		if vector.Metadata[domains.VectorMetadataTable] == "project_assignments" {
+			mockChangedContents = append(mockChangedContents, mockContents[i])
+			mockChangedVectors = append(mockChangedVectors, vector)
+		}
+	}
+	mockChangedEmbeddings := mockVector[:len(mockChangedContents)]
+	for i := range mockChangedVectors {
+		mockChangedVectors[i].Embedding = mockChangedEmbeddings[i]
+	}
+
+	// Once the new vectors are stored, those not rewritten are deleted: on a
+	// full ingestion every other vector, such as one of a table dropped since
+	mockStaleVector := domains.Vector{
+		ID:       "stale_vector",
+		TenantID: mockMetaData.TenantID,
+		Metadata: map[string]string{domains.VectorMetadataKind: "table", domains.VectorMetadataTable: "dropped_table"},
+	}
+	expectStaleVectorsDeleted := func() {
+		mockVectorStoreAdapter.EXPECT().
+			List(gomock.Any(), mockMetaData.TenantID).
+			Return(append(append([]domains.Vector{}, mockVectorEntities...), mockStaleVector), nil)
+
+		mockVectorStoreAdapter.EXPECT().
+			DeleteByIDs(gomock.Any(), mockMetaData.TenantID, []string{mockStaleVector.ID}).
+			Return(nil)
+	}
+
+	// and on an incremental one the vectors of altered and dropped tables;
+	// employees and the glossary are kept
+	mockIncrementalStoredVectors := []domains.Vector{
+		{ID: "legacy_vector", TenantID: mockMetaData.TenantID, Metadata: map[string]string{domains.VectorMetadataKind: "table", domains.VectorMetadataTable: "legacy_orders"}},
+		{ID: "outdated_vector", TenantID: mockMetaData.TenantID, Metadata: map[string]string{domains.VectorMetadataKind: "column", domains.VectorMetadataTable: "project_assignments"}},
+	}
+	for _, vector := range mockVectorEntities {
+		if vector.Metadata[domains.VectorMetadataTable] != "project_assignments" {
+			mockIncrementalStoredVectors = append(mockIncrementalStoredVectors, vector)
 		}
 	}
+	mockIncrementalStoredVectors = append(mockIncrementalStoredVectors, mockChangedVectors...)
+
+	expectChecksumCommitted := func() {
+		mockInternalDatabaseAdapter.EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+			Return(mockStoredWorkspace(), nil)
+
+		mockInternalDatabaseAdapter.EXPECT().
+			UpsertWorkspace(gomock.Any(), mockCommittedWorkspace).
+			Return(nil)
+	}
+
+	// Nothing is stored, deleted or committed when an embedding is missing
+	mockCountError := ports.IngestionEmbeddingCountError
+	mockCountError.AddAdditionalErrorInfo(fmt.Sprintf("%d embeddings for %d documents", len(mockVector)-1, len(mockVector)))
 
 	tests := []struct {
-		name        string
-		metadata    *domains.DatabaseMetadata
-		prepareMock func()
-		expectError error
+		name            string
+		metadata        *domains.DatabaseMetadata
+		embedBatchSize  int
+		upsertBatchSize int
+		prepareMock     func()
+		expectError     error
 	}{
 		{
 			name:     "success",
@@ -122,20 +282,148 @@ func UnitTestVectorizeAndStore(
 					SetInProgress(gomock.Any(), mockMetaData.TenantID).
 					Return(nil)
 
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
 				mockEmbedderAdapter.EXPECT().
 					EmbedBatch(gomock.Any(), gomock.Any()).
 					Return(mockVector, nil)
 
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseEmbedding, len(mockContents), len(mockContents)}).
+					Return(nil)
+
 				mockVectorStoreAdapter.EXPECT().
 					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
 					Return(nil)
 
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseUpserting, len(mockContents), len(mockContents)}).
+					Return(nil)
+
+				expectStaleVectorsDeleted()
+
+				expectChecksumCommitted()
+
+				mockStatusAdapter.EXPECT().
+					SetDone(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name:            "success in batches",
+			metadata:        mockMetaData,
+			embedBatchSize:  3,
+			upsertBatchSize: 5,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				for start := 0; start < len(mockContents); start += 3 {
+					end := min(start+3, len(mockContents))
+					mockEmbedderAdapter.EXPECT().
+						EmbedBatch(gomock.Any(), mockContents[start:end]).
+						Return(mockVector[start:end], nil)
+
+					mockStatusAdapter.EXPECT().
+						SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseEmbedding, end, len(mockContents)}).
+						Return(nil)
+				}
+
+				for start := 0; start < len(mockVectorEntities); start += 5 {
+					end := min(start+5, len(mockVectorEntities))
+					mockVectorStoreAdapter.EXPECT().
+						Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities[start:end]).
+						Return(nil)
+
+					mockStatusAdapter.EXPECT().
+						SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseUpserting, end, len(mockVectorEntities)}).
+						Return(nil)
+				}
+
+				expectStaleVectorsDeleted()
+
+				expectChecksumCommitted()
+
 				mockStatusAdapter.EXPECT().
 					SetDone(gomock.Any(), mockMetaData.TenantID).
 					Return(nil)
 			},
 			expectError: nil,
 		},
+		{
+			name:     "success even if progress cannot be recorded",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), gomock.Any()).
+					Return(mockVector, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(errors.New("status error")).
+					Times(2)
+
+				expectStaleVectorsDeleted()
+
+				expectChecksumCommitted()
+
+				mockStatusAdapter.EXPECT().
+					SetDone(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name:           "error embed vector in a later batch",
+			metadata:       mockMetaData,
+			embedBatchSize: 4,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), mockContents[:4]).
+					Return(mockVector[:4], nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseEmbedding, 4, len(mockContents)}).
+					Return(nil)
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), mockContents[4:min(8, len(mockContents))]).
+					Return(nil, errors.New("some error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("some error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("some error"),
+		},
 		{
 			name:     "error set status done",
 			metadata: mockMetaData,
@@ -144,20 +432,360 @@ func UnitTestVectorizeAndStore(
 					SetInProgress(gomock.Any(), mockMetaData.TenantID).
 					Return(nil)
 
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
 				mockEmbedderAdapter.EXPECT().
 					EmbedBatch(gomock.Any(), gomock.Any()).
 					Return(mockVector, nil)
 
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseEmbedding, len(mockContents), len(mockContents)}).
+					Return(nil)
+
 				mockVectorStoreAdapter.EXPECT().
 					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
 					Return(nil)
 
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, progressMatcher{domains.IngestionPhaseUpserting, len(mockContents), len(mockContents)}).
+					Return(nil)
+
+				expectStaleVectorsDeleted()
+
+				expectChecksumCommitted()
+
 				mockStatusAdapter.EXPECT().
 					SetDone(gomock.Any(), mockMetaData.TenantID).
 					Return(errors.New("some error"))
 			},
 			expectError: errors.New("some error"),
 		},
+		{
+			name:     "success when workspace was deleted meanwhile",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), gomock.Any()).
+					Return(mockVector, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				expectStaleVectorsDeleted()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, nil)
+
+				mockStatusAdapter.EXPECT().
+					SetDone(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name:     "error get workspace on commit",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), gomock.Any()).
+					Return(mockVector, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				expectStaleVectorsDeleted()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, errors.New("some error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("some error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("some error"),
+		},
+		{
+			name:     "error commit checksum",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), gomock.Any()).
+					Return(mockVector, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				expectStaleVectorsDeleted()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(mockStoredWorkspace(), nil)
+
+				mockInternalDatabaseAdapter.EXPECT().
+					UpsertWorkspace(gomock.Any(), mockCommittedWorkspace).
+					Return(errors.New("some error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("some error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("some error"),
+		},
+		{
+			name:     "success incremental only re-embeds changed tables",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(mockPreviousWorkspace, nil)
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), mockChangedContents).
+					Return(mockChangedEmbeddings, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockChangedVectors).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				mockVectorStoreAdapter.EXPECT().
+					List(gomock.Any(), mockMetaData.TenantID).
+					Return(mockIncrementalStoredVectors, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					DeleteByIDs(gomock.Any(), mockMetaData.TenantID, []string{"legacy_vector", "outdated_vector"}).
+					Return(nil)
+
+				expectChecksumCommitted()
+
+				mockStatusAdapter.EXPECT().
+					SetDone(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+			},
+			expectError: nil,
+		},
+		{
+			name:     "error save schema version",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				mockInternalDatabaseAdapter.EXPECT().
+					SaveSchemaVersion(gomock.Any(), mockMetaData).
+					Return(nil, errors.New("database error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("database error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:     "error load previous checksums",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, errors.New("some error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("some error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("some error"),
+		},
+		{
+			name:     "error list stored vectors",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), gomock.Any()).
+					Return(mockVector, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				mockVectorStoreAdapter.EXPECT().
+					List(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, errors.New("some error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("some error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("some error"),
+		},
+		{
+			name:     "error list glossary on full ingestion",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, nil)
+
+				mockInternalDatabaseAdapter.EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, errors.New("database error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("database error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:     "error list examples on full ingestion",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, nil)
+
+				mockInternalDatabaseAdapter.EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(mockGlossaryTerms, nil)
+
+				mockInternalDatabaseAdapter.EXPECT().
+					ListExamplesByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(nil, errors.New("database error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("database error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:     "error delete vectors of a changed table",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				mockInternalDatabaseAdapter.EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockMetaData.TenantID).
+					Return(mockPreviousWorkspace, nil)
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), mockChangedContents).
+					Return(mockChangedEmbeddings, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					Upsert(gomock.Any(), mockMetaData.TenantID, mockChangedVectors).
+					Return(nil)
+
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				mockVectorStoreAdapter.EXPECT().
+					List(gomock.Any(), mockMetaData.TenantID).
+					Return(mockIncrementalStoredVectors, nil)
+
+				mockVectorStoreAdapter.EXPECT().
+					DeleteByIDs(gomock.Any(), mockMetaData.TenantID, []string{"legacy_vector", "outdated_vector"}).
+					Return(errors.New("some error"))
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, errors.New("some error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("some error"),
+		},
 		{
 			name:     "error upsert vector",
 			metadata: mockMetaData,
@@ -166,10 +794,18 @@ func UnitTestVectorizeAndStore(
 					SetInProgress(gomock.Any(), mockMetaData.TenantID).
 					Return(nil)
 
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
 				mockEmbedderAdapter.EXPECT().
 					EmbedBatch(gomock.Any(), gomock.Any()).
 					Return(mockVector, nil)
 
+				mockStatusAdapter.EXPECT().
+					SetProgress(gomock.Any(), mockMetaData.TenantID, gomock.Any()).
+					Return(nil)
+
 				mockVectorStoreAdapter.EXPECT().
 					Upsert(gomock.Any(), mockMetaData.TenantID, mockVectorEntities).
 					Return(errors.New("some error"))
@@ -188,6 +824,10 @@ func UnitTestVectorizeAndStore(
 					SetInProgress(gomock.Any(), mockMetaData.TenantID).
 					Return(nil)
 
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
 				mockEmbedderAdapter.EXPECT().
 					EmbedBatch(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("some error"))
@@ -198,6 +838,28 @@ func UnitTestVectorizeAndStore(
 			},
 			expectError: errors.New("some error"),
 		},
+		{
+			name:     "error embedder returns fewer embeddings than documents",
+			metadata: mockMetaData,
+			prepareMock: func() {
+				mockStatusAdapter.EXPECT().
+					SetInProgress(gomock.Any(), mockMetaData.TenantID).
+					Return(nil)
+
+				expectVersionSaved()
+
+				expectFullIngestionPlanned()
+
+				mockEmbedderAdapter.EXPECT().
+					EmbedBatch(gomock.Any(), gomock.Any()).
+					Return(mockVector[:len(mockVector)-1], nil)
+
+				mockStatusAdapter.EXPECT().
+					SetError(gomock.Any(), mockMetaData.TenantID, mockCountError.Error()).
+					Return(nil)
+			},
+			expectError: mockCountError,
+		},
 		{
 			name:     "error status in progress",
 			metadata: mockMetaData,
@@ -218,11 +880,15 @@ func UnitTestVectorizeAndStore(
 			mockEmbedderAdapter = mocks.NewMockEmbedderPort(ctrl)
 			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
 			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
 
 			svc := svcImp(
 				mockEmbedderAdapter,
 				mockVectorStoreAdapter,
 				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				tt.embedBatchSize,
+				tt.upsertBatchSize,
 			)
 
 			if tt.prepareMock != nil {
@@ -106,3 +106,33 @@ func (mr *MockClientDatabasePortMockRecorder) GetDatabaseMetadata(ctx interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseMetadata", reflect.TypeOf((*MockClientDatabasePort)(nil).GetDatabaseMetadata), ctx)
 }
+
+// ProfileColumn mocks base method.
+func (m *MockClientDatabasePort) ProfileColumn(ctx context.Context, table, column string, maxValues int) (*domains.ColumnProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfileColumn", ctx, table, column, maxValues)
+	ret0, _ := ret[0].(*domains.ColumnProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileColumn indicates an expected call of ProfileColumn.
+func (mr *MockClientDatabasePortMockRecorder) ProfileColumn(ctx, table, column, maxValues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileColumn", reflect.TypeOf((*MockClientDatabasePort)(nil).ProfileColumn), ctx, table, column, maxValues)
+}
+
+// SampleRows mocks base method.
+func (m *MockClientDatabasePort) SampleRows(ctx context.Context, table string, limit int) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SampleRows", ctx, table, limit)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SampleRows indicates an expected call of SampleRows.
+func (mr *MockClientDatabasePortMockRecorder) SampleRows(ctx, table, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SampleRows", reflect.TypeOf((*MockClientDatabasePort)(nil).SampleRows), ctx, table, limit)
+}
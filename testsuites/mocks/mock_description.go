@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/description.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// MockDescriptionService is a mock of DescriptionService interface.
+type MockDescriptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDescriptionServiceMockRecorder
+}
+
+// MockDescriptionServiceMockRecorder is the mock recorder for MockDescriptionService.
+type MockDescriptionServiceMockRecorder struct {
+	mock *MockDescriptionService
+}
+
+// NewMockDescriptionService creates a new mock instance.
+func NewMockDescriptionService(ctrl *gomock.Controller) *MockDescriptionService {
+	mock := &MockDescriptionService{ctrl: ctrl}
+	mock.recorder = &MockDescriptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDescriptionService) EXPECT() *MockDescriptionServiceMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockDescriptionService) Import(ctx context.Context, tenantID string, format domains.SchemaFormat, source string) ([]*domains.Description, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, tenantID, format, source)
+	ret0, _ := ret[0].([]*domains.Description)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockDescriptionServiceMockRecorder) Import(ctx, tenantID, format, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockDescriptionService)(nil).Import), ctx, tenantID, format, source)
+}
+
+// List mocks base method.
+func (m *MockDescriptionService) List(ctx context.Context, tenantID string) ([]*domains.Description, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.Description)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDescriptionServiceMockRecorder) List(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDescriptionService)(nil).List), ctx, tenantID)
+}
+
+// Override mocks base method.
+func (m *MockDescriptionService) Override(ctx context.Context, tenantID, table, column, text string) (*domains.Description, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Override", ctx, tenantID, table, column, text)
+	ret0, _ := ret[0].(*domains.Description)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Override indicates an expected call of Override.
+func (mr *MockDescriptionServiceMockRecorder) Override(ctx, tenantID, table, column, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Override", reflect.TypeOf((*MockDescriptionService)(nil).Override), ctx, tenantID, table, column, text)
+}
+
+// Reset mocks base method.
+func (m *MockDescriptionService) Reset(ctx context.Context, tenantID, table, column string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, tenantID, table, column)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockDescriptionServiceMockRecorder) Reset(ctx, tenantID, table, column interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockDescriptionService)(nil).Reset), ctx, tenantID, table, column)
+}
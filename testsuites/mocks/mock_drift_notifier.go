@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/drift_notifier.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// MockDriftNotifierPort is a mock of DriftNotifierPort interface.
+type MockDriftNotifierPort struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriftNotifierPortMockRecorder
+}
+
+// MockDriftNotifierPortMockRecorder is the mock recorder for MockDriftNotifierPort.
+type MockDriftNotifierPortMockRecorder struct {
+	mock *MockDriftNotifierPort
+}
+
+// NewMockDriftNotifierPort creates a new mock instance.
+func NewMockDriftNotifierPort(ctrl *gomock.Controller) *MockDriftNotifierPort {
+	mock := &MockDriftNotifierPort{ctrl: ctrl}
+	mock.recorder = &MockDriftNotifierPortMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriftNotifierPort) EXPECT() *MockDriftNotifierPortMockRecorder {
+	return m.recorder
+}
+
+// NotifyDrift mocks base method.
+func (m *MockDriftNotifierPort) NotifyDrift(ctx context.Context, event *domains.DriftEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyDrift", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyDrift indicates an expected call of NotifyDrift.
+func (mr *MockDriftNotifierPortMockRecorder) NotifyDrift(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyDrift", reflect.TypeOf((*MockDriftNotifierPort)(nil).NotifyDrift), ctx, event)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/embedding_cache.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmbeddingCachePort is a mock of EmbeddingCachePort interface.
+type MockEmbeddingCachePort struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmbeddingCachePortMockRecorder
+}
+
+// MockEmbeddingCachePortMockRecorder is the mock recorder for MockEmbeddingCachePort.
+type MockEmbeddingCachePortMockRecorder struct {
+	mock *MockEmbeddingCachePort
+}
+
+// NewMockEmbeddingCachePort creates a new mock instance.
+func NewMockEmbeddingCachePort(ctrl *gomock.Controller) *MockEmbeddingCachePort {
+	mock := &MockEmbeddingCachePort{ctrl: ctrl}
+	mock.recorder = &MockEmbeddingCachePortMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmbeddingCachePort) EXPECT() *MockEmbeddingCachePortMockRecorder {
+	return m.recorder
+}
+
+// GetMany mocks base method.
+func (m *MockEmbeddingCachePort) GetMany(ctx context.Context, keys []string) (map[string][]float32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[string][]float32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockEmbeddingCachePortMockRecorder) GetMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockEmbeddingCachePort)(nil).GetMany), ctx, keys)
+}
+
+// SetMany mocks base method.
+func (m *MockEmbeddingCachePort) SetMany(ctx context.Context, embeddings map[string][]float32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMany", ctx, embeddings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockEmbeddingCachePortMockRecorder) SetMany(ctx, embeddings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockEmbeddingCachePort)(nil).SetMany), ctx, embeddings)
+}
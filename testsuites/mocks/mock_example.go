@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/example.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// MockExampleService is a mock of ExampleService interface.
+type MockExampleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExampleServiceMockRecorder
+}
+
+// MockExampleServiceMockRecorder is the mock recorder for MockExampleService.
+type MockExampleServiceMockRecorder struct {
+	mock *MockExampleService
+}
+
+// NewMockExampleService creates a new mock instance.
+func NewMockExampleService(ctrl *gomock.Controller) *MockExampleService {
+	mock := &MockExampleService{ctrl: ctrl}
+	mock.recorder = &MockExampleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExampleService) EXPECT() *MockExampleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExampleService) Create(ctx context.Context, example *domains.Example) (*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, example)
+	ret0, _ := ret[0].(*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExampleServiceMockRecorder) Create(ctx, example interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExampleService)(nil).Create), ctx, example)
+}
+
+// Delete mocks base method.
+func (m *MockExampleService) Delete(ctx context.Context, tenantID string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExampleServiceMockRecorder) Delete(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExampleService)(nil).Delete), ctx, tenantID, id)
+}
+
+// Get mocks base method.
+func (m *MockExampleService) Get(ctx context.Context, tenantID string, id int64) (*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExampleServiceMockRecorder) Get(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExampleService)(nil).Get), ctx, tenantID, id)
+}
+
+// Import mocks base method.
+func (m *MockExampleService) Import(ctx context.Context, tenantID string, r io.Reader) ([]*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, tenantID, r)
+	ret0, _ := ret[0].([]*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockExampleServiceMockRecorder) Import(ctx, tenantID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockExampleService)(nil).Import), ctx, tenantID, r)
+}
+
+// List mocks base method.
+func (m *MockExampleService) List(ctx context.Context, tenantID string) ([]*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockExampleServiceMockRecorder) List(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockExampleService)(nil).List), ctx, tenantID)
+}
+
+// Promote mocks base method.
+func (m *MockExampleService) Promote(ctx context.Context, tenantID string, historyID int64, notes string) (*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Promote", ctx, tenantID, historyID, notes)
+	ret0, _ := ret[0].(*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Promote indicates an expected call of Promote.
+func (mr *MockExampleServiceMockRecorder) Promote(ctx, tenantID, historyID, notes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*MockExampleService)(nil).Promote), ctx, tenantID, historyID, notes)
+}
+
+// Update mocks base method.
+func (m *MockExampleService) Update(ctx context.Context, example *domains.Example) (*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, example)
+	ret0, _ := ret[0].(*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExampleServiceMockRecorder) Update(ctx, example interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExampleService)(nil).Update), ctx, example)
+}
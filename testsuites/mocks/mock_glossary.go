@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/glossary.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// MockGlossaryService is a mock of GlossaryService interface.
+type MockGlossaryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGlossaryServiceMockRecorder
+}
+
+// MockGlossaryServiceMockRecorder is the mock recorder for MockGlossaryService.
+type MockGlossaryServiceMockRecorder struct {
+	mock *MockGlossaryService
+}
+
+// NewMockGlossaryService creates a new mock instance.
+func NewMockGlossaryService(ctrl *gomock.Controller) *MockGlossaryService {
+	mock := &MockGlossaryService{ctrl: ctrl}
+	mock.recorder = &MockGlossaryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGlossaryService) EXPECT() *MockGlossaryServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGlossaryService) Create(ctx context.Context, term *domains.GlossaryTerm) (*domains.GlossaryTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, term)
+	ret0, _ := ret[0].(*domains.GlossaryTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGlossaryServiceMockRecorder) Create(ctx, term interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGlossaryService)(nil).Create), ctx, term)
+}
+
+// Delete mocks base method.
+func (m *MockGlossaryService) Delete(ctx context.Context, tenantID string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGlossaryServiceMockRecorder) Delete(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGlossaryService)(nil).Delete), ctx, tenantID, id)
+}
+
+// Get mocks base method.
+func (m *MockGlossaryService) Get(ctx context.Context, tenantID string, id int64) (*domains.GlossaryTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domains.GlossaryTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGlossaryServiceMockRecorder) Get(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGlossaryService)(nil).Get), ctx, tenantID, id)
+}
+
+// List mocks base method.
+func (m *MockGlossaryService) List(ctx context.Context, tenantID string) ([]*domains.GlossaryTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.GlossaryTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGlossaryServiceMockRecorder) List(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGlossaryService)(nil).List), ctx, tenantID)
+}
+
+// Update mocks base method.
+func (m *MockGlossaryService) Update(ctx context.Context, term *domains.GlossaryTerm) (*domains.GlossaryTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, term)
+	ret0, _ := ret[0].(*domains.GlossaryTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGlossaryServiceMockRecorder) Update(ctx, term interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGlossaryService)(nil).Update), ctx, term)
+}
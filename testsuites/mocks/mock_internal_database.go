@@ -35,6 +35,48 @@ func (m *MockInternalDatabasePort) EXPECT() *MockInternalDatabasePortMockRecorde
 	return m.recorder
 }
 
+// AppendQueryHistory mocks base method.
+func (m *MockInternalDatabasePort) AppendQueryHistory(ctx context.Context, entry *domains.QueryHistoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendQueryHistory", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendQueryHistory indicates an expected call of AppendQueryHistory.
+func (mr *MockInternalDatabasePortMockRecorder) AppendQueryHistory(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendQueryHistory", reflect.TypeOf((*MockInternalDatabasePort)(nil).AppendQueryHistory), ctx, entry)
+}
+
+// AppendStatusEvent mocks base method.
+func (m *MockInternalDatabasePort) AppendStatusEvent(ctx context.Context, event *domains.StatusEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendStatusEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendStatusEvent indicates an expected call of AppendStatusEvent.
+func (mr *MockInternalDatabasePortMockRecorder) AppendStatusEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendStatusEvent", reflect.TypeOf((*MockInternalDatabasePort)(nil).AppendStatusEvent), ctx, event)
+}
+
+// AppendStatusTransition mocks base method.
+func (m *MockInternalDatabasePort) AppendStatusTransition(ctx context.Context, event *domains.StatusEvent, status domains.WorkspaceStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendStatusTransition", ctx, event, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendStatusTransition indicates an expected call of AppendStatusTransition.
+func (mr *MockInternalDatabasePortMockRecorder) AppendStatusTransition(ctx, event, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendStatusTransition", reflect.TypeOf((*MockInternalDatabasePort)(nil).AppendStatusTransition), ctx, event, status)
+}
+
 // Close mocks base method.
 func (m *MockInternalDatabasePort) Close() error {
 	m.ctrl.T.Helper()
@@ -63,6 +105,160 @@ func (mr *MockInternalDatabasePortMockRecorder) Connect(ctx, dbURL interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockInternalDatabasePort)(nil).Connect), ctx, dbURL)
 }
 
+// DeleteDescription mocks base method.
+func (m *MockInternalDatabasePort) DeleteDescription(ctx context.Context, tenantID, table, column string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDescription", ctx, tenantID, table, column)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDescription indicates an expected call of DeleteDescription.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteDescription(ctx, tenantID, table, column interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDescription", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteDescription), ctx, tenantID, table, column)
+}
+
+// DeleteDescriptionsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteDescriptionsByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDescriptionsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDescriptionsByTenantID indicates an expected call of DeleteDescriptionsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteDescriptionsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDescriptionsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteDescriptionsByTenantID), ctx, tenantID)
+}
+
+// DeleteExample mocks base method.
+func (m *MockInternalDatabasePort) DeleteExample(ctx context.Context, tenantID string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExample", ctx, tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExample indicates an expected call of DeleteExample.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteExample(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExample", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteExample), ctx, tenantID, id)
+}
+
+// DeleteExamplesByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteExamplesByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExamplesByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExamplesByTenantID indicates an expected call of DeleteExamplesByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteExamplesByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExamplesByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteExamplesByTenantID), ctx, tenantID)
+}
+
+// DeleteGlossaryTerm mocks base method.
+func (m *MockInternalDatabasePort) DeleteGlossaryTerm(ctx context.Context, tenantID string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGlossaryTerm", ctx, tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGlossaryTerm indicates an expected call of DeleteGlossaryTerm.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteGlossaryTerm(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGlossaryTerm", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteGlossaryTerm), ctx, tenantID, id)
+}
+
+// DeleteGlossaryTermsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteGlossaryTermsByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGlossaryTermsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGlossaryTermsByTenantID indicates an expected call of DeleteGlossaryTermsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteGlossaryTermsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGlossaryTermsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteGlossaryTermsByTenantID), ctx, tenantID)
+}
+
+// DeleteQueryHistoryByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteQueryHistoryByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueryHistoryByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQueryHistoryByTenantID indicates an expected call of DeleteQueryHistoryByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteQueryHistoryByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueryHistoryByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteQueryHistoryByTenantID), ctx, tenantID)
+}
+
+// DeleteSchemaVersionsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteSchemaVersionsByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchemaVersionsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchemaVersionsByTenantID indicates an expected call of DeleteSchemaVersionsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteSchemaVersionsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchemaVersionsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteSchemaVersionsByTenantID), ctx, tenantID)
+}
+
+// DeleteSemanticModel mocks base method.
+func (m *MockInternalDatabasePort) DeleteSemanticModel(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSemanticModel", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSemanticModel indicates an expected call of DeleteSemanticModel.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteSemanticModel(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSemanticModel", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteSemanticModel), ctx, tenantID)
+}
+
+// DeleteStatusEventsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteStatusEventsByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStatusEventsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStatusEventsByTenantID indicates an expected call of DeleteStatusEventsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteStatusEventsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStatusEventsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteStatusEventsByTenantID), ctx, tenantID)
+}
+
+// DeleteTenantAliasesByTenantID mocks base method.
+func (m *MockInternalDatabasePort) DeleteTenantAliasesByTenantID(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTenantAliasesByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTenantAliasesByTenantID indicates an expected call of DeleteTenantAliasesByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) DeleteTenantAliasesByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenantAliasesByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteTenantAliasesByTenantID), ctx, tenantID)
+}
+
 // DeleteWorkspaceByTenantID mocks base method.
 func (m *MockInternalDatabasePort) DeleteWorkspaceByTenantID(ctx context.Context, tenantID string) error {
 	m.ctrl.T.Helper()
@@ -77,6 +273,111 @@ func (mr *MockInternalDatabasePortMockRecorder) DeleteWorkspaceByTenantID(ctx, t
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).DeleteWorkspaceByTenantID), ctx, tenantID)
 }
 
+// GetExample mocks base method.
+func (m *MockInternalDatabasePort) GetExample(ctx context.Context, tenantID string, id int64) (*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExample", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExample indicates an expected call of GetExample.
+func (mr *MockInternalDatabasePortMockRecorder) GetExample(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExample", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetExample), ctx, tenantID, id)
+}
+
+// GetGlossaryTerm mocks base method.
+func (m *MockInternalDatabasePort) GetGlossaryTerm(ctx context.Context, tenantID string, id int64) (*domains.GlossaryTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGlossaryTerm", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domains.GlossaryTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGlossaryTerm indicates an expected call of GetGlossaryTerm.
+func (mr *MockInternalDatabasePortMockRecorder) GetGlossaryTerm(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlossaryTerm", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetGlossaryTerm), ctx, tenantID, id)
+}
+
+// GetLatestStatusEventByTenantID mocks base method.
+func (m *MockInternalDatabasePort) GetLatestStatusEventByTenantID(ctx context.Context, tenantID string, eventTypes []domains.StatusEventType) (*domains.StatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestStatusEventByTenantID", ctx, tenantID, eventTypes)
+	ret0, _ := ret[0].(*domains.StatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestStatusEventByTenantID indicates an expected call of GetLatestStatusEventByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) GetLatestStatusEventByTenantID(ctx, tenantID, eventTypes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestStatusEventByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetLatestStatusEventByTenantID), ctx, tenantID, eventTypes)
+}
+
+// GetQueryHistoryEntry mocks base method.
+func (m *MockInternalDatabasePort) GetQueryHistoryEntry(ctx context.Context, tenantID string, id int64) (*domains.QueryHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryHistoryEntry", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domains.QueryHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryHistoryEntry indicates an expected call of GetQueryHistoryEntry.
+func (mr *MockInternalDatabasePortMockRecorder) GetQueryHistoryEntry(ctx, tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryHistoryEntry", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetQueryHistoryEntry), ctx, tenantID, id)
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockInternalDatabasePort) GetSchemaVersion(ctx context.Context, tenantID string, version int64) (*domains.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx, tenantID, version)
+	ret0, _ := ret[0].(*domains.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockInternalDatabasePortMockRecorder) GetSchemaVersion(ctx, tenantID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetSchemaVersion), ctx, tenantID, version)
+}
+
+// GetSemanticModel mocks base method.
+func (m *MockInternalDatabasePort) GetSemanticModel(ctx context.Context, tenantID string) (*domains.SemanticModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSemanticModel", ctx, tenantID)
+	ret0, _ := ret[0].(*domains.SemanticModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSemanticModel indicates an expected call of GetSemanticModel.
+func (mr *MockInternalDatabasePortMockRecorder) GetSemanticModel(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSemanticModel", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetSemanticModel), ctx, tenantID)
+}
+
+// GetTenantIDByAlias mocks base method.
+func (m *MockInternalDatabasePort) GetTenantIDByAlias(ctx context.Context, alias string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantIDByAlias", ctx, alias)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantIDByAlias indicates an expected call of GetTenantIDByAlias.
+func (mr *MockInternalDatabasePortMockRecorder) GetTenantIDByAlias(ctx, alias interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantIDByAlias", reflect.TypeOf((*MockInternalDatabasePort)(nil).GetTenantIDByAlias), ctx, alias)
+}
+
 // GetWorkspaceByTenantID mocks base method.
 func (m *MockInternalDatabasePort) GetWorkspaceByTenantID(ctx context.Context, tenantID string) (*domains.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -93,18 +394,208 @@ func (mr *MockInternalDatabasePortMockRecorder) GetWorkspaceByTenantID(ctx, tena
 }
 
 // ListAllWorkspaces mocks base method.
-func (m *MockInternalDatabasePort) ListAllWorkspaces(ctx context.Context) ([]*domains.Workspace, error) {
+func (m *MockInternalDatabasePort) ListAllWorkspaces(ctx context.Context, query *domains.WorkspaceQuery) (*domains.WorkspacePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllWorkspaces", ctx)
-	ret0, _ := ret[0].([]*domains.Workspace)
+	ret := m.ctrl.Call(m, "ListAllWorkspaces", ctx, query)
+	ret0, _ := ret[0].(*domains.WorkspacePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAllWorkspaces indicates an expected call of ListAllWorkspaces.
-func (mr *MockInternalDatabasePortMockRecorder) ListAllWorkspaces(ctx interface{}) *gomock.Call {
+func (mr *MockInternalDatabasePortMockRecorder) ListAllWorkspaces(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllWorkspaces", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListAllWorkspaces), ctx, query)
+}
+
+// ListDescriptionsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListDescriptionsByTenantID(ctx context.Context, tenantID string) ([]*domains.Description, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDescriptionsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.Description)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDescriptionsByTenantID indicates an expected call of ListDescriptionsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListDescriptionsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDescriptionsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListDescriptionsByTenantID), ctx, tenantID)
+}
+
+// ListExamplesByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListExamplesByTenantID(ctx context.Context, tenantID string) ([]*domains.Example, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExamplesByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.Example)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExamplesByTenantID indicates an expected call of ListExamplesByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListExamplesByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExamplesByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListExamplesByTenantID), ctx, tenantID)
+}
+
+// ListGlossaryTermsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListGlossaryTermsByTenantID(ctx context.Context, tenantID string) ([]*domains.GlossaryTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGlossaryTermsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.GlossaryTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGlossaryTermsByTenantID indicates an expected call of ListGlossaryTermsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListGlossaryTermsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGlossaryTermsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListGlossaryTermsByTenantID), ctx, tenantID)
+}
+
+// ListQueryHistoryByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListQueryHistoryByTenantID(ctx context.Context, tenantID string) ([]*domains.QueryHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueryHistoryByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.QueryHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueryHistoryByTenantID indicates an expected call of ListQueryHistoryByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListQueryHistoryByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryHistoryByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListQueryHistoryByTenantID), ctx, tenantID)
+}
+
+// ListSchemaVersionsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListSchemaVersionsByTenantID(ctx context.Context, tenantID string) ([]*domains.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchemaVersionsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchemaVersionsByTenantID indicates an expected call of ListSchemaVersionsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListSchemaVersionsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchemaVersionsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListSchemaVersionsByTenantID), ctx, tenantID)
+}
+
+// ListStatusEventsByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListStatusEventsByTenantID(ctx context.Context, tenantID string) ([]*domains.StatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusEventsByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.StatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusEventsByTenantID indicates an expected call of ListStatusEventsByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListStatusEventsByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusEventsByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListStatusEventsByTenantID), ctx, tenantID)
+}
+
+// ListTenantAliasesByTenantID mocks base method.
+func (m *MockInternalDatabasePort) ListTenantAliasesByTenantID(ctx context.Context, tenantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenantAliasesByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenantAliasesByTenantID indicates an expected call of ListTenantAliasesByTenantID.
+func (mr *MockInternalDatabasePortMockRecorder) ListTenantAliasesByTenantID(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenantAliasesByTenantID", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListTenantAliasesByTenantID), ctx, tenantID)
+}
+
+// SaveSchemaVersion mocks base method.
+func (m *MockInternalDatabasePort) SaveSchemaVersion(ctx context.Context, metadata *domains.DatabaseMetadata) (*domains.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSchemaVersion", ctx, metadata)
+	ret0, _ := ret[0].(*domains.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveSchemaVersion indicates an expected call of SaveSchemaVersion.
+func (mr *MockInternalDatabasePortMockRecorder) SaveSchemaVersion(ctx, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSchemaVersion", reflect.TypeOf((*MockInternalDatabasePort)(nil).SaveSchemaVersion), ctx, metadata)
+}
+
+// SaveTenantAlias mocks base method.
+func (m *MockInternalDatabasePort) SaveTenantAlias(ctx context.Context, alias, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTenantAlias", ctx, alias, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTenantAlias indicates an expected call of SaveTenantAlias.
+func (mr *MockInternalDatabasePortMockRecorder) SaveTenantAlias(ctx, alias, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTenantAlias", reflect.TypeOf((*MockInternalDatabasePort)(nil).SaveTenantAlias), ctx, alias, tenantID)
+}
+
+// UpsertDescription mocks base method.
+func (m *MockInternalDatabasePort) UpsertDescription(ctx context.Context, description *domains.Description) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDescription", ctx, description)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDescription indicates an expected call of UpsertDescription.
+func (mr *MockInternalDatabasePortMockRecorder) UpsertDescription(ctx, description interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDescription", reflect.TypeOf((*MockInternalDatabasePort)(nil).UpsertDescription), ctx, description)
+}
+
+// UpsertExample mocks base method.
+func (m *MockInternalDatabasePort) UpsertExample(ctx context.Context, example *domains.Example) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExample", ctx, example)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertExample indicates an expected call of UpsertExample.
+func (mr *MockInternalDatabasePortMockRecorder) UpsertExample(ctx, example interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExample", reflect.TypeOf((*MockInternalDatabasePort)(nil).UpsertExample), ctx, example)
+}
+
+// UpsertGlossaryTerm mocks base method.
+func (m *MockInternalDatabasePort) UpsertGlossaryTerm(ctx context.Context, term *domains.GlossaryTerm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertGlossaryTerm", ctx, term)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertGlossaryTerm indicates an expected call of UpsertGlossaryTerm.
+func (mr *MockInternalDatabasePortMockRecorder) UpsertGlossaryTerm(ctx, term interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGlossaryTerm", reflect.TypeOf((*MockInternalDatabasePort)(nil).UpsertGlossaryTerm), ctx, term)
+}
+
+// UpsertSemanticModel mocks base method.
+func (m *MockInternalDatabasePort) UpsertSemanticModel(ctx context.Context, semanticModel *domains.SemanticModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSemanticModel", ctx, semanticModel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSemanticModel indicates an expected call of UpsertSemanticModel.
+func (mr *MockInternalDatabasePortMockRecorder) UpsertSemanticModel(ctx, semanticModel interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllWorkspaces", reflect.TypeOf((*MockInternalDatabasePort)(nil).ListAllWorkspaces), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSemanticModel", reflect.TypeOf((*MockInternalDatabasePort)(nil).UpsertSemanticModel), ctx, semanticModel)
 }
 
 // UpsertWorkspace mocks base method.
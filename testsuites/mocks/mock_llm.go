@@ -35,10 +35,25 @@ func (m *MockLLMPort) EXPECT() *MockLLMPortMockRecorder {
 	return m.recorder
 }
 
+// DescribeTable mocks base method.
+func (m *MockLLMPort) DescribeTable(ctx context.Context, table domains.Table, relations []domains.Relation, sampleRows []map[string]any) (*domains.TableDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTable", ctx, table, relations, sampleRows)
+	ret0, _ := ret[0].(*domains.TableDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTable indicates an expected call of DescribeTable.
+func (mr *MockLLMPortMockRecorder) DescribeTable(ctx, table, relations, sampleRows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTable", reflect.TypeOf((*MockLLMPort)(nil).DescribeTable), ctx, table, relations, sampleRows)
+}
+
 // GenerateQuery mocks base method.
-func (m *MockLLMPort) GenerateQuery(ctx context.Context, prompt string, contexts []domains.Vector, additionalPrompts ...string) (*string, error) {
+func (m *MockLLMPort) GenerateQuery(ctx context.Context, prompt string, contexts []domains.Vector, examples []*domains.Example, additionalPrompts ...string) (*string, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, prompt, contexts}
+	varargs := []interface{}{ctx, prompt, contexts, examples}
 	for _, a := range additionalPrompts {
 		varargs = append(varargs, a)
 	}
@@ -49,8 +64,8 @@ func (m *MockLLMPort) GenerateQuery(ctx context.Context, prompt string, contexts
 }
 
 // GenerateQuery indicates an expected call of GenerateQuery.
-func (mr *MockLLMPortMockRecorder) GenerateQuery(ctx, prompt, contexts interface{}, additionalPrompts ...interface{}) *gomock.Call {
+func (mr *MockLLMPortMockRecorder) GenerateQuery(ctx, prompt, contexts, examples interface{}, additionalPrompts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, prompt, contexts}, additionalPrompts...)
+	varargs := append([]interface{}{ctx, prompt, contexts, examples}, additionalPrompts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateQuery", reflect.TypeOf((*MockLLMPort)(nil).GenerateQuery), varargs...)
 }
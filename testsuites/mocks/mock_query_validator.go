@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
 )
 
 // MockQueryValidatorPort is a mock of QueryValidatorPort interface.
@@ -61,3 +62,18 @@ func (mr *MockQueryValidatorPortMockRecorder) IsSafe(query interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSafe", reflect.TypeOf((*MockQueryValidatorPort)(nil).IsSafe), query)
 }
+
+// References mocks base method.
+func (m *MockQueryValidatorPort) References(query string) ([]domains.QueryReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "References", query)
+	ret0, _ := ret[0].([]domains.QueryReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// References indicates an expected call of References.
+func (mr *MockQueryValidatorPortMockRecorder) References(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "References", reflect.TypeOf((*MockQueryValidatorPort)(nil).References), query)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/scheduler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSchedulerService is a mock of SchedulerService interface.
+type MockSchedulerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerServiceMockRecorder
+}
+
+// MockSchedulerServiceMockRecorder is the mock recorder for MockSchedulerService.
+type MockSchedulerServiceMockRecorder struct {
+	mock *MockSchedulerService
+}
+
+// NewMockSchedulerService creates a new mock instance.
+func NewMockSchedulerService(ctrl *gomock.Controller) *MockSchedulerService {
+	mock := &MockSchedulerService{ctrl: ctrl}
+	mock.recorder = &MockSchedulerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulerService) EXPECT() *MockSchedulerServiceMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockSchedulerService) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockSchedulerServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSchedulerService)(nil).Run), ctx)
+}
+
+// SyncDue mocks base method.
+func (m *MockSchedulerService) SyncDue(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncDue", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncDue indicates an expected call of SyncDue.
+func (mr *MockSchedulerServiceMockRecorder) SyncDue(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncDue", reflect.TypeOf((*MockSchedulerService)(nil).SyncDue), ctx, now)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/schema_version.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// MockSchemaVersionService is a mock of SchemaVersionService interface.
+type MockSchemaVersionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaVersionServiceMockRecorder
+}
+
+// MockSchemaVersionServiceMockRecorder is the mock recorder for MockSchemaVersionService.
+type MockSchemaVersionServiceMockRecorder struct {
+	mock *MockSchemaVersionService
+}
+
+// NewMockSchemaVersionService creates a new mock instance.
+func NewMockSchemaVersionService(ctrl *gomock.Controller) *MockSchemaVersionService {
+	mock := &MockSchemaVersionService{ctrl: ctrl}
+	mock.recorder = &MockSchemaVersionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaVersionService) EXPECT() *MockSchemaVersionServiceMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockSchemaVersionService) Diff(ctx context.Context, tenantID string, from, to int64) (*domains.SchemaDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, tenantID, from, to)
+	ret0, _ := ret[0].(*domains.SchemaDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockSchemaVersionServiceMockRecorder) Diff(ctx, tenantID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockSchemaVersionService)(nil).Diff), ctx, tenantID, from, to)
+}
+
+// Get mocks base method.
+func (m *MockSchemaVersionService) Get(ctx context.Context, tenantID string, version int64) (*domains.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, version)
+	ret0, _ := ret[0].(*domains.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSchemaVersionServiceMockRecorder) Get(ctx, tenantID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSchemaVersionService)(nil).Get), ctx, tenantID, version)
+}
+
+// List mocks base method.
+func (m *MockSchemaVersionService) List(ctx context.Context, tenantID string) ([]*domains.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSchemaVersionServiceMockRecorder) List(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSchemaVersionService)(nil).List), ctx, tenantID)
+}
+
+// Rollback mocks base method.
+func (m *MockSchemaVersionService) Rollback(ctx context.Context, tenantID string, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, tenantID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockSchemaVersionServiceMockRecorder) Rollback(ctx, tenantID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockSchemaVersionService)(nil).Rollback), ctx, tenantID, version)
+}
+
+// Unpin mocks base method.
+func (m *MockSchemaVersionService) Unpin(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockSchemaVersionServiceMockRecorder) Unpin(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockSchemaVersionService)(nil).Unpin), ctx, tenantID)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/semantic_model.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
+)
+
+// MockSemanticModelService is a mock of SemanticModelService interface.
+type MockSemanticModelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSemanticModelServiceMockRecorder
+}
+
+// MockSemanticModelServiceMockRecorder is the mock recorder for MockSemanticModelService.
+type MockSemanticModelServiceMockRecorder struct {
+	mock *MockSemanticModelService
+}
+
+// NewMockSemanticModelService creates a new mock instance.
+func NewMockSemanticModelService(ctrl *gomock.Controller) *MockSemanticModelService {
+	mock := &MockSemanticModelService{ctrl: ctrl}
+	mock.recorder = &MockSemanticModelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSemanticModelService) EXPECT() *MockSemanticModelServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSemanticModelService) Delete(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSemanticModelServiceMockRecorder) Delete(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSemanticModelService)(nil).Delete), ctx, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockSemanticModelService) Get(ctx context.Context, tenantID string) (*domains.SemanticModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID)
+	ret0, _ := ret[0].(*domains.SemanticModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSemanticModelServiceMockRecorder) Get(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSemanticModelService)(nil).Get), ctx, tenantID)
+}
+
+// Put mocks base method.
+func (m *MockSemanticModelService) Put(ctx context.Context, tenantID string, definition []byte) (*domains.SemanticModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, tenantID, definition)
+	ret0, _ := ret[0].(*domains.SemanticModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockSemanticModelServiceMockRecorder) Put(ctx, tenantID, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockSemanticModelService)(nil).Put), ctx, tenantID, definition)
+}
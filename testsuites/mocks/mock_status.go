@@ -49,6 +49,36 @@ func (mr *MockStatusPortMockRecorder) Clear(ctx, tenantID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockStatusPort)(nil).Clear), ctx, tenantID)
 }
 
+// GetHistory mocks base method.
+func (m *MockStatusPort) GetHistory(ctx context.Context, tenantID string) ([]*domains.StatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, tenantID)
+	ret0, _ := ret[0].([]*domains.StatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockStatusPortMockRecorder) GetHistory(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockStatusPort)(nil).GetHistory), ctx, tenantID)
+}
+
+// GetProgress mocks base method.
+func (m *MockStatusPort) GetProgress(ctx context.Context, tenantID string) (*domains.IngestionProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", ctx, tenantID)
+	ret0, _ := ret[0].(*domains.IngestionProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *MockStatusPortMockRecorder) GetProgress(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*MockStatusPort)(nil).GetProgress), ctx, tenantID)
+}
+
 // GetStatus mocks base method.
 func (m *MockStatusPort) GetStatus(ctx context.Context, tenantID string) (domains.WorkspaceStatus, *string, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +137,20 @@ func (mr *MockStatusPortMockRecorder) SetInProgress(ctx, tenantID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInProgress", reflect.TypeOf((*MockStatusPort)(nil).SetInProgress), ctx, tenantID)
 }
 
+// SetProgress mocks base method.
+func (m *MockStatusPort) SetProgress(ctx context.Context, tenantID string, progress domains.IngestionProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProgress", ctx, tenantID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProgress indicates an expected call of SetProgress.
+func (mr *MockStatusPortMockRecorder) SetProgress(ctx, tenantID, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProgress", reflect.TypeOf((*MockStatusPort)(nil).SetProgress), ctx, tenantID, progress)
+}
+
 // SetWarn mocks base method.
 func (m *MockStatusPort) SetWarn(ctx context.Context, tenantID, message string) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	domains "github.com/kamil5b/go-nl2query-lib/domains"
 )
 
 // MockTaskQueuePort is a mock of TaskQueuePort interface.
@@ -34,6 +35,20 @@ func (m *MockTaskQueuePort) EXPECT() *MockTaskQueuePortMockRecorder {
 	return m.recorder
 }
 
+// CancelIngestionTasks mocks base method.
+func (m *MockTaskQueuePort) CancelIngestionTasks(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelIngestionTasks", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelIngestionTasks indicates an expected call of CancelIngestionTasks.
+func (mr *MockTaskQueuePortMockRecorder) CancelIngestionTasks(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelIngestionTasks", reflect.TypeOf((*MockTaskQueuePort)(nil).CancelIngestionTasks), ctx, tenantID)
+}
+
 // EnqueueIngestionTask mocks base method.
 func (m *MockTaskQueuePort) EnqueueIngestionTask(ctx context.Context, tenantID, dbURL string) error {
 	m.ctrl.T.Helper()
@@ -47,3 +62,17 @@ func (mr *MockTaskQueuePortMockRecorder) EnqueueIngestionTask(ctx, tenantID, dbU
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueIngestionTask", reflect.TypeOf((*MockTaskQueuePort)(nil).EnqueueIngestionTask), ctx, tenantID, dbURL)
 }
+
+// EnqueueSchemaIngestionTask mocks base method.
+func (m *MockTaskQueuePort) EnqueueSchemaIngestionTask(ctx context.Context, metadata *domains.DatabaseMetadata) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueSchemaIngestionTask", ctx, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueSchemaIngestionTask indicates an expected call of EnqueueSchemaIngestionTask.
+func (mr *MockTaskQueuePortMockRecorder) EnqueueSchemaIngestionTask(ctx, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueSchemaIngestionTask", reflect.TypeOf((*MockTaskQueuePort)(nil).EnqueueSchemaIngestionTask), ctx, metadata)
+}
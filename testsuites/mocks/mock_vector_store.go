@@ -49,6 +49,34 @@ func (mr *MockVectorStorePortMockRecorder) Delete(ctx, tenantID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVectorStorePort)(nil).Delete), ctx, tenantID)
 }
 
+// DeleteByFilter mocks base method.
+func (m *MockVectorStorePort) DeleteByFilter(ctx context.Context, tenantID string, filter map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByFilter", ctx, tenantID, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByFilter indicates an expected call of DeleteByFilter.
+func (mr *MockVectorStorePortMockRecorder) DeleteByFilter(ctx, tenantID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFilter", reflect.TypeOf((*MockVectorStorePort)(nil).DeleteByFilter), ctx, tenantID, filter)
+}
+
+// DeleteByIDs mocks base method.
+func (m *MockVectorStorePort) DeleteByIDs(ctx context.Context, tenantID string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByIDs", ctx, tenantID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByIDs indicates an expected call of DeleteByIDs.
+func (mr *MockVectorStorePortMockRecorder) DeleteByIDs(ctx, tenantID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByIDs", reflect.TypeOf((*MockVectorStorePort)(nil).DeleteByIDs), ctx, tenantID, ids)
+}
+
 // Exists mocks base method.
 func (m *MockVectorStorePort) Exists(ctx context.Context, tenantID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +92,21 @@ func (mr *MockVectorStorePortMockRecorder) Exists(ctx, tenantID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockVectorStorePort)(nil).Exists), ctx, tenantID)
 }
 
+// List mocks base method.
+func (m *MockVectorStorePort) List(ctx context.Context, tenantID string) ([]domains.Vector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID)
+	ret0, _ := ret[0].([]domains.Vector)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockVectorStorePortMockRecorder) List(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVectorStorePort)(nil).List), ctx, tenantID)
+}
+
 // Search mocks base method.
 func (m *MockVectorStorePort) Search(ctx context.Context, tenantID string, queryEmbedding []float32, limit int) ([]domains.Vector, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +122,21 @@ func (mr *MockVectorStorePortMockRecorder) Search(ctx, tenantID, queryEmbedding,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockVectorStorePort)(nil).Search), ctx, tenantID, queryEmbedding, limit)
 }
 
+// SearchByFilter mocks base method.
+func (m *MockVectorStorePort) SearchByFilter(ctx context.Context, tenantID string, queryEmbedding []float32, filter map[string]string, limit int) ([]domains.Vector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByFilter", ctx, tenantID, queryEmbedding, filter, limit)
+	ret0, _ := ret[0].([]domains.Vector)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByFilter indicates an expected call of SearchByFilter.
+func (mr *MockVectorStorePortMockRecorder) SearchByFilter(ctx, tenantID, queryEmbedding, filter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByFilter", reflect.TypeOf((*MockVectorStorePort)(nil).SearchByFilter), ctx, tenantID, queryEmbedding, filter, limit)
+}
+
 // Upsert mocks base method.
 func (m *MockVectorStorePort) Upsert(ctx context.Context, tenantID string, vectors []domains.Vector) error {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domains "github.com/kamil5b/go-nl2query-lib/domains"
@@ -35,6 +36,21 @@ func (m *MockWorkspaceService) EXPECT() *MockWorkspaceServiceMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockWorkspaceService) Create(ctx context.Context, definition *domains.WorkspaceDefinition) (*domains.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, definition)
+	ret0, _ := ret[0].(*domains.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceServiceMockRecorder) Create(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceService)(nil).Create), ctx, definition)
+}
+
 // Delete mocks base method.
 func (m *MockWorkspaceService) Delete(ctx context.Context, tenantID string) error {
 	m.ctrl.T.Helper()
@@ -64,26 +80,85 @@ func (mr *MockWorkspaceServiceMockRecorder) GetByTenantID(ctx, tenantID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTenantID", reflect.TypeOf((*MockWorkspaceService)(nil).GetByTenantID), ctx, tenantID)
 }
 
+// ImportSchema mocks base method.
+func (m *MockWorkspaceService) ImportSchema(ctx context.Context, name string, format domains.SchemaFormat, source string) (*domains.SyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSchema", ctx, name, format, source)
+	ret0, _ := ret[0].(*domains.SyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSchema indicates an expected call of ImportSchema.
+func (mr *MockWorkspaceServiceMockRecorder) ImportSchema(ctx, name, format, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSchema", reflect.TypeOf((*MockWorkspaceService)(nil).ImportSchema), ctx, name, format, source)
+}
+
 // ListAll mocks base method.
-func (m *MockWorkspaceService) ListAll(ctx context.Context) ([]*domains.Workspace, error) {
+func (m *MockWorkspaceService) ListAll(ctx context.Context, query *domains.WorkspaceQuery) (*domains.WorkspacePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAll", ctx)
-	ret0, _ := ret[0].([]*domains.Workspace)
+	ret := m.ctrl.Call(m, "ListAll", ctx, query)
+	ret0, _ := ret[0].(*domains.WorkspacePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAll indicates an expected call of ListAll.
-func (mr *MockWorkspaceServiceMockRecorder) ListAll(ctx interface{}) *gomock.Call {
+func (mr *MockWorkspaceServiceMockRecorder) ListAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockWorkspaceService)(nil).ListAll), ctx, query)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockWorkspaceService) PurgeExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockWorkspaceServiceMockRecorder) PurgeExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockWorkspaceService)(nil).PurgeExpired), ctx, now)
+}
+
+// Restore mocks base method.
+func (m *MockWorkspaceService) Restore(ctx context.Context, tenantID string) (*domains.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, tenantID)
+	ret0, _ := ret[0].(*domains.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockWorkspaceServiceMockRecorder) Restore(ctx, tenantID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockWorkspaceService)(nil).ListAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockWorkspaceService)(nil).Restore), ctx, tenantID)
+}
+
+// RotateDBURL mocks base method.
+func (m *MockWorkspaceService) RotateDBURL(ctx context.Context, tenantID, dbUrl string) (*domains.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateDBURL", ctx, tenantID, dbUrl)
+	ret0, _ := ret[0].(*domains.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateDBURL indicates an expected call of RotateDBURL.
+func (mr *MockWorkspaceServiceMockRecorder) RotateDBURL(ctx, tenantID, dbUrl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateDBURL", reflect.TypeOf((*MockWorkspaceService)(nil).RotateDBURL), ctx, tenantID, dbUrl)
 }
 
 // SyncClientDatabase mocks base method.
-func (m *MockWorkspaceService) SyncClientDatabase(ctx context.Context, dbUrl string) (*domains.DatabaseMetadata, *string, error) {
+func (m *MockWorkspaceService) SyncClientDatabase(ctx context.Context, dbUrl string) (*domains.SyncReport, *string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SyncClientDatabase", ctx, dbUrl)
-	ret0, _ := ret[0].(*domains.DatabaseMetadata)
+	ret0, _ := ret[0].(*domains.SyncReport)
 	ret1, _ := ret[1].(*string)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
@@ -94,3 +169,64 @@ func (mr *MockWorkspaceServiceMockRecorder) SyncClientDatabase(ctx, dbUrl interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncClientDatabase", reflect.TypeOf((*MockWorkspaceService)(nil).SyncClientDatabase), ctx, dbUrl)
 }
+
+// SyncWorkspace mocks base method.
+func (m *MockWorkspaceService) SyncWorkspace(ctx context.Context, tenantID string) (*domains.SyncReport, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncWorkspace", ctx, tenantID)
+	ret0, _ := ret[0].(*domains.SyncReport)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SyncWorkspace indicates an expected call of SyncWorkspace.
+func (mr *MockWorkspaceServiceMockRecorder) SyncWorkspace(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncWorkspace", reflect.TypeOf((*MockWorkspaceService)(nil).SyncWorkspace), ctx, tenantID)
+}
+
+// Update mocks base method.
+func (m *MockWorkspaceService) Update(ctx context.Context, definition *domains.WorkspaceDefinition) (*domains.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, definition)
+	ret0, _ := ret[0].(*domains.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWorkspaceServiceMockRecorder) Update(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWorkspaceService)(nil).Update), ctx, definition)
+}
+
+// UpdateSyncOptions mocks base method.
+func (m *MockWorkspaceService) UpdateSyncOptions(ctx context.Context, tenantID string, options *domains.SyncOptions) (*domains.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncOptions", ctx, tenantID, options)
+	ret0, _ := ret[0].(*domains.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSyncOptions indicates an expected call of UpdateSyncOptions.
+func (mr *MockWorkspaceServiceMockRecorder) UpdateSyncOptions(ctx, tenantID, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncOptions", reflect.TypeOf((*MockWorkspaceService)(nil).UpdateSyncOptions), ctx, tenantID, options)
+}
+
+// UpdateSyncSchedule mocks base method.
+func (m *MockWorkspaceService) UpdateSyncSchedule(ctx context.Context, tenantID, schedule string) (*domains.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncSchedule", ctx, tenantID, schedule)
+	ret0, _ := ret[0].(*domains.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSyncSchedule indicates an expected call of UpdateSyncSchedule.
+func (mr *MockWorkspaceServiceMockRecorder) UpdateSyncSchedule(ctx, tenantID, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncSchedule", reflect.TypeOf((*MockWorkspaceService)(nil).UpdateSyncSchedule), ctx, tenantID, schedule)
+}
@@ -3,7 +3,9 @@ package query
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/kamil5b/go-nl2query-lib/domains"
@@ -12,6 +14,35 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// queryHistoryMatcher matches the recorded entry, ignoring its warning and
+// timestamp.
+type queryHistoryMatcher struct {
+	tenantID string
+	prompt   string
+	query    *string
+}
+
+func (m queryHistoryMatcher) Matches(x interface{}) bool {
+	entry, ok := x.(*domains.QueryHistoryEntry)
+	if !ok {
+		return false
+	}
+	return entry.TenantID == m.tenantID &&
+		entry.Prompt == m.prompt &&
+		entry.Query == valueOf(m.query)
+}
+
+func (m queryHistoryMatcher) String() string {
+	return fmt.Sprintf("query history of %s for %q", m.tenantID, m.prompt)
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 func UnitTestPromptToQueryData(
 	t *testing.T,
 	svcImp func(
@@ -25,6 +56,7 @@ func UnitTestPromptToQueryData(
 		QueryValidator ports.QueryValidatorPort,
 		queryErrorLimit int,
 		executionErrorLimit int,
+		maxExamples int,
 	) ports.QueryService,
 ) {
 	var (
@@ -47,12 +79,17 @@ func UnitTestPromptToQueryData(
 		TenantID:       mockTenantID,
 		EncryptedDBURL: mockEncryptedDBUrl,
 	}
+	// A workspace imported from a schema script has no database to connect to
+	mockSchemaOnlyWorkspace := &domains.Workspace{
+		TenantID: mockTenantID,
+	}
 	mockQueryResultErrSyntax := "```sql SELECT * FROM table; ```"
 	mockQueryResultErr := "SELECT * FROM table;"
 	mockQueryResult := "SELECT * FROM tables;"
 
 	mockQueryErrorLimit := 2
 	mockExecutionErrorLimit := 2
+	mockMaxExamples := 2
 	mockVector := []float32{0.1, 0.2, 0.3}
 	mockVectorEntity := []domains.Vector{
 		{
@@ -61,6 +98,82 @@ func UnitTestPromptToQueryData(
 			Content:   mockContent,
 		},
 	}
+	// The search already found the first term; the second is appended
+	mockGlossaryTerms := []*domains.GlossaryTerm{
+		{ID: 1, TenantID: mockTenantID, Term: "GMV", Definition: "sum(order_items.price*qty) excluding refunds"},
+		{ID: 2, TenantID: mockTenantID, Term: "client", Definition: "a row of the customers table"},
+	}
+	mockSearchedWithGlossary := append([]domains.Vector{}, mockVectorEntity...)
+	mockSearchedWithGlossary = append(mockSearchedWithGlossary, domains.GlossaryDocument(mockGlossaryTerms[0]))
+	mockVectorEntityWithGlossary := append([]domains.Vector{}, mockSearchedWithGlossary...)
+	mockVectorEntityWithGlossary = append(mockVectorEntityWithGlossary, domains.GlossaryDocument(mockGlossaryTerms[1]))
+	// Examples are searched apart from the schema context and given to the
+	// LLM as few-shot pairs, most similar first
+	var mockNoExamples []*domains.Example
+	mockExampleFilter := map[string]string{
+		domains.VectorMetadataKind: string(domains.DocumentKindExample),
+	}
+	mockExamples := []*domains.Example{
+		{ID: 1, TenantID: mockTenantID, Question: "How many orders?", Query: "SELECT count(*) FROM orders"},
+		{ID: 2, TenantID: mockTenantID, Question: "Top clients", Query: "SELECT name FROM customers LIMIT 10"},
+	}
+	mockExampleHits := []domains.Vector{
+		domains.ExampleDocument(mockExamples[1]),
+		domains.ExampleDocument(mockExamples[0]),
+	}
+	mockSearchedWithExample := append([]domains.Vector{}, mockVectorEntity...)
+	mockSearchedWithExample = append(mockSearchedWithExample, domains.ExampleDocument(mockExamples[0]))
+	// A prompt naming a metric of the semantic model gets its definition, and
+	// the query must compute it with the canonical expression
+	mockMetricPrompt := "total sales by region"
+	mockSemanticModel := &domains.SemanticModel{
+		TenantID: mockTenantID,
+		Metrics: []domains.Metric{
+			{Name: "revenue", Expression: "SUM(order_items.price * order_items.qty)", Table: "order_items", Synonyms: []string{"sales"}},
+			{Name: "order_count", Expression: "COUNT(DISTINCT orders.id)", Table: "orders"},
+		},
+		Dimensions: []domains.Dimension{{Name: "region", Expression: "customers.region", Table: "customers"}},
+	}
+	mockVectorEntityWithDefinitions := append([]domains.Vector{}, mockVectorEntity...)
+	mockVectorEntityWithDefinitions = append(mockVectorEntityWithDefinitions, mockSemanticModel.Relevant(mockMetricPrompt).Documents()...)
+	mockImprovisedQuery := "SELECT SUM(order_items.price) FROM order_items"
+	mockCanonicalQuery := "SELECT customers.region, SUM(order_items.price*order_items.qty) FROM order_items JOIN orders ON order_items.order_id = orders.id JOIN customers ON orders.customer_id = customers.id GROUP BY customers.region"
+	mockMetricFix := "metric revenue must be computed as SUM(order_items.price * order_items.qty) over order_items"
+	// A workspace syncing without sensitive columns holds queries to the
+	// same options
+	mockFilteredWorkspace := &domains.Workspace{
+		TenantID:       mockTenantID,
+		EncryptedDBURL: mockEncryptedDBUrl,
+		SyncOptions:    &domains.SyncOptions{SensitiveColumns: []string{"customers.email"}},
+	}
+	mockSensitiveQuery := "SELECT c.email FROM customers c"
+	mockSensitiveReferences := []domains.QueryReference{{Table: "customers"}, {Table: "customers", Column: "email"}}
+	mockAllowedQuery := "SELECT c.id FROM customers c"
+	mockAllowedReferences := []domains.QueryReference{{Table: "customers"}, {Table: "customers", Column: "id"}}
+	mockSensitiveFix := "column customers.email is sensitive and must not be read"
+	// A named workspace runs each query on the data source holding its tables
+	mockNamedWorkspace := &domains.Workspace{
+		TenantID:      mockTenantID,
+		Name:          "Sales",
+		ActiveVersion: 3,
+		DataSources: []domains.DataSource{
+			{Name: "oltp", EncryptedDBURL: "encrypted_oltp"},
+			{Name: "reporting", EncryptedDBURL: "encrypted_reporting"},
+		},
+	}
+	mockNamedVersion := &domains.SchemaVersion{
+		TenantID: mockTenantID,
+		Version:  3,
+		Metadata: &domains.DatabaseMetadata{Tables: []domains.Table{
+			{Name: "orders", Source: "oltp"},
+			{Name: "daily_revenue", Source: "reporting"},
+		}},
+	}
+	mockReportingURL := "https://reporting.url/sales"
+	mockReportingQuery := "SELECT day, revenue FROM daily_revenue"
+	mockCrossSourceQuery := "SELECT o.day, r.revenue FROM orders o JOIN daily_revenue r ON r.day = o.day"
+	mockCrossSourceReferences := []domains.QueryReference{{Table: "orders"}, {Table: "daily_revenue"}}
+	mockCrossSourceFix := "query reads tables of several data sources: oltp (orders), reporting (daily_revenue)"
 	constToWarn := func(msg string) *string {
 		return &msg
 	}
@@ -70,13 +183,15 @@ func UnitTestPromptToQueryData(
 	}
 
 	tests := []struct {
-		name             string
-		prepareMock      func()
-		isReturningQuery *string
-		isReturningData  map[string]any
-		withData         bool
-		warnMessage      *string
-		expectError      error
+		name               string
+		prompt             string
+		prepareMock        func()
+		isReturningQuery   *string
+		isReturningData    map[string]any
+		withData           bool
+		warnMessage        *string
+		recordHistoryError error
+		expectError        error
 	}{
 		{
 			name:             "success with data and full route with loops",
@@ -112,12 +227,24 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
 
 				// Outer loop iteration 0
 				// Inner loop iteration 0 - syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -127,7 +254,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 1 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -137,7 +264,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 2 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -147,7 +274,7 @@ func UnitTestPromptToQueryData(
 				// After inner loop - generate with accumulated errors
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErr, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -166,7 +293,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 0 - syntax error with execution error from previous attempt
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -176,7 +303,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 1 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -186,7 +313,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 2 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -196,7 +323,7 @@ func UnitTestPromptToQueryData(
 				// After inner loop - generate with accumulated errors
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -247,12 +374,24 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
 
 				// Outer loop iteration 0
 				// Inner loop iteration 0 - syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -262,7 +401,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 1 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -272,7 +411,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 2 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -282,7 +421,7 @@ func UnitTestPromptToQueryData(
 				// After inner loop - generate with accumulated errors
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErr, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -301,7 +440,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 0 - syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -311,7 +450,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 1 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -321,7 +460,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 2 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -331,7 +470,7 @@ func UnitTestPromptToQueryData(
 				// After inner loop - generate with accumulated errors (this will be the final query returned)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -374,12 +513,24 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
 
 				// Outer loop iteration 0
 				// Inner loop iteration 0 - syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -389,7 +540,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 1 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -399,7 +550,7 @@ func UnitTestPromptToQueryData(
 				// Inner loop iteration 2 - still syntax error
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -409,7 +560,7 @@ func UnitTestPromptToQueryData(
 				// After inner loop - generate with accumulated errors
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, gomock.Any()).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, gomock.Any()).
 					Return(&mockQueryResultErrSyntax, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -452,9 +603,21 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -462,7 +625,7 @@ func UnitTestPromptToQueryData(
 					Return(true, nil)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -509,9 +672,21 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -519,7 +694,7 @@ func UnitTestPromptToQueryData(
 					Return(true, nil)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -529,9 +704,10 @@ func UnitTestPromptToQueryData(
 			expectError: nil,
 		},
 		{
-			name:             "success with data and full route with loops but expected no data",
-			withData:         false,
+			name:             "success on a schema-only workspace without connecting",
+			withData:         true,
 			isReturningQuery: &mockQueryResult,
+			warnMessage:      constToWarn(ports.QueryServiceWarnWontExecuteClientDatabaseError),
 			prepareMock: func() {
 				mockStatusAdapter.
 					EXPECT().
@@ -544,7 +720,7 @@ func UnitTestPromptToQueryData(
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
-					Return(mockWorkspace, nil)
+					Return(mockSchemaOnlyWorkspace, nil)
 				mockEmbedderAdapter.
 					EXPECT().
 					Embed(gomock.Any(), mockString).
@@ -553,10 +729,21 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
-
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -564,7 +751,7 @@ func UnitTestPromptToQueryData(
 					Return(true, nil)
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
 					Return(&mockQueryResult, nil)
 				mockQueryValidatorAdapter.
 					EXPECT().
@@ -574,30 +761,11 @@ func UnitTestPromptToQueryData(
 			expectError: nil,
 		},
 		{
-			name:     "error status in progress",
-			withData: false,
-			prepareMock: func() {
-				mockStatusAdapter.
-					EXPECT().
-					GetStatus(gomock.Any(), mockTenantID).
-					Return(domains.StatusInProgress, nil, nil)
-			},
-			expectError: ports.StatusInProgressError,
-		},
-		{
-			name:     "err get status",
-			withData: false,
-			prepareMock: func() {
-				mockStatusAdapter.
-					EXPECT().
-					GetStatus(gomock.Any(), mockTenantID).
-					Return(domains.StatusError, nil, errors.New("err"))
-			},
-			expectError: errors.New("err"),
-		},
-		{
-			name:     "err connect internal DB",
-			withData: false,
+			name:               "success even if history cannot be recorded",
+			withData:           true,
+			isReturningQuery:   &mockQueryResult,
+			warnMessage:        constToWarn(ports.QueryServiceWarnWontExecuteClientDatabaseError),
+			recordHistoryError: errors.New("database error"),
 			prepareMock: func() {
 				mockStatusAdapter.
 					EXPECT().
@@ -606,32 +774,62 @@ func UnitTestPromptToQueryData(
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					Connect(gomock.Any(), mockTenantID).
-					Return(errors.New("err"))
-			},
-			expectError: errors.New("err"),
-		},
-		{
-			name:     "err executing internal DB get workspace",
-			withData: false,
-			prepareMock: func() {
-				mockStatusAdapter.
+					Return(nil)
+				mockInternalDatabaseAdapter.
 					EXPECT().
-					GetStatus(gomock.Any(), mockTenantID).
-					Return(domains.StatusDone, nil, nil)
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt(mockEncryptedDBUrl).
+					Return(mockURL, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockURL).
+					Return(errors.New("connection error"))
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), mockTenantID).
-					Return(nil)
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
-					Return(nil, errors.New("err"))
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockQueryResult, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockQueryResult, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil)
 			},
-			expectError: errors.New("err"),
+			expectError: nil,
 		},
 		{
-			name:     "err decrypt database URL",
-			withData: true,
+			name:             "success with data and full route with loops but expected no data",
+			withData:         false,
+			isReturningQuery: &mockQueryResult,
 			prepareMock: func() {
 				mockStatusAdapter.
 					EXPECT().
@@ -645,16 +843,50 @@ func UnitTestPromptToQueryData(
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
 					Return(mockWorkspace, nil)
-				mockEncryptAdapter.
+				mockEmbedderAdapter.
 					EXPECT().
-					Decrypt(mockEncryptedDBUrl).
-					Return("", errors.New("err"))
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockQueryResult, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockQueryResult, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil)
 			},
-			expectError: errors.New("err"),
+			expectError: nil,
 		},
 		{
-			name:     "err embed prompt",
-			withData: false,
+			name:             "success with glossary terms the search missed",
+			withData:         false,
+			isReturningQuery: &mockQueryResult,
 			prepareMock: func() {
 				mockStatusAdapter.
 					EXPECT().
@@ -671,13 +903,47 @@ func UnitTestPromptToQueryData(
 				mockEmbedderAdapter.
 					EXPECT().
 					Embed(gomock.Any(), mockString).
-					Return(nil, errors.New("err"))
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockSearchedWithGlossary, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockGlossaryTerms, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntityWithGlossary, mockNoExamples).
+					Return(&mockQueryResult, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntityWithGlossary, mockNoExamples).
+					Return(&mockQueryResult, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil)
 			},
-			expectError: errors.New("err"),
+			expectError: nil,
 		},
 		{
-			name:     "err vector store search",
-			withData: false,
+			name:             "success with similar examples as few-shot pairs",
+			withData:         false,
+			isReturningQuery: &mockQueryResult,
 			prepareMock: func() {
 				mockStatusAdapter.
 					EXPECT().
@@ -698,13 +964,42 @@ func UnitTestPromptToQueryData(
 				mockVectorStoreAdapter.
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
-					Return(nil, errors.New("err"))
+					Return(mockSearchedWithExample, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(mockExampleHits, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListExamplesByTenantID(gomock.Any(), mockTenantID).
+					Return(mockExamples, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, []*domains.Example{mockExamples[1], mockExamples[0]}).
+					Return(&mockQueryResult, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockQueryResult).
+					Return(true, nil).
+					Times(2)
 			},
-			expectError: errors.New("err"),
+			expectError: nil,
 		},
 		{
-			name:     "err generate query initial",
-			withData: false,
+			name:             "success with the metric fixed to its canonical expression",
+			prompt:           mockMetricPrompt,
+			withData:         false,
+			isReturningQuery: &mockCanonicalQuery,
 			prepareMock: func() {
 				mockStatusAdapter.
 					EXPECT().
@@ -720,21 +1015,740 @@ func UnitTestPromptToQueryData(
 					Return(mockWorkspace, nil)
 				mockEmbedderAdapter.
 					EXPECT().
-					Embed(gomock.Any(), mockString).
+					Embed(gomock.Any(), mockMetricPrompt).
 					Return(mockVector, nil)
 				mockVectorStoreAdapter.
 					EXPECT().
 					Search(gomock.Any(), mockTenantID, mockVector, 10).
 					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(mockSemanticModel, nil)
+
+				// Inner loop iteration 0 - metric improvised
 				mockLLMAdapter.
 					EXPECT().
-					GenerateQuery(gomock.Any(), mockString, mockVectorEntity).
-					Return(nil, errors.New("err"))
+					GenerateQuery(gomock.Any(), mockMetricPrompt, mockVectorEntityWithDefinitions, mockNoExamples).
+					Return(&mockImprovisedQuery, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockImprovisedQuery).
+					Return(true, nil)
+				// Inner loop iteration 1 - canonical expression
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockMetricPrompt, mockVectorEntityWithDefinitions, mockNoExamples, mockImprovisedQuery, mockMetricFix).
+					Return(&mockCanonicalQuery, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockCanonicalQuery).
+					Return(true, nil).
+					Times(2)
 			},
-			expectError: errors.New("err"),
+			expectError: nil,
 		},
-	}
-
+		{
+			name:             "success with warn because metric keeps being improvised",
+			prompt:           mockMetricPrompt,
+			withData:         false,
+			isReturningQuery: &mockImprovisedQuery,
+			warnMessage:      constToWarn(ports.QueryServiceWarnMetricNotCanonical),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockMetricPrompt).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(mockSemanticModel, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockMetricPrompt, mockVectorEntityWithDefinitions, mockNoExamples).
+					Return(&mockImprovisedQuery, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockMetricPrompt, mockVectorEntityWithDefinitions, mockNoExamples, mockImprovisedQuery, mockMetricFix).
+					Return(&mockImprovisedQuery, nil).
+					Times(mockQueryErrorLimit + 1)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockImprovisedQuery).
+					Return(true, nil).
+					Times(mockQueryErrorLimit + 2)
+			},
+			expectError: nil,
+		},
+		{
+			name:             "success with the sensitive column fixed before execution",
+			withData:         true,
+			isReturningQuery: &mockAllowedQuery,
+			isReturningData:  dataResult,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockFilteredWorkspace, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt(mockEncryptedDBUrl).
+					Return(mockURL, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockURL).
+					Return(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+
+				// Inner loop iteration 0 - sensitive column read
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockSensitiveQuery, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockSensitiveQuery).
+					Return(true, nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					References(mockSensitiveQuery).
+					Return(mockSensitiveReferences, nil)
+				// Inner loop iteration 1 - allowed columns only
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, mockSensitiveQuery, mockSensitiveFix).
+					Return(&mockAllowedQuery, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockAllowedQuery).
+					Return(true, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					References(mockAllowedQuery).
+					Return(mockAllowedReferences, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					ContainsDDLDML(mockAllowedQuery).
+					Return(false)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Execute(gomock.Any(), mockAllowedQuery).
+					Return(dataResult, nil)
+			},
+			expectError: nil,
+		},
+		{
+			name:             "success with warn because query keeps reading a sensitive column",
+			withData:         true,
+			isReturningQuery: &mockSensitiveQuery,
+			warnMessage:      constToWarn(ports.QueryServiceWarnQueryNotAllowed),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockFilteredWorkspace, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt(mockEncryptedDBUrl).
+					Return(mockURL, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockURL).
+					Return(nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockSensitiveQuery, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, mockSensitiveQuery, mockSensitiveFix).
+					Return(&mockSensitiveQuery, nil).
+					Times(mockQueryErrorLimit + 1)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockSensitiveQuery).
+					Return(true, nil).
+					Times(mockQueryErrorLimit + 2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					References(mockSensitiveQuery).
+					Return(mockSensitiveReferences, nil).
+					Times(mockQueryErrorLimit + 2)
+			},
+			expectError: nil,
+		},
+		{
+			name:             "success routed to the data source holding the tables",
+			withData:         true,
+			isReturningQuery: &mockReportingQuery,
+			isReturningData:  dataResult,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockNamedWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(3)).
+					Return(mockNamedVersion, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockReportingQuery, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockReportingQuery).
+					Return(true, nil).
+					Times(2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					References(mockReportingQuery).
+					Return([]domains.QueryReference{{Table: "daily_revenue"}}, nil).
+					Times(2)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt("encrypted_reporting").
+					Return(mockReportingURL, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockReportingURL).
+					Return(nil)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					ContainsDDLDML(mockReportingQuery).
+					Return(false)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Execute(gomock.Any(), mockReportingQuery).
+					Return(dataResult, nil)
+			},
+			expectError: nil,
+		},
+		{
+			name:             "success with warn because query keeps joining tables of several data sources",
+			withData:         true,
+			isReturningQuery: &mockCrossSourceQuery,
+			warnMessage:      constToWarn(ports.QueryServiceWarnQueryCrossesDataSources),
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockNamedWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(3)).
+					Return(mockNamedVersion, nil)
+
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(&mockCrossSourceQuery, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples, mockCrossSourceQuery, mockCrossSourceFix).
+					Return(&mockCrossSourceQuery, nil).
+					Times(mockQueryErrorLimit + 1)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					IsSafe(mockCrossSourceQuery).
+					Return(true, nil).
+					Times(mockQueryErrorLimit + 2)
+				mockQueryValidatorAdapter.
+					EXPECT().
+					References(mockCrossSourceQuery).
+					Return(mockCrossSourceReferences, nil).
+					Times(mockQueryErrorLimit + 2)
+			},
+			expectError: nil,
+		},
+		{
+			name:     "error status in progress",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:     "err get status",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusError, nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err connect internal DB",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err executing internal DB get workspace",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err workspace deleted",
+			withData: true,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(&domains.Workspace{TenantID: mockTenantID, EncryptedDBURL: mockEncryptedDBUrl, DeletedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name:     "err decrypt database URL",
+			withData: true,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt(mockEncryptedDBUrl).
+					Return("", errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err embed prompt",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err vector store search",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err list glossary",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err search examples",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err list examples",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(mockExampleHits, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListExamplesByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err get semantic model",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name:     "err generate query initial",
+			withData: false,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace, nil)
+				mockEmbedderAdapter.
+					EXPECT().
+					Embed(gomock.Any(), mockString).
+					Return(mockVector, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					Search(gomock.Any(), mockTenantID, mockVector, 10).
+					Return(mockVectorEntity, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListGlossaryTermsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockVectorStoreAdapter.
+					EXPECT().
+					SearchByFilter(gomock.Any(), mockTenantID, mockVector, mockExampleFilter, mockMaxExamples).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockLLMAdapter.
+					EXPECT().
+					GenerateQuery(gomock.Any(), mockString, mockVectorEntity, mockNoExamples).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -760,13 +1774,27 @@ func UnitTestPromptToQueryData(
 				mockQueryValidatorAdapter,
 				mockQueryErrorLimit,
 				mockExecutionErrorLimit,
+				mockMaxExamples,
 			)
 
 			if tt.prepareMock != nil {
 				tt.prepareMock()
 			}
+			prompt := tt.prompt
+			if prompt == "" {
+				prompt = mockString
+			}
+			if tt.expectError == nil {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					AppendQueryHistory(gomock.Any(), queryHistoryMatcher{mockTenantID, prompt, tt.isReturningQuery}).
+					DoAndReturn(func(_ context.Context, entry *domains.QueryHistoryEntry) error {
+						entry.ID = 42
+						return tt.recordHistoryError
+					})
+			}
 
-			res, msg, err := svc.PromptToQueryData(context.Background(), mockTenantID, mockString, tt.withData)
+			res, msg, err := svc.PromptToQueryData(context.Background(), mockTenantID, prompt, tt.withData)
 
 			if tt.expectError != nil {
 				require.Error(t, err)
@@ -776,6 +1804,14 @@ func UnitTestPromptToQueryData(
 				require.NoError(t, err)
 				require.Equal(t, tt.isReturningQuery, res.ResultQuery)
 				require.Equal(t, tt.isReturningData, res.ResultData)
+				if tt.warnMessage != nil {
+					require.Equal(t, tt.warnMessage, msg)
+				}
+				if tt.recordHistoryError == nil {
+					require.Equal(t, int64(42), res.HistoryID)
+				} else {
+					require.Zero(t, res.HistoryID)
+				}
 			}
 		})
 	}
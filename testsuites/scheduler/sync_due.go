@@ -0,0 +1,478 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSchedulerService_SyncDue(t *testing.T) {
+//	    scheduler.UnitTestSyncDue(t, NewSchedulerService(config, statusAdapter, internalDatabaseAdapter, encryptAdapter, workspaceService, driftNotifierAdapter))
+//	}
+func UnitTestSyncDue(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+		workspaceService ports.WorkspaceService,
+		driftNotifierAdapter ports.DriftNotifierPort,
+	) ports.SchedulerService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEncryptAdapter          *mocks.MockEncryptPort
+		mockWorkspaceService        *mocks.MockWorkspaceService
+		mockDriftNotifierAdapter    *mocks.MockDriftNotifierPort
+	)
+
+	mockTenantID := "tenant_123"
+	mockOtherTenantID := "tenant_456"
+	mockEncryptedDBUrl := "encrypted_tenant_123"
+	mockOtherEncryptedDBUrl := "encrypted_tenant_456"
+	mockURL := "postgres://client/tenant_123"
+	mockOtherURL := "postgres://client/tenant_456"
+	mockChecksum := "checksum_abc"
+	mockChecksum2 := "checksum_def"
+
+	// Last synced at 09:00, so an hourly schedule is due from 10:00
+	lastSync := time.Date(2026, 1, 14, 9, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 14, hour, minute, 0, 0, time.UTC)
+	}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockHistory := func(lastDone time.Time) []*domains.StatusEvent {
+		return []*domains.StatusEvent{
+			{Type: domains.StatusEventInProgress, CreatedAt: lastDone.Add(-time.Minute)},
+			{Type: domains.StatusEventDone, CreatedAt: lastDone},
+		}
+	}
+	// The last sync comes from the status history, tenant_recent synced at 10:01
+	defaultHistory := func(_ context.Context, tenantID string) ([]*domains.StatusEvent, error) {
+		if tenantID == "tenant_recent" {
+			return mockHistory(at(10, 1)), nil
+		}
+		return mockHistory(lastSync), nil
+	}
+
+	mockDue := &domains.Workspace{
+		TenantID:       mockTenantID,
+		EncryptedDBURL: mockEncryptedDBUrl,
+		Status:         domains.StatusDone,
+		Checksum:       mockChecksum,
+		SyncSchedule:   "@hourly",
+		CreatedAt:      created,
+	}
+	mockOtherDue := &domains.Workspace{
+		TenantID:       mockOtherTenantID,
+		EncryptedDBURL: mockOtherEncryptedDBUrl,
+		Status:         domains.StatusDone,
+		Checksum:       mockChecksum,
+		SyncSchedule:   "*/30 * * * *",
+		CreatedAt:      created,
+	}
+	// Neither synced recently, nor scheduled, nor backed by a database
+	mockNotDue := []*domains.Workspace{
+		{TenantID: "tenant_recent", EncryptedDBURL: "encrypted_recent", SyncSchedule: "@hourly", CreatedAt: created},
+		{TenantID: "tenant_manual", EncryptedDBURL: "encrypted_manual", CreatedAt: created},
+		{TenantID: "tenant_schema_only", SyncSchedule: "@hourly", CreatedAt: created},
+	}
+	mockWorkspaces := append([]*domains.Workspace{mockDue}, mockNotDue...)
+	page := func(workspaces ...*domains.Workspace) *domains.WorkspacePage {
+		return &domains.WorkspacePage{Workspaces: workspaces}
+	}
+
+	mockDiff := domains.SchemaDiff{AddedTables: []string{"payments"}}
+	mockReport := func(outcome domains.SyncOutcome) *domains.SyncReport {
+		report := &domains.SyncReport{TenantID: mockTenantID, Outcome: outcome}
+		if outcome != domains.SyncOutcomeUnchanged {
+			report.Checksum = mockChecksum2
+			report.Diff = mockDiff
+		}
+		return report
+	}
+	mockDrift := func(outcome domains.SyncOutcome, detectedAt time.Time) *domains.DriftEvent {
+		return &domains.DriftEvent{
+			TenantID:         mockTenantID,
+			PreviousChecksum: mockChecksum,
+			Checksum:         mockChecksum2,
+			Outcome:          outcome,
+			Diff:             mockDiff,
+			DetectedAt:       detectedAt,
+		}
+	}
+
+	expectSync := func(times int) {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil).
+			Times(times)
+		mockEncryptAdapter.
+			EXPECT().
+			Decrypt(mockEncryptedDBUrl).
+			Return(mockURL, nil).
+			Times(times)
+	}
+
+	syncErr := func(failures ...string) error {
+		err := ports.SchedulerSyncIncompleteError
+		err.AddBatchAdditionalErrorInfo(failures)
+		return err
+	}
+
+	tests := []struct {
+		name        string
+		runs        []time.Time
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success syncs the due workspace and emits drift",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockWorkspaces...), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeEnqueued), nil, nil)
+				mockDriftNotifierAdapter.
+					EXPECT().
+					NotifyDrift(gomock.Any(), mockDrift(domains.SyncOutcomeEnqueued, at(10, 5))).
+					Return(nil)
+			},
+		},
+		{
+			name: "success due although updated since the last sync",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(&domains.Workspace{
+						TenantID:       mockTenantID,
+						EncryptedDBURL: mockEncryptedDBUrl,
+						Status:         domains.StatusDone,
+						Checksum:       mockChecksum,
+						SyncSchedule:   "@hourly",
+						CreatedAt:      created,
+						UpdatedAt:      at(10, 1),
+					}), nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetHistory(gomock.Any(), mockTenantID).
+					Return(append(mockHistory(lastSync), &domains.StatusEvent{Type: domains.StatusEventWarn, CreatedAt: at(10, 1)}), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeUnchanged), nil, nil)
+			},
+		},
+		{
+			name: "success due when never synced",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue), nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetHistory(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeUnchanged), nil, nil)
+			},
+		},
+		{
+			name: "success syncs a named workspace by its ID",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(&domains.Workspace{
+						TenantID:     "sales",
+						DataSources:  []domains.DataSource{{Name: "oltp", EncryptedDBURL: "encrypted_oltp"}},
+						SyncSchedule: "@hourly",
+						CreatedAt:    created,
+					}), nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), "sales").
+					Return(domains.StatusDone, nil, nil)
+				mockWorkspaceService.
+					EXPECT().
+					SyncWorkspace(gomock.Any(), "sales").
+					Return(&domains.SyncReport{TenantID: "sales", Outcome: domains.SyncOutcomeUnchanged}, nil, nil)
+			},
+		},
+		{
+			name: "success unchanged schema emits nothing",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockWorkspaces...), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeUnchanged), nil, nil)
+			},
+		},
+		{
+			name: "success not synced twice before the next due time",
+			runs: []time.Time{at(10, 5), at(10, 30), at(11, 0)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue), nil).
+					Times(3)
+				expectSync(2)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeUnchanged), nil, nil).
+					Times(2)
+			},
+		},
+		{
+			name: "success pinned drift is emitted once",
+			runs: []time.Time{at(10, 5), at(11, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue), nil).
+					Times(2)
+				expectSync(2)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomePinned), nil, nil).
+					Times(2)
+				mockDriftNotifierAdapter.
+					EXPECT().
+					NotifyDrift(gomock.Any(), mockDrift(domains.SyncOutcomePinned, at(10, 5))).
+					Return(nil)
+			},
+		},
+		{
+			name: "success skips a workspace in progress",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockWorkspaces...), nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+		},
+		{
+			name: "success ingestion started during the sync",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockWorkspaces...), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(nil, nil, ports.StatusInProgressError)
+			},
+		},
+		{
+			name: "success lists every page",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), &domains.WorkspaceQuery{Limit: domains.MaxWorkspacePageSize}).
+					Return(&domains.WorkspacePage{Workspaces: mockNotDue, NextCursor: "cursor_1"}, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), &domains.WorkspaceQuery{Limit: domains.MaxWorkspacePageSize, Cursor: "cursor_1"}).
+					Return(page(mockDue), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeUnchanged), nil, nil)
+			},
+		},
+		{
+			name: "error list workspaces",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error get status history",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue), nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetHistory(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("history error"))
+			},
+			expectError: syncErr(mockTenantID + ": history error"),
+		},
+		{
+			name: "error failed sync does not stop the others",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue, mockOtherDue), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(nil, nil, errors.New("connection refused"))
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockOtherTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt(mockOtherEncryptedDBUrl).
+					Return(mockOtherURL, nil)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockOtherURL).
+					Return(&domains.SyncReport{TenantID: mockOtherTenantID, Outcome: domains.SyncOutcomeUnchanged}, nil, nil)
+			},
+			expectError: syncErr(mockTenantID + ": connection refused"),
+		},
+		{
+			name: "error invalid stored schedule",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(&domains.Workspace{TenantID: mockTenantID, EncryptedDBURL: mockEncryptedDBUrl, SyncSchedule: "every hour"}), nil)
+			},
+			expectError: syncErr(mockTenantID + `: invalid cron expression "every hour": expected 5 fields, got 2`),
+		},
+		{
+			name: "error decrypt database URL",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue), nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt(mockEncryptedDBUrl).
+					Return("", errors.New("decrypt error"))
+			},
+			expectError: syncErr(mockTenantID + ": decrypt error"),
+		},
+		{
+			name: "error notify drift",
+			runs: []time.Time{at(10, 5)},
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), gomock.Any()).
+					Return(page(mockDue), nil)
+				expectSync(1)
+				mockWorkspaceService.
+					EXPECT().
+					SyncClientDatabase(gomock.Any(), mockURL).
+					Return(mockReport(domains.SyncOutcomeEnqueued), nil, nil)
+				mockDriftNotifierAdapter.
+					EXPECT().
+					NotifyDrift(gomock.Any(), mockDrift(domains.SyncOutcomeEnqueued, at(10, 5))).
+					Return(errors.New("webhook error"))
+			},
+			expectError: syncErr(mockTenantID + ": webhook error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEncryptAdapter = mocks.NewMockEncryptPort(ctrl)
+			mockWorkspaceService = mocks.NewMockWorkspaceService(ctrl)
+			mockDriftNotifierAdapter = mocks.NewMockDriftNotifierPort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockEncryptAdapter,
+				mockWorkspaceService,
+				mockDriftNotifierAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+			mockStatusAdapter.
+				EXPECT().
+				GetHistory(gomock.Any(), gomock.Any()).
+				DoAndReturn(defaultHistory).
+				AnyTimes()
+
+			var err error
+			for _, now := range tt.runs {
+				if err = svc.SyncDue(context.Background(), now); err != nil {
+					break
+				}
+			}
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
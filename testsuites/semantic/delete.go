@@ -0,0 +1,80 @@
+package semantic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSemanticModelService_Delete(t *testing.T) {
+//	    semantic.UnitTestDelete(t, NewSemanticModelService(config, internalDatabaseAdapter))
+//	}
+func UnitTestDelete(
+	t *testing.T,
+	svcImp func(
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.SemanticModelService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success delete semantic model",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil)
+			},
+		},
+		{
+			name: "error delete semantic model",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteSemanticModel(gomock.Any(), mockTenantID).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(mockInternalDatabaseAdapter)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := svc.Delete(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package semantic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSemanticModelService_Get(t *testing.T) {
+//	    semantic.UnitTestGet(t, NewSemanticModelService(config, internalDatabaseAdapter))
+//	}
+func UnitTestGet(
+	t *testing.T,
+	svcImp func(
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.SemanticModelService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockSemanticModel := &domains.SemanticModel{
+		TenantID: mockTenantID,
+		Metrics:  []domains.Metric{{Name: "revenue", Expression: "SUM(orders.total)", Table: "orders"}},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  *domains.SemanticModel
+	}{
+		{
+			name: "success get semantic model",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(mockSemanticModel, nil)
+			},
+			expectData: mockSemanticModel,
+		},
+		{
+			name: "error semantic model not found",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.SemanticModelNotFoundError,
+		},
+		{
+			name: "error get semantic model",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSemanticModel(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(mockInternalDatabaseAdapter)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Get(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package semantic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSemanticModelService_Put(t *testing.T) {
+//	    semantic.UnitTestPut(t, NewSemanticModelService(config, internalDatabaseAdapter))
+//	}
+func UnitTestPut(
+	t *testing.T,
+	svcImp func(
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.SemanticModelService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockYAML := `
+metrics:
+  - name: revenue
+    description: Paid order lines, net of refunds
+    expression: SUM(order_items.price * order_items.qty)
+    table: order_items
+    filters:
+      - order_items.refunded = false
+    synonyms: [sales]
+dimensions:
+  - name: region
+    expression: customers.region
+    table: customers
+joins:
+  - from: orders
+    to: customers
+    on: orders.customer_id = customers.id
+`
+	mockJSON := `{"metrics": [{"name": "order_count", "expression": "COUNT(DISTINCT orders.id)", "table": "orders"}]}`
+	mockSemanticModel := &domains.SemanticModel{
+		TenantID: mockTenantID,
+		Metrics: []domains.Metric{{
+			Name:        "revenue",
+			Description: "Paid order lines, net of refunds",
+			Expression:  "SUM(order_items.price * order_items.qty)",
+			Table:       "order_items",
+			Filters:     []string{"order_items.refunded = false"},
+			Synonyms:    []string{"sales"},
+		}},
+		Dimensions: []domains.Dimension{{Name: "region", Expression: "customers.region", Table: "customers"}},
+		Joins:      []domains.JoinPath{{From: "orders", To: "customers", On: "orders.customer_id = customers.id"}},
+	}
+
+	invalidError := func(info ...string) error {
+		err := ports.SemanticModelInvalidError
+		err.AddBatchAdditionalErrorInfo(info)
+		return err
+	}
+	expectWorkspace := func() {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(&domains.Workspace{TenantID: mockTenantID}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		definition  string
+		prepareMock func()
+		expectError error
+		expectData  *domains.SemanticModel
+	}{
+		{
+			name:       "success put YAML definition",
+			definition: mockYAML,
+			prepareMock: func() {
+				expectWorkspace()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertSemanticModel(gomock.Any(), mockSemanticModel).
+					Return(nil)
+			},
+			expectData: mockSemanticModel,
+		},
+		{
+			name:       "success put JSON definition",
+			definition: mockJSON,
+			prepareMock: func() {
+				expectWorkspace()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertSemanticModel(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectData: &domains.SemanticModel{
+				TenantID: mockTenantID,
+				Metrics:  []domains.Metric{{Name: "order_count", Expression: "COUNT(DISTINCT orders.id)", Table: "orders"}},
+			},
+		},
+		{
+			name:        "error unknown field",
+			definition:  "metrics:\n  - name: revenue\n    expresion: SUM(orders.total)\n",
+			expectError: invalidError("yaml: unmarshal errors:\n  line 3: field expresion not found in type domains.Metric"),
+		},
+		{
+			name: "error incomplete and duplicate definitions",
+			definition: `
+metrics:
+  - name: revenue
+    expression: SUM(orders.total)
+    table: orders
+  - name: margin
+    table: orders
+dimensions:
+  - name: Revenue
+    expression: orders.total
+    table: orders
+joins:
+  - from: orders
+    to: customers
+`,
+			expectError: invalidError(
+				"metric margin needs an expression and a table",
+				"Revenue is defined twice",
+				"join needs from, to and on",
+			),
+		},
+		{
+			name:       "error workspace not found",
+			definition: mockYAML,
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:       "error workspace deleted",
+			definition: mockYAML,
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(&domains.Workspace{TenantID: mockTenantID, DeletedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name:       "error store semantic model",
+			definition: mockYAML,
+			prepareMock: func() {
+				expectWorkspace()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertSemanticModel(gomock.Any(), mockSemanticModel).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(mockInternalDatabaseAdapter)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Put(context.Background(), mockTenantID, []byte(tt.definition))
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSchemaVersionService_Diff(t *testing.T) {
+//	    version.UnitTestDiff(t, NewSchemaVersionService(config, statusAdapter, internalDatabaseAdapter, taskQueueService))
+//	}
+func UnitTestDiff(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.SchemaVersionService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockVersion := func(version int64, tables ...string) *domains.SchemaVersion {
+		metadata := &domains.DatabaseMetadata{TenantID: mockTenantID}
+		for _, table := range tables {
+			metadata.Tables = append(metadata.Tables, domains.Table{Name: table})
+		}
+		return &domains.SchemaVersion{TenantID: mockTenantID, Version: version, Metadata: metadata}
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  *domains.SchemaDiff
+	}{
+		{
+			name: "success diff versions",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(mockVersion(1, "orders", "legacy"), nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(3)).
+					Return(mockVersion(3, "orders", "payments"), nil)
+			},
+			expectData: &domains.SchemaDiff{
+				AddedTables:   []string{"payments"},
+				RemovedTables: []string{"legacy"},
+			},
+		},
+		{
+			name: "error from version not found",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(nil, nil)
+			},
+			expectError: ports.SchemaVersionNotFoundError,
+		},
+		{
+			name: "error get to version",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(mockVersion(1, "orders"), nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(3)).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mocks.NewMockStatusPort(ctrl),
+				mockInternalDatabaseAdapter,
+				mocks.NewMockTaskQueuePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Diff(context.Background(), mockTenantID, 1, 3)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSchemaVersionService_Get(t *testing.T) {
+//	    version.UnitTestGet(t, NewSchemaVersionService(config, statusAdapter, internalDatabaseAdapter, taskQueueService))
+//	}
+func UnitTestGet(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.SchemaVersionService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockVersion := &domains.SchemaVersion{
+		TenantID: mockTenantID,
+		Version:  2,
+		Checksum: "checksum_def",
+		Metadata: &domains.DatabaseMetadata{TenantID: mockTenantID, Tables: []domains.Table{{Name: "orders"}}, Checksum: "checksum_def"},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  *domains.SchemaVersion
+	}{
+		{
+			name: "success get version",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(2)).
+					Return(mockVersion, nil)
+			},
+			expectData: mockVersion,
+		},
+		{
+			name: "error version not found",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(2)).
+					Return(nil, nil)
+			},
+			expectError: ports.SchemaVersionNotFoundError,
+		},
+		{
+			name: "error get version",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(2)).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mocks.NewMockStatusPort(ctrl),
+				mockInternalDatabaseAdapter,
+				mocks.NewMockTaskQueuePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Get(context.Background(), mockTenantID, 2)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
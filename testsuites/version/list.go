@@ -0,0 +1,94 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSchemaVersionService_List(t *testing.T) {
+//	    version.UnitTestList(t, NewSchemaVersionService(config, statusAdapter, internalDatabaseAdapter, taskQueueService))
+//	}
+func UnitTestList(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.SchemaVersionService,
+) {
+	var (
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockVersions := []*domains.SchemaVersion{
+		{TenantID: mockTenantID, Version: 1, Checksum: "checksum_abc"},
+		{TenantID: mockTenantID, Version: 2, Checksum: "checksum_def"},
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+		expectData  []*domains.SchemaVersion
+	}{
+		{
+			name: "success list versions",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListSchemaVersionsByTenantID(gomock.Any(), mockTenantID).
+					Return(mockVersions, nil)
+			},
+			expectData: mockVersions,
+		},
+		{
+			name: "error list versions",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListSchemaVersionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mocks.NewMockStatusPort(ctrl),
+				mockInternalDatabaseAdapter,
+				mocks.NewMockTaskQueuePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.List(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSchemaVersionService_Rollback(t *testing.T) {
+//	    version.UnitTestRollback(t, NewSchemaVersionService(config, statusAdapter, internalDatabaseAdapter, taskQueueService))
+//	}
+func UnitTestRollback(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.SchemaVersionService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockTaskQueuePort           *mocks.MockTaskQueuePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID:      mockTenantID,
+			Status:        domains.StatusDone,
+			Checksum:      "checksum_def",
+			ActiveVersion: 2,
+		}
+	}
+	mockMetadata := &domains.DatabaseMetadata{
+		TenantID: mockTenantID,
+		Tables:   []domains.Table{{Name: "orders"}},
+		Checksum: "checksum_abc",
+	}
+	mockVersion := &domains.SchemaVersion{TenantID: mockTenantID, Version: 1, Checksum: "checksum_abc", Metadata: mockMetadata}
+	// The active version only changes once the ingestion succeeds
+	mockPinnedWorkspace := &domains.Workspace{
+		TenantID:      mockTenantID,
+		Status:        domains.StatusInProgress,
+		Checksum:      "checksum_def",
+		ActiveVersion: 2,
+		PinnedVersion: 1,
+	}
+	mockFailedWorkspace := &domains.Workspace{
+		TenantID:      mockTenantID,
+		Status:        domains.StatusError,
+		Checksum:      "checksum_def",
+		ActiveVersion: 2,
+		PinnedVersion: 1,
+	}
+
+	expectIdle := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(), nil)
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success pins and re-ingests the version",
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(mockVersion, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockPinnedWorkspace).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
+					Return(nil)
+			},
+		},
+		{
+			name: "error status in progress",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "error workspace not found",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name: "error workspace deleted",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(&domains.Workspace{TenantID: mockTenantID, DeletedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name: "error version not found",
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(nil, nil)
+			},
+			expectError: ports.SchemaVersionNotFoundError,
+		},
+		{
+			name: "error upsert workspace",
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(mockVersion, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockPinnedWorkspace).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name: "error enqueue leaves the workspace in error",
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(mockVersion, nil)
+				gomock.InOrder(
+					mockInternalDatabaseAdapter.
+						EXPECT().
+						UpsertWorkspace(gomock.Any(), mockPinnedWorkspace).
+						Return(nil),
+					mockStatusAdapter.
+						EXPECT().
+						SetInProgress(gomock.Any(), mockTenantID).
+						Return(nil),
+					mockTaskQueuePort.
+						EXPECT().
+						EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
+						Return(errors.New("queue error")),
+					mockInternalDatabaseAdapter.
+						EXPECT().
+						UpsertWorkspace(gomock.Any(), mockFailedWorkspace).
+						Return(nil),
+					mockStatusAdapter.
+						EXPECT().
+						SetError(gomock.Any(), mockTenantID, errors.New("queue error").Error()).
+						Return(nil),
+				)
+			},
+			expectError: errors.New("queue error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockTaskQueuePort = mocks.NewMockTaskQueuePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockTaskQueuePort,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := svc.Rollback(context.Background(), mockTenantID, 1)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestSchemaVersionService_Unpin(t *testing.T) {
+//	    version.UnitTestUnpin(t, NewSchemaVersionService(config, statusAdapter, internalDatabaseAdapter, taskQueueService))
+//	}
+func UnitTestUnpin(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.SchemaVersionService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockWorkspace := func(pinned int64) *domains.Workspace {
+		return &domains.Workspace{
+			TenantID:      mockTenantID,
+			Status:        domains.StatusDone,
+			Checksum:      "checksum_abc",
+			ActiveVersion: 1,
+			PinnedVersion: pinned,
+		}
+	}
+
+	expectIdle := func(pinned int64) {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(pinned), nil)
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success unpin",
+			prepareMock: func() {
+				expectIdle(1)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockWorkspace(0)).
+					Return(nil)
+			},
+		},
+		{
+			name: "success not pinned",
+			prepareMock: func() {
+				expectIdle(0)
+			},
+		},
+		{
+			name: "error status in progress",
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "error upsert workspace",
+			prepareMock: func() {
+				expectIdle(1)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockWorkspace(0)).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mocks.NewMockTaskQueuePort(ctrl),
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := svc.Unpin(context.Background(), mockTenantID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
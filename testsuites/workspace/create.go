@@ -0,0 +1,234 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_Create(t *testing.T) {
+//	    workspace.UnitTestCreate(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
+//	}
+func UnitTestCreate(
+	t *testing.T,
+	svcImp func(
+		clientDatabaseAdapter ports.ClientDatabasePort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockClientDatabaseAdapter   *mocks.MockClientDatabasePort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEncryptAdapter          *mocks.MockEncryptPort
+	)
+
+	mockID := "sales"
+	mockOLTPURL := "postgres://primary:5432/app"
+	mockReportingURL := "postgres://replica:5432/app"
+	mockDefinition := func() *domains.WorkspaceDefinition {
+		return &domains.WorkspaceDefinition{
+			ID:     mockID,
+			Name:   "Sales",
+			Labels: map[string]string{"team": "sales"},
+			DataSources: []domains.DataSourceDefinition{
+				{Name: "oltp", DBURL: mockOLTPURL},
+				{Name: "reporting", DBURL: mockReportingURL, Preferred: true},
+			},
+		}
+	}
+	mockCreated := &domains.Workspace{
+		TenantID: mockID,
+		Name:     "Sales",
+		Labels:   map[string]string{"team": "sales"},
+		DataSources: []domains.DataSource{
+			{Name: "oltp", EncryptedDBURL: "encrypted_oltp"},
+			{Name: "reporting", EncryptedDBURL: "encrypted_reporting", Preferred: true},
+		},
+	}
+
+	invalidErr := ports.WorkspaceDefinitionInvalidError
+	invalidErr.AddAdditionalErrorInfo(`invalid id "tenant_123": the "tenant_" prefix is reserved`)
+	missingURLErr := ports.WorkspaceDefinitionInvalidError
+	missingURLErr.AddAdditionalErrorInfo(`data source "reporting" has no database URL`)
+	unreachableErr := ports.WorkspaceDBURLUnreachableError
+	unreachableErr.AddAdditionalErrorInfo("reporting: connection refused")
+
+	expectNew := func() {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockID).
+			Return(nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockID).
+			Return(nil, nil)
+	}
+	expectSources := func() {
+		mockClientDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockOLTPURL).
+			Return(nil)
+		mockEncryptAdapter.
+			EXPECT().
+			Encrypt(mockOLTPURL).
+			Return("encrypted_oltp")
+		mockClientDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockReportingURL).
+			Return(nil)
+		mockEncryptAdapter.
+			EXPECT().
+			Encrypt(mockReportingURL).
+			Return("encrypted_reporting")
+	}
+
+	tests := []struct {
+		name        string
+		definition  func() *domains.WorkspaceDefinition
+		prepareMock func()
+		expectError error
+		expectData  *domains.Workspace
+	}{
+		{
+			name:       "success create named workspace",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectNew()
+				expectSources()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockCreated).
+					Return(nil)
+			},
+			expectData: mockCreated,
+		},
+		{
+			name: "error invalid definition",
+			definition: func() *domains.WorkspaceDefinition {
+				definition := mockDefinition()
+				definition.ID = "tenant_123"
+				return definition
+			},
+			expectError: invalidErr,
+		},
+		{
+			name:       "error workspace already exists",
+			definition: mockDefinition,
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockID).
+					Return(&domains.Workspace{TenantID: mockID}, nil)
+			},
+			expectError: ports.WorkspaceAlreadyExistsError,
+		},
+		{
+			name: "error data source without URL",
+			definition: func() *domains.WorkspaceDefinition {
+				definition := mockDefinition()
+				definition.DataSources[1].DBURL = ""
+				return definition
+			},
+			prepareMock: func() {
+				expectNew()
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockOLTPURL).
+					Return(nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockOLTPURL).
+					Return("encrypted_oltp")
+			},
+			expectError: missingURLErr,
+		},
+		{
+			name:       "error data source does not connect",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectNew()
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockOLTPURL).
+					Return(nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockOLTPURL).
+					Return("encrypted_oltp")
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockReportingURL).
+					Return(errors.New("connection refused"))
+			},
+			expectError: unreachableErr,
+		},
+		{
+			name:       "error connect internal DB",
+			definition: mockDefinition,
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockID).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:       "error upsert workspace",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectNew()
+				expectSources()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockCreated).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClientDatabaseAdapter = mocks.NewMockClientDatabasePort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEncryptAdapter = mocks.NewMockEncryptPort(ctrl)
+
+			svc := svcImp(
+				mockClientDatabaseAdapter,
+				mockInternalDatabaseAdapter,
+				mockEncryptAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Create(context.Background(), tt.definition())
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
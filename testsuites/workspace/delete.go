@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/kamil5b/go-nl2query-lib/domains"
@@ -15,21 +16,36 @@ import (
 // @example usage:
 //
 //	func TestWorkspaceService_Delete(t *testing.T) {
-//	    workspace.UnitTestDelete(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService))
+//	    workspace.UnitTestDelete(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
 //	}
 func UnitTestDelete(
 	t *testing.T,
 	svcImp func(
 		statusAdapter ports.StatusPort,
 		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+		vectorStoreAdapter ports.VectorStorePort,
 	) ports.WorkspaceService,
 ) {
 	var (
 		mockStatusAdapter           *mocks.MockStatusPort
 		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockTaskQueuePort           *mocks.MockTaskQueuePort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
 	)
 
 	mockTenantID := "tenant_123"
+	mockDeletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{TenantID: mockTenantID, EncryptedDBURL: "encrypted_url", Status: domains.StatusDone, Checksum: "checksum_abc"}
+	}
+
+	expectIdle := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+	}
 
 	tests := []struct {
 		name        string
@@ -38,31 +54,101 @@ func UnitTestDelete(
 		expectError error
 	}{
 		{
-			name:     "success delete workspace",
+			name:     "success marks the workspace deleted and keeps its data",
 			tenantID: mockTenantID,
 			prepareMock: func() {
-				mockStatusAdapter.
-					EXPECT().
-					GetStatus(gomock.Any(), mockTenantID).
-					Return(domains.StatusDone, nil, nil)
+				expectIdle()
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					DeleteWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace(), nil)
+				mockTaskQueuePort.
+					EXPECT().
+					CancelIngestionTasks(gomock.Any(), mockTenantID).
 					Return(nil)
+				before := time.Now()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, workspace *domains.Workspace) error {
+						require.False(t, workspace.DeletedAt.Before(before))
+						workspace.DeletedAt = time.Time{}
+						require.Equal(t, mockWorkspace(), workspace)
+						return nil
+					})
 			},
 			expectError: nil,
 		},
 		{
-			name:     "error deleting workspace",
+			name:     "error workspace not found",
 			tenantID: mockTenantID,
 			prepareMock: func() {
-				mockStatusAdapter.
+				expectIdle()
+				mockInternalDatabaseAdapter.
 					EXPECT().
-					GetStatus(gomock.Any(), mockTenantID).
-					Return(domains.StatusDone, nil, nil)
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:     "error workspace already deleted",
+			tenantID: mockTenantID,
+			prepareMock: func() {
+				expectIdle()
+				deleted := mockWorkspace()
+				deleted.DeletedAt = mockDeletedAt
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(deleted, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:     "error get workspace",
+			tenantID: mockTenantID,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+		{
+			name:     "error cancel ingestion tasks",
+			tenantID: mockTenantID,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace(), nil)
+				mockTaskQueuePort.
+					EXPECT().
+					CancelIngestionTasks(gomock.Any(), mockTenantID).
+					Return(errors.New("queue error"))
+			},
+			expectError: errors.New("queue error"),
+		},
+		{
+			name:     "error saving workspace",
+			tenantID: mockTenantID,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockWorkspace(), nil)
+				mockTaskQueuePort.
+					EXPECT().
+					CancelIngestionTasks(gomock.Any(), mockTenantID).
+					Return(nil)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					DeleteWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					UpsertWorkspace(gomock.Any(), gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectError: errors.New("database error"),
@@ -98,10 +184,14 @@ func UnitTestDelete(
 
 			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
 			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockTaskQueuePort = mocks.NewMockTaskQueuePort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
 
 			svc := svcImp(
 				mockStatusAdapter,
 				mockInternalDatabaseAdapter,
+				mockTaskQueuePort,
+				mockVectorStoreAdapter,
 			)
 
 			if tt.prepareMock != nil {
@@ -15,7 +15,7 @@ import (
 // @example usage:
 //
 //	func TestWorkspaceService_GetByTenantID(t *testing.T) {
-//	    workspace.UnitTestGetByTenantID(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService))
+//	    workspace.UnitTestGetByTenantID(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
 //	}
 func UnitTestGetByTenantID(
 	t *testing.T,
@@ -0,0 +1,466 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_ImportSchema(t *testing.T) {
+//	    workspace.UnitTestImportSchema(t, New(statusAdapter, internalDatabaseAdapter, hashAdapter, taskQueueService))
+//	}
+func UnitTestImportSchema(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		hashAdapter ports.HashPort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockHashAdapter             *mocks.MockHashPort
+		mockTaskQueuePort           *mocks.MockTaskQueuePort
+	)
+
+	mockName := "billing-schema"
+	mockTenantID := "tenant_123"
+	mockChecksum := "checksum_abc"
+	mockChecksum2 := "checksum_def"
+	mockSchema := `
+CREATE TABLE customers (
+    id integer PRIMARY KEY,
+    name text NOT NULL
+);
+COMMENT ON TABLE customers IS 'People who place orders';`
+
+	mockMetadata := &domains.DatabaseMetadata{
+		TenantID: mockTenantID,
+		Checksum: mockChecksum2,
+		Tables: []domains.Table{
+			{
+				Name:     "customers",
+				Comments: "People who place orders",
+				Columns: []domains.Column{
+					{Name: "id", Type: "integer", IsPrimaryKey: true},
+					{Name: "name", Type: "text"},
+				},
+				Constraints: []domains.Constraint{
+					{Name: "customers_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}},
+				},
+			},
+		},
+	}
+
+	// A schema-only workspace keeps no database URL
+	mockResult := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockTenantID,
+			Status:   domains.StatusDone,
+			Checksum: mockChecksum,
+		}
+	}
+	mockQueuedUpdate := &domains.Workspace{
+		TenantID: mockTenantID,
+		Name:     mockName,
+		Status:   domains.StatusInProgress,
+		Checksum: mockChecksum,
+	}
+	mockQueuedCreate := &domains.Workspace{
+		TenantID: mockTenantID,
+		Name:     mockName,
+		Status:   domains.StatusInProgress,
+	}
+	mockFailedCreate := &domains.Workspace{
+		TenantID: mockTenantID,
+		Name:     mockName,
+		Status:   domains.StatusError,
+	}
+
+	// Neither workspace has an active version, so every table is added
+	mockEnqueued := &domains.SyncReport{
+		TenantID: mockTenantID,
+		Outcome:  domains.SyncOutcomeEnqueued,
+		Checksum: mockChecksum2,
+		Metadata: mockMetadata,
+		Diff:     domains.DiffMetadata(nil, mockMetadata),
+	}
+
+	invalidErr := func(info string) error {
+		err := ports.WorkspaceSchemaInvalidError
+		err.AddAdditionalErrorInfo(info)
+		return err
+	}
+
+	tests := []struct {
+		name        string
+		schemaName  string
+		format      domains.SchemaFormat
+		schema      string
+		prepareMock func()
+		expected    *domains.SyncReport
+		expectError error
+	}{
+		{
+			name: "success create",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedCreate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
+					Return(nil)
+			},
+			expected: mockEnqueued,
+		},
+		{
+			name: "success update",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockResult(), nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedUpdate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
+					Return(nil)
+			},
+			expected: mockEnqueued,
+		},
+		{
+			name: "success unchanged schema",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockResult(), nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum, nil)
+			},
+			expected: &domains.SyncReport{
+				TenantID: mockTenantID,
+				Outcome:  domains.SyncOutcomeUnchanged,
+			},
+		},
+		{
+			name: "err workspace pinned",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(func() *domains.Workspace {
+						workspace := mockResult()
+						workspace.PinnedVersion = 1
+						return workspace
+					}(), nil)
+			},
+			expectError: ports.WorkspacePinnedError,
+		},
+		{
+			name: "err workspace syncs a client database",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(func() *domains.Workspace {
+						workspace := mockResult()
+						workspace.EncryptedDBURL = "encrypted_db_url"
+						return workspace
+					}(), nil)
+			},
+			expectError: ports.WorkspaceNotSchemaOnlyError,
+		},
+		{
+			name:        "err name required",
+			schemaName:  "-",
+			expectError: invalidErr("name is required"),
+		},
+		{
+			name:        "err unsupported format",
+			format:      "oracle",
+			expectError: invalidErr(`unsupported schema format "oracle"`),
+		},
+		{
+			name:        "err invalid script",
+			schema:      "CREATE TABLE customers (id integer",
+			expectError: invalidErr(`line 1: expected "," or ")", found end of statement`),
+		},
+		{
+			name:        "err no tables",
+			schema:      "CREATE SEQUENCE customers_id_seq;",
+			expectError: invalidErr("no tables found"),
+		},
+		{
+			name: "err status in progress",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "err connect internal database",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "err upsert workspace",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedCreate).
+					Return(errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "err enqueue leaves the workspace in error",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockName).
+					Return(mockTenantID)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				gomock.InOrder(
+					mockInternalDatabaseAdapter.
+						EXPECT().
+						UpsertWorkspace(gomock.Any(), mockQueuedCreate).
+						Return(nil),
+					mockStatusAdapter.
+						EXPECT().
+						SetInProgress(gomock.Any(), mockTenantID).
+						Return(nil),
+					mockTaskQueuePort.
+						EXPECT().
+						EnqueueSchemaIngestionTask(gomock.Any(), gomock.Any()).
+						Return(errors.New("err")),
+					mockInternalDatabaseAdapter.
+						EXPECT().
+						UpsertWorkspace(gomock.Any(), mockFailedCreate).
+						Return(nil),
+					mockStatusAdapter.
+						EXPECT().
+						SetError(gomock.Any(), mockTenantID, errors.New("err").Error()).
+						Return(nil),
+				)
+			},
+			expectError: errors.New("err"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockHashAdapter = mocks.NewMockHashPort(ctrl)
+			mockTaskQueuePort = mocks.NewMockTaskQueuePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockHashAdapter,
+				mockTaskQueuePort,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			name := tt.schemaName
+			if name == "" {
+				name = mockName
+			} else if name == "-" {
+				name = ""
+			}
+			format := tt.format
+			if format == "" {
+				format = domains.SchemaFormatPostgresDDL
+			}
+			schema := tt.schema
+			if schema == "" {
+				schema = mockSchema
+			}
+
+			result, err := svc.ImportSchema(context.Background(), name, format, schema)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
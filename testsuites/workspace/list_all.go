@@ -15,7 +15,7 @@ import (
 // @example usage:
 //
 //	func TestWorkspaceService_ListAll(t *testing.T) {
-//	    workspace.UnitTestListAll(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService))
+//	    workspace.UnitTestListAll(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
 //	}
 func UnitTestListAll(
 	t *testing.T,
@@ -37,42 +37,70 @@ func UnitTestListAll(
 		Checksum: "checksum_def",
 	}
 
-	mockWorkspaceList := []*domains.Workspace{mockWorkspace1, mockWorkspace2}
+	mockPage := &domains.WorkspacePage{
+		Workspaces: []*domains.Workspace{mockWorkspace1, mockWorkspace2},
+		NextCursor: "cursor_abc",
+	}
+	mockQuery := &domains.WorkspaceQuery{
+		Statuses: []domains.WorkspaceStatus{domains.StatusDone},
+		Labels:   map[string]string{"team": "sales"},
+		Search:   "sales",
+		SortBy:   domains.WorkspaceSortName,
+		Limit:    2,
+	}
+
+	invalidErr := func(info string) error {
+		err := ports.WorkspaceQueryInvalidError
+		err.AddAdditionalErrorInfo(info)
+		return err
+	}
 
 	tests := []struct {
 		name        string
+		query       *domains.WorkspaceQuery
 		prepareMock func()
 		expectError error
-		expectData  []*domains.Workspace
+		expectData  *domains.WorkspacePage
 	}{
 		{
-			name: "success list all workspaces",
+			name:  "success list a filtered page",
+			query: mockQuery,
 			prepareMock: func() {
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					ListAllWorkspaces(gomock.Any()).
-					Return(mockWorkspaceList, nil)
+					ListAllWorkspaces(gomock.Any(), mockQuery).
+					Return(mockPage, nil)
 			},
 			expectError: nil,
-			expectData:  mockWorkspaceList,
+			expectData:  mockPage,
 		},
 		{
-			name: "success empty list",
+			name: "success empty list without query",
 			prepareMock: func() {
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					ListAllWorkspaces(gomock.Any()).
-					Return([]*domains.Workspace{}, nil)
+					ListAllWorkspaces(gomock.Any(), nil).
+					Return(&domains.WorkspacePage{Workspaces: []*domains.Workspace{}}, nil)
 			},
 			expectError: nil,
-			expectData:  []*domains.Workspace{},
+			expectData:  &domains.WorkspacePage{Workspaces: []*domains.Workspace{}},
+		},
+		{
+			name:        "error invalid sort field",
+			query:       &domains.WorkspaceQuery{SortBy: "status"},
+			expectError: invalidErr(`invalid sort field "status"`),
+		},
+		{
+			name:        "error invalid cursor",
+			query:       &domains.WorkspaceQuery{Cursor: "not a cursor"},
+			expectError: invalidErr("invalid cursor"),
 		},
 		{
 			name: "error listing workspaces",
 			prepareMock: func() {
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					ListAllWorkspaces(gomock.Any()).
+					ListAllWorkspaces(gomock.Any(), nil).
 					Return(nil, errors.New("database error"))
 			},
 			expectError: errors.New("database error"),
@@ -94,7 +122,7 @@ func UnitTestListAll(
 				tt.prepareMock()
 			}
 
-			result, err := svc.ListAll(context.Background())
+			result, err := svc.ListAll(context.Background(), tt.query)
 
 			if tt.expectError != nil {
 				require.Error(t, err)
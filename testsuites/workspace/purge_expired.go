@@ -0,0 +1,265 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_PurgeExpired(t *testing.T) {
+//	    workspace.UnitTestPurgeExpired(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
+//	}
+func UnitTestPurgeExpired(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		taskQueueService ports.TaskQueuePort,
+		vectorStoreAdapter ports.VectorStorePort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockTaskQueuePort           *mocks.MockTaskQueuePort
+		mockVectorStoreAdapter      *mocks.MockVectorStorePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockOtherTenantID := "tenant_456"
+	mockNow := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Deleted over 30 days ago, the default retention window
+	mockQuery := &domains.WorkspaceQuery{
+		Deleted:       true,
+		DeletedBefore: mockNow.Add(-30 * 24 * time.Hour),
+		SortBy:        domains.WorkspaceSortTenantID,
+		Limit:         domains.MaxWorkspacePageSize,
+	}
+
+	expectList := func(tenantIDs ...string) {
+		workspaces := make([]*domains.Workspace, len(tenantIDs))
+		for i, tenantID := range tenantIDs {
+			workspaces[i] = &domains.Workspace{TenantID: tenantID, DeletedAt: mockNow.Add(-31 * 24 * time.Hour)}
+		}
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListAllWorkspaces(gomock.Any(), mockQuery).
+			Return(&domains.WorkspacePage{Workspaces: workspaces}, nil)
+	}
+
+	// expectCascade expects every cleanup step of the tenant once, failing
+	// with the given errors
+	expectCascade := func(tenantID string, cancelErr, vectorErr, clearErr, statusHistoryErr, queryHistoryErr, descriptionsErr, glossaryErr, examplesErr, semanticModelErr, schemaVersionsErr, aliasesErr error) {
+		mockTaskQueuePort.
+			EXPECT().
+			CancelIngestionTasks(gomock.Any(), tenantID).
+			Return(cancelErr)
+		mockVectorStoreAdapter.
+			EXPECT().
+			Delete(gomock.Any(), tenantID).
+			Return(vectorErr)
+		mockStatusAdapter.
+			EXPECT().
+			Clear(gomock.Any(), tenantID).
+			Return(clearErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteStatusEventsByTenantID(gomock.Any(), tenantID).
+			Return(statusHistoryErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteQueryHistoryByTenantID(gomock.Any(), tenantID).
+			Return(queryHistoryErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteDescriptionsByTenantID(gomock.Any(), tenantID).
+			Return(descriptionsErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteGlossaryTermsByTenantID(gomock.Any(), tenantID).
+			Return(glossaryErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteExamplesByTenantID(gomock.Any(), tenantID).
+			Return(examplesErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteSemanticModel(gomock.Any(), tenantID).
+			Return(semanticModelErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteSchemaVersionsByTenantID(gomock.Any(), tenantID).
+			Return(schemaVersionsErr)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			DeleteTenantAliasesByTenantID(gomock.Any(), tenantID).
+			Return(aliasesErr)
+	}
+
+	incompleteError := func(info ...string) string {
+		err := ports.WorkspaceDeleteIncompleteError
+		err.AddBatchAdditionalErrorInfo(info)
+		return err.Error()
+	}
+
+	purgeError := func(failures ...string) error {
+		err := ports.WorkspacePurgeIncompleteError
+		err.AddBatchAdditionalErrorInfo(failures)
+		return err
+	}
+
+	tests := []struct {
+		name        string
+		prepareMock func()
+		expectError error
+	}{
+		{
+			name: "success purges every expired workspace",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), mockQuery).
+					Return(&domains.WorkspacePage{Workspaces: []*domains.Workspace{{TenantID: mockTenantID}}, NextCursor: "cursor_1"}, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), &domains.WorkspaceQuery{
+						Deleted:       true,
+						DeletedBefore: mockQuery.DeletedBefore,
+						SortBy:        domains.WorkspaceSortTenantID,
+						Limit:         domains.MaxWorkspacePageSize,
+						Cursor:        "cursor_1",
+					}).
+					Return(&domains.WorkspacePage{Workspaces: []*domains.Workspace{{TenantID: mockOtherTenantID}}}, nil)
+				for _, tenantID := range []string{mockTenantID, mockOtherTenantID} {
+					expectCascade(tenantID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+					mockInternalDatabaseAdapter.
+						EXPECT().
+						DeleteWorkspaceByTenantID(gomock.Any(), tenantID).
+						Return(nil)
+				}
+			},
+		},
+		{
+			name: "success nothing to purge",
+			prepareMock: func() {
+				expectList()
+			},
+		},
+		{
+			name: "error partial purge keeps the workspace for retry and purges the others",
+			prepareMock: func() {
+				expectList(mockTenantID, mockOtherTenantID)
+				expectCascade(mockTenantID, nil, errors.New("vector store error"), nil, nil, errors.New("database error"), nil, nil, nil, nil, nil, nil)
+				expectCascade(mockOtherTenantID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteWorkspaceByTenantID(gomock.Any(), mockOtherTenantID).
+					Return(nil)
+			},
+			expectError: purgeError(
+				mockTenantID + ": " + incompleteError(
+					"delete vectors: vector store error",
+					"delete query history: database error",
+				),
+			),
+		},
+		{
+			name: "error every cleanup step",
+			prepareMock: func() {
+				expectList(mockTenantID)
+				expectCascade(
+					mockTenantID,
+					errors.New("queue error"),
+					errors.New("vector store error"),
+					errors.New("status error"),
+					errors.New("database error"),
+					errors.New("database error"),
+					errors.New("database error"),
+					errors.New("database error"),
+					errors.New("database error"),
+					errors.New("database error"),
+					errors.New("database error"),
+					errors.New("database error"),
+				)
+			},
+			expectError: purgeError(
+				mockTenantID + ": " + incompleteError(
+					"cancel ingestion tasks: queue error",
+					"delete vectors: vector store error",
+					"clear status: status error",
+					"delete status history: database error",
+					"delete query history: database error",
+					"delete descriptions: database error",
+					"delete glossary: database error",
+					"delete examples: database error",
+					"delete semantic model: database error",
+					"delete schema versions: database error",
+					"delete tenant aliases: database error",
+				),
+			),
+		},
+		{
+			name: "error deleting workspace",
+			prepareMock: func() {
+				expectList(mockTenantID)
+				expectCascade(mockTenantID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					DeleteWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(errors.New("database error"))
+			},
+			expectError: purgeError(mockTenantID + ": database error"),
+		},
+		{
+			name: "error list workspaces",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListAllWorkspaces(gomock.Any(), mockQuery).
+					Return(nil, errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockTaskQueuePort = mocks.NewMockTaskQueuePort(ctrl)
+			mockVectorStoreAdapter = mocks.NewMockVectorStorePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+				mockTaskQueuePort,
+				mockVectorStoreAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			err := svc.PurgeExpired(context.Background(), mockNow)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/kamil5b/go-nl2query-lib/domains"
@@ -43,9 +44,33 @@ func UnitTestSyncClientDatabase(
 	mockChecksum := "checksum_abc"
 	mockChecksum2 := "checksum_def"
 
-	mockResult := &domains.Workspace{
-		TenantID: mockTenantID,
-		Checksum: mockChecksum,
+	mockResult := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID:       mockTenantID,
+			EncryptedDBURL: mockEncryptedDBUrl,
+			Status:         domains.StatusDone,
+			Checksum:       mockChecksum,
+			ActiveVersion:  1,
+		}
+	}
+
+	// The stored checksum stays unchanged until ingestion succeeds
+	mockQueuedUpdate := &domains.Workspace{
+		TenantID:       mockTenantID,
+		EncryptedDBURL: mockEncryptedDBUrl,
+		Status:         domains.StatusInProgress,
+		Checksum:       mockChecksum,
+		ActiveVersion:  1,
+	}
+	mockQueuedCreate := &domains.Workspace{
+		TenantID:       mockTenantID,
+		EncryptedDBURL: mockEncryptedDBUrl,
+		Status:         domains.StatusInProgress,
+	}
+	mockFailedCreate := &domains.Workspace{
+		TenantID:       mockTenantID,
+		EncryptedDBURL: mockEncryptedDBUrl,
+		Status:         domains.StatusError,
 	}
 
 	mockTableName := "mock_tables"
@@ -71,19 +96,85 @@ func UnitTestSyncClientDatabase(
 		},
 	}
 
+	// An audit table and a password column, which the workspace does not sync
+	mockFilteredWorkspace := mockResult()
+	mockFilteredWorkspace.SyncOptions = &domains.SyncOptions{
+		ExcludeTables:    []string{"audit_*"},
+		SensitiveColumns: []string{"*password*"},
+	}
+	mockUnfilteredMetadata := &domains.DatabaseMetadata{
+		TenantID: mockTenantID,
+		Tables: []domains.Table{
+			{
+				Name: mockTableName,
+				Columns: []domains.Column{
+					{Name: mockColumnName, Type: "VARCHAR", IsPrimaryKey: true},
+					{Name: "password_hash", Type: "VARCHAR"},
+				},
+			},
+			{Name: "audit_log", Columns: []domains.Column{{Name: "id", Type: "INT"}}},
+		},
+	}
+
+	// What is ingested from mockUnfilteredMetadata: the audit table and the
+	// password column are left out
+	mockFilteredMetadata := &domains.DatabaseMetadata{
+		TenantID: mockTenantID,
+		Tables: []domains.Table{
+			{Name: mockTableName, Columns: []domains.Column{{Name: mockColumnName, Type: "VARCHAR", IsPrimaryKey: true}}},
+		},
+		Checksum: mockChecksum2,
+	}
+
+	// The last ingestion had a since dropped column
+	mockSnapshot := &domains.DatabaseMetadata{
+		TenantID: mockTenantID,
+		Tables: []domains.Table{
+			{
+				Name: mockTableName,
+				Columns: []domains.Column{
+					{Name: mockColumnName, Type: "VARCHAR", IsPrimaryKey: true},
+					{Name: "mock_dropped", Type: "INT", Nullable: true},
+				},
+			},
+		},
+		Checksum: mockChecksum,
+	}
+
+	// The tenant of the URL, which no credential rotation points elsewhere
+	expectTenant := func() {
+		mockHashAdapter.
+			EXPECT().
+			GenerateTenantID(mockString).
+			Return(mockTenantID)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockTenantID).
+			Return(nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetTenantIDByAlias(gomock.Any(), mockTenantID).
+			Return("", nil)
+	}
+
+	expectActiveVersion := func() {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+			Return(&domains.SchemaVersion{TenantID: mockTenantID, Version: 1, Checksum: mockChecksum, Metadata: mockSnapshot}, nil)
+	}
+
 	tests := []struct {
-		name        string
-		prepareMock func()
-		warnMessage *string
-		expectError error
+		name         string
+		prepareMock  func()
+		warnMessage  *string
+		expectReport *domains.SyncReport
+		expectError  error
 	}{
 		{
 			name: "success update",
 			prepareMock: func() {
-				mockHashAdapter.
-					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -92,14 +183,10 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					Encrypt(mockString).
 					Return(mockEncryptedDBUrl)
-				mockInternalDatabaseAdapter.
-					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
-					Return(nil)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
-					Return(mockResult, nil)
+					Return(mockResult(), nil)
 				mockClientDatabaseAdapter.
 					EXPECT().
 					Connect(gomock.Any(), mockString).
@@ -112,21 +199,42 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					GenerateChecksum(gomock.Any()).
 					Return(mockChecksum2, nil)
+				expectActiveVersion()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedUpdate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
 				mockTaskQueuePort.
 					EXPECT().
-					EnqueueIngestionTask(gomock.Any(), mockTenantID, mockString).
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
 					Return(nil)
 
 			},
+			expectReport: &domains.SyncReport{
+				TenantID: mockTenantID,
+				Outcome:  domains.SyncOutcomeEnqueued,
+				Checksum: mockChecksum2,
+				Metadata: mockMetadata,
+				Diff: domains.SchemaDiff{
+					AlteredTables: []domains.TableDiff{
+						{Name: mockTableName, RemovedColumns: []domains.Column{{Name: "mock_dropped", Type: "INT", Nullable: true}}},
+					},
+				},
+			},
 			expectError: nil,
 		},
 		{
 			name: "success create",
 			prepareMock: func() {
-				mockHashAdapter.
-					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -135,10 +243,6 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					Encrypt(mockString).
 					Return(mockEncryptedDBUrl)
-				mockInternalDatabaseAdapter.
-					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
-					Return(nil)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
@@ -155,21 +259,96 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					GenerateChecksum(gomock.Any()).
 					Return(mockChecksum, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedCreate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
 				mockTaskQueuePort.
 					EXPECT().
-					EnqueueIngestionTask(gomock.Any(), mockTenantID, mockString).
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
 					Return(nil)
 
 			},
+			expectReport: &domains.SyncReport{
+				TenantID: mockTenantID,
+				Outcome:  domains.SyncOutcomeEnqueued,
+				Checksum: mockChecksum,
+				Metadata: mockMetadata,
+				Diff:     domains.SchemaDiff{AddedTables: []string{mockTableName}},
+			},
 			expectError: nil,
 		},
 		{
-			name: "err enqueue ingestion task",
+			name: "success enqueues the metadata without unsynced tables and columns",
 			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockFilteredWorkspace, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
+					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockUnfilteredMetadata, nil)
 				mockHashAdapter.
 					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				expectActiveVersion()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockFilteredMetadata).
+					Return(nil)
+			},
+			expectReport: &domains.SyncReport{
+				TenantID: mockTenantID,
+				Outcome:  domains.SyncOutcomeEnqueued,
+				Checksum: mockChecksum2,
+				Metadata: mockFilteredMetadata,
+				Diff: domains.SchemaDiff{
+					AlteredTables: []domains.TableDiff{
+						{Name: mockTableName, RemovedColumns: []domains.Column{{Name: "mock_dropped", Type: "INT", Nullable: true}}},
+					},
+				},
+			},
+			expectError: nil,
+		},
+		{
+			name: "success pinned reports changes without ingesting",
+			prepareMock: func() {
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -180,8 +359,50 @@ func UnitTestSyncClientDatabase(
 					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(func() *domains.Workspace {
+						workspace := mockResult()
+						workspace.PinnedVersion = 1
+						return workspace
+					}(), nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
 					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockMetadata, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				expectActiveVersion()
+			},
+			expectReport: &domains.SyncReport{
+				TenantID: mockTenantID,
+				Outcome:  domains.SyncOutcomePinned,
+				Checksum: mockChecksum2,
+				Diff: domains.SchemaDiff{
+					AlteredTables: []domains.TableDiff{
+						{Name: mockTableName, RemovedColumns: []domains.Column{{Name: "mock_dropped", Type: "INT", Nullable: true}}},
+					},
+				},
+			},
+			expectError: nil,
+		},
+		{
+			name: "err enqueue ingestion task",
+			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
@@ -198,21 +419,37 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					GenerateChecksum(gomock.Any()).
 					Return(mockChecksum, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedCreate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(nil)
 				mockTaskQueuePort.
 					EXPECT().
-					EnqueueIngestionTask(gomock.Any(), mockTenantID, mockString).
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMetadata).
 					Return(errors.New("err"))
-
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockFailedCreate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetError(gomock.Any(), mockTenantID, errors.New("err").Error()).
+					Return(nil)
 			},
 			expectError: errors.New("err"),
 		},
 		{
-			name: "success with no changes",
+			name: "err set status in progress leaves the workspace in error",
 			prepareMock: func() {
-				mockHashAdapter.
-					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -223,12 +460,59 @@ func UnitTestSyncClientDatabase(
 					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil) // No existing record
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
 					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockMetadata, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedCreate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockTenantID).
+					Return(errors.New("err"))
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockFailedCreate).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetError(gomock.Any(), mockTenantID, errors.New("err").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "err upsert workspace",
+			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
-					Return(mockResult, nil)
+					Return(mockResult(), nil)
 				mockClientDatabaseAdapter.
 					EXPECT().
 					Connect(gomock.Any(), mockString).
@@ -240,17 +524,58 @@ func UnitTestSyncClientDatabase(
 				mockHashAdapter.
 					EXPECT().
 					GenerateChecksum(gomock.Any()).
-					Return(mockChecksum, nil) // Match existing checksum
+					Return(mockChecksum2, nil)
+				expectActiveVersion()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueuedUpdate).
+					Return(errors.New("err"))
 			},
-			expectError: nil,
+			expectError: errors.New("err"),
 		},
 		{
-			name: "error generate checksum",
+			name: "err get active schema version",
 			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockResult(), nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
+					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockMetadata, nil)
 				mockHashAdapter.
 					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetSchemaVersion(gomock.Any(), mockTenantID, int64(1)).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "err list descriptions",
+			prepareMock: func() {
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -261,12 +586,44 @@ func UnitTestSyncClientDatabase(
 					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockResult(), nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
 					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockMetadata, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum2, nil)
+				expectActiveVersion()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "success with no changes",
+			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
-					Return(mockResult, nil)
+					Return(mockResult(), nil)
 				mockClientDatabaseAdapter.
 					EXPECT().
 					Connect(gomock.Any(), mockString).
@@ -278,17 +635,52 @@ func UnitTestSyncClientDatabase(
 				mockHashAdapter.
 					EXPECT().
 					GenerateChecksum(gomock.Any()).
-					Return("", errors.New("err"))
+					Return(mockChecksum, nil) // Match existing checksum
 			},
-			expectError: errors.New("err"),
+			expectReport: &domains.SyncReport{TenantID: mockTenantID, Outcome: domains.SyncOutcomeUnchanged},
+			expectError:  nil,
 		},
 		{
-			name: "success with warn",
+			name: "success with no changes once unsynced tables and columns are filtered",
 			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockFilteredWorkspace, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
+					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockUnfilteredMetadata, nil)
 				mockHashAdapter.
 					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+					GenerateChecksum(&domains.DatabaseMetadata{
+						TenantID: mockTenantID,
+						Tables: []domains.Table{
+							{Name: mockTableName, Columns: mockUnfilteredMetadata.Tables[0].Columns[:1]},
+						},
+					}).
+					Return(mockChecksum, nil)
+			},
+			expectReport: &domains.SyncReport{TenantID: mockTenantID, Outcome: domains.SyncOutcomeUnchanged},
+			expectError:  nil,
+		},
+		{
+			name: "error generate checksum",
+			prepareMock: func() {
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -299,12 +691,39 @@ func UnitTestSyncClientDatabase(
 					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockResult(), nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
 					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(mockMetadata, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return("", errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "success with warn",
+			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
-					Return(mockResult, nil)
+					Return(mockResult(), nil)
 				mockClientDatabaseAdapter.
 					EXPECT().
 					Connect(gomock.Any(), mockString).
@@ -314,15 +733,13 @@ func UnitTestSyncClientDatabase(
 				msg := ports.WorkspaceServiceWarnUseExistingClientDatabaseError
 				return &msg
 			}(),
-			expectError: nil,
+			expectReport: &domains.SyncReport{TenantID: mockTenantID, Outcome: domains.SyncOutcomeUsedCachedSchema},
+			expectError:  nil,
 		},
 		{
-			name: "err executing internal DB",
+			name: "err workspace deleted",
 			prepareMock: func() {
-				mockHashAdapter.
-					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -331,10 +748,27 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					Encrypt(mockString).
 					Return(mockEncryptedDBUrl)
+				deleted := mockResult()
+				deleted.DeletedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
-					Return(nil)
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(deleted, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name: "err executing internal DB",
+			prepareMock: func() {
+				expectTenant()
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockString).
+					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
 					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
@@ -349,6 +783,28 @@ func UnitTestSyncClientDatabase(
 					EXPECT().
 					GenerateTenantID(mockString).
 					Return(mockTenantID)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "success with a rotated database URL",
+			prepareMock: func() {
+				mockHashAdapter.
+					EXPECT().
+					GenerateTenantID(mockString).
+					Return("tenant_rotated")
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), "tenant_rotated").
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetTenantIDByAlias(gomock.Any(), "tenant_rotated").
+					Return(mockTenantID, nil)
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -359,18 +815,46 @@ func UnitTestSyncClientDatabase(
 					Return(mockEncryptedDBUrl)
 				mockInternalDatabaseAdapter.
 					EXPECT().
-					Connect(gomock.Any(), gomock.Any()).
-					Return(errors.New("err"))
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(mockResult(), nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockString).
+					Return(nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					GetDatabaseMetadata(gomock.Any()).
+					Return(&domains.DatabaseMetadata{Tables: mockMetadata.Tables}, nil)
+				mockHashAdapter.
+					EXPECT().
+					GenerateChecksum(gomock.Any()).
+					Return(mockChecksum, nil)
 			},
-			expectError: errors.New("err"),
+			expectReport: &domains.SyncReport{TenantID: mockTenantID, Outcome: domains.SyncOutcomeUnchanged},
+			expectError:  nil,
 		},
 		{
-			name: "err get status",
+			name: "err resolve tenant alias",
 			prepareMock: func() {
 				mockHashAdapter.
 					EXPECT().
 					GenerateTenantID(mockString).
 					Return(mockTenantID)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockTenantID).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetTenantIDByAlias(gomock.Any(), mockTenantID).
+					Return("", errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+		{
+			name: "err get status",
+			prepareMock: func() {
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -381,10 +865,7 @@ func UnitTestSyncClientDatabase(
 		{
 			name: "err status in progress",
 			prepareMock: func() {
-				mockHashAdapter.
-					EXPECT().
-					GenerateTenantID(mockString).
-					Return(mockTenantID)
+				expectTenant()
 				mockStatusAdapter.
 					EXPECT().
 					GetStatus(gomock.Any(), mockTenantID).
@@ -419,7 +900,7 @@ func UnitTestSyncClientDatabase(
 				tt.prepareMock()
 			}
 
-			_, msg, err := svc.SyncClientDatabase(context.Background(), mockString)
+			report, msg, err := svc.SyncClientDatabase(context.Background(), mockString)
 
 			if tt.expectError != nil {
 				require.Error(t, err)
@@ -427,6 +908,8 @@ func UnitTestSyncClientDatabase(
 				require.Equal(t, msg, tt.warnMessage)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tt.warnMessage, msg)
+				require.Equal(t, tt.expectReport, report)
 			}
 		})
 	}
@@ -0,0 +1,376 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_SyncWorkspace(t *testing.T) {
+//	    workspace.UnitTestSyncWorkspace(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
+//	}
+func UnitTestSyncWorkspace(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		clientDatabaseAdapter ports.ClientDatabasePort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+		hashAdapter ports.HashPort,
+		taskQueueService ports.TaskQueuePort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockClientDatabaseAdapter   *mocks.MockClientDatabasePort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEncryptAdapter          *mocks.MockEncryptPort
+		mockHashAdapter             *mocks.MockHashPort
+		mockTaskQueuePort           *mocks.MockTaskQueuePort
+	)
+
+	mockID := "sales"
+	mockOLTPURL := "postgres://primary:5432/app"
+	mockReportingURL := "postgres://replica:5432/app"
+	mockWorkspace := func(checksum string) *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockID,
+			Name:     "Sales",
+			Checksum: checksum,
+			DataSources: []domains.DataSource{
+				{Name: "oltp", EncryptedDBURL: "encrypted_oltp"},
+				{Name: "reporting", EncryptedDBURL: "encrypted_reporting"},
+			},
+		}
+	}
+
+	// The replica also has the orders table, which is taken from the OLTP
+	// database listed first
+	mockOLTPMetadata := func() *domains.DatabaseMetadata {
+		return &domains.DatabaseMetadata{Tables: []domains.Table{
+			{Name: "orders", Columns: []domains.Column{{Name: "id", Type: "INT", IsPrimaryKey: true}}},
+		}}
+	}
+	mockReportingMetadata := func() *domains.DatabaseMetadata {
+		return &domains.DatabaseMetadata{Tables: []domains.Table{
+			{Name: "orders", Columns: []domains.Column{{Name: "id", Type: "INT", IsPrimaryKey: true}}},
+			{Name: "daily_revenue", Columns: []domains.Column{{Name: "day", Type: "DATE"}}},
+		}}
+	}
+	mockMerged := &domains.DatabaseMetadata{
+		TenantID: mockID,
+		Tables: []domains.Table{
+			{Name: "orders", Columns: []domains.Column{{Name: "id", Type: "INT", IsPrimaryKey: true}}, Source: "oltp"},
+			{Name: "daily_revenue", Columns: []domains.Column{{Name: "day", Type: "DATE"}}, Source: "reporting"},
+		},
+		Checksum: "checksum_new",
+	}
+	mockQueued := mockWorkspace("")
+	mockQueued.Status = domains.StatusInProgress
+	// Preferring the replica reads the orders table from it instead
+	mockPreferred := func() *domains.Workspace {
+		workspace := mockWorkspace("")
+		workspace.DataSources[1].Preferred = true
+		return workspace
+	}
+	mockPreferredQueued := mockPreferred()
+	mockPreferredQueued.Status = domains.StatusInProgress
+	mockPreferredMerged := &domains.DatabaseMetadata{
+		TenantID: mockID,
+		Tables: []domains.Table{
+			{Name: "orders", Columns: []domains.Column{{Name: "id", Type: "INT", IsPrimaryKey: true}}, Source: "reporting"},
+			{Name: "daily_revenue", Columns: []domains.Column{{Name: "day", Type: "DATE"}}, Source: "reporting"},
+		},
+		Checksum: "checksum_new",
+	}
+	mockFailed := mockWorkspace("")
+	mockFailed.Status = domains.StatusError
+
+	expectIdle := func(workspace *domains.Workspace) {
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockID).
+			Return(nil)
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockID).
+			Return(workspace, nil)
+	}
+	expectRead := func(checksum string) {
+		mockEncryptAdapter.
+			EXPECT().
+			Decrypt("encrypted_oltp").
+			Return(mockOLTPURL, nil)
+		mockEncryptAdapter.
+			EXPECT().
+			Decrypt("encrypted_reporting").
+			Return(mockReportingURL, nil)
+		mockClientDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockOLTPURL).
+			Return(nil)
+		mockClientDatabaseAdapter.
+			EXPECT().
+			GetDatabaseMetadata(gomock.Any()).
+			Return(mockOLTPMetadata(), nil)
+		mockClientDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockReportingURL).
+			Return(nil)
+		mockClientDatabaseAdapter.
+			EXPECT().
+			GetDatabaseMetadata(gomock.Any()).
+			Return(mockReportingMetadata(), nil)
+		mockHashAdapter.
+			EXPECT().
+			GenerateChecksum(gomock.Any()).
+			Return(checksum, nil)
+	}
+	// Each source is connected to again to describe its tables
+	expectDescribed := func() {
+		mockClientDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockOLTPURL).
+			Return(nil)
+		mockClientDatabaseAdapter.
+			EXPECT().
+			Connect(gomock.Any(), mockReportingURL).
+			Return(nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			ListDescriptionsByTenantID(gomock.Any(), mockID).
+			Return(nil, nil).
+			Times(2)
+	}
+
+	tests := []struct {
+		name         string
+		prepareMock  func()
+		warnMessage  *string
+		expectReport *domains.SyncReport
+		expectError  error
+	}{
+		{
+			name: "success merges the data sources and enqueues their ingestion",
+			prepareMock: func() {
+				expectIdle(mockWorkspace(""))
+				expectRead("checksum_new")
+				expectDescribed()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueued).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMerged).
+					Return(nil)
+			},
+			expectReport: &domains.SyncReport{
+				TenantID: mockID,
+				Outcome:  domains.SyncOutcomeEnqueued,
+				Checksum: "checksum_new",
+				Metadata: mockMerged,
+				Diff:     domains.SchemaDiff{AddedTables: []string{"orders", "daily_revenue"}},
+			},
+		},
+		{
+			name: "success reads duplicate tables from the preferred data source",
+			prepareMock: func() {
+				expectIdle(mockPreferred())
+				expectRead("checksum_new")
+				// Only the replica has tables left to describe
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockReportingURL).
+					Return(nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					ListDescriptionsByTenantID(gomock.Any(), mockID).
+					Return(nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockPreferredQueued).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockPreferredMerged).
+					Return(nil)
+			},
+			expectReport: &domains.SyncReport{
+				TenantID: mockID,
+				Outcome:  domains.SyncOutcomeEnqueued,
+				Checksum: "checksum_new",
+				Metadata: mockPreferredMerged,
+				Diff:     domains.SchemaDiff{AddedTables: []string{"orders", "daily_revenue"}},
+			},
+		},
+		{
+			name: "success with no changes",
+			prepareMock: func() {
+				expectIdle(mockWorkspace("checksum_new"))
+				expectRead("checksum_new")
+			},
+			expectReport: &domains.SyncReport{TenantID: mockID, Outcome: domains.SyncOutcomeUnchanged},
+		},
+		{
+			name: "success with warn because a data source is unreachable",
+			prepareMock: func() {
+				expectIdle(mockWorkspace("checksum_old"))
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt("encrypted_oltp").
+					Return(mockOLTPURL, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockOLTPURL).
+					Return(errors.New("connection refused"))
+			},
+			warnMessage: func() *string {
+				msg := ports.WorkspaceServiceWarnUseExistingClientDatabaseError
+				return &msg
+			}(),
+			expectReport: &domains.SyncReport{TenantID: mockID, Outcome: domains.SyncOutcomeUsedCachedSchema},
+		},
+		{
+			name: "err data source unreachable before the first ingestion",
+			prepareMock: func() {
+				expectIdle(mockWorkspace(""))
+				mockEncryptAdapter.
+					EXPECT().
+					Decrypt("encrypted_oltp").
+					Return(mockOLTPURL, nil)
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockOLTPURL).
+					Return(errors.New("connection refused"))
+			},
+			expectError: errors.New("connection refused"),
+		},
+		{
+			name: "err enqueue ingestion task",
+			prepareMock: func() {
+				expectIdle(mockWorkspace(""))
+				expectRead("checksum_new")
+				expectDescribed()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockQueued).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetInProgress(gomock.Any(), mockID).
+					Return(nil)
+				mockTaskQueuePort.
+					EXPECT().
+					EnqueueSchemaIngestionTask(gomock.Any(), mockMerged).
+					Return(errors.New("queue error"))
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockFailed).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					SetError(gomock.Any(), mockID, errors.New("queue error").Error()).
+					Return(nil)
+			},
+			expectError: errors.New("queue error"),
+		},
+		{
+			name: "err workspace not found",
+			prepareMock: func() {
+				expectIdle(nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name: "err workspace identified by its database URL",
+			prepareMock: func() {
+				expectIdle(&domains.Workspace{TenantID: mockID, EncryptedDBURL: "encrypted_url"})
+			},
+			expectError: ports.WorkspaceNotNamedError,
+		},
+		{
+			name: "err status in progress",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockID).
+					Return(nil)
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name: "err connect internal DB",
+			prepareMock: func() {
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockID).
+					Return(errors.New("err"))
+			},
+			expectError: errors.New("err"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockClientDatabaseAdapter = mocks.NewMockClientDatabasePort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEncryptAdapter = mocks.NewMockEncryptPort(ctrl)
+			mockHashAdapter = mocks.NewMockHashPort(ctrl)
+			mockTaskQueuePort = mocks.NewMockTaskQueuePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockClientDatabaseAdapter,
+				mockInternalDatabaseAdapter,
+				mockEncryptAdapter,
+				mockHashAdapter,
+				mockTaskQueuePort,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			report, msg, err := svc.SyncWorkspace(context.Background(), mockID)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+				require.Equal(t, msg, tt.warnMessage)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.warnMessage, msg)
+				require.Equal(t, tt.expectReport, report)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_Update(t *testing.T) {
+//	    workspace.UnitTestUpdate(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
+//	}
+func UnitTestUpdate(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		clientDatabaseAdapter ports.ClientDatabasePort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+		encryptAdapter ports.EncryptPort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockClientDatabaseAdapter   *mocks.MockClientDatabasePort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+		mockEncryptAdapter          *mocks.MockEncryptPort
+	)
+
+	mockID := "sales"
+	mockReportingURL := "postgres://replica-2:5432/app"
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID:    mockID,
+			Name:        "Sales",
+			Status:      domains.StatusDone,
+			Checksum:    "checksum_abc",
+			DataSources: []domains.DataSource{{Name: "oltp", EncryptedDBURL: "encrypted_oltp"}},
+		}
+	}
+	// The OLTP source keeps its URL and becomes preferred, a reporting replica
+	// is added
+	mockDefinition := func() *domains.WorkspaceDefinition {
+		return &domains.WorkspaceDefinition{
+			ID:     mockID,
+			Name:   "Sales EU",
+			Labels: map[string]string{"region": "eu"},
+			DataSources: []domains.DataSourceDefinition{
+				{Name: "oltp", Preferred: true},
+				{Name: "reporting", DBURL: mockReportingURL},
+			},
+		}
+	}
+	mockUpdated := mockWorkspace()
+	mockUpdated.Name = "Sales EU"
+	mockUpdated.Labels = map[string]string{"region": "eu"}
+	mockUpdated.DataSources = []domains.DataSource{
+		{Name: "oltp", EncryptedDBURL: "encrypted_oltp", Preferred: true},
+		{Name: "reporting", EncryptedDBURL: "encrypted_reporting"},
+	}
+
+	invalidErr := ports.WorkspaceDefinitionInvalidError
+	invalidErr.AddAdditionalErrorInfo("at least one data source is required")
+	missingURLErr := ports.WorkspaceDefinitionInvalidError
+	missingURLErr.AddAdditionalErrorInfo(`data source "archive" has no database URL`)
+
+	expectIdle := func(workspace *domains.Workspace) {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockID).
+			Return(workspace, nil)
+	}
+
+	tests := []struct {
+		name        string
+		definition  func() *domains.WorkspaceDefinition
+		prepareMock func()
+		expectError error
+		expectData  *domains.Workspace
+	}{
+		{
+			name:       "success update name, labels and data sources",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectIdle(mockWorkspace())
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockReportingURL).
+					Return(nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockReportingURL).
+					Return("encrypted_reporting")
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockUpdated).
+					Return(nil)
+			},
+			expectData: mockUpdated,
+		},
+		{
+			name: "error invalid definition",
+			definition: func() *domains.WorkspaceDefinition {
+				return &domains.WorkspaceDefinition{ID: mockID}
+			},
+			expectError: invalidErr,
+		},
+		{
+			name:       "error status in progress",
+			definition: mockDefinition,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:       "error workspace not found",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectIdle(nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:       "error workspace identified by its database URL",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectIdle(&domains.Workspace{TenantID: mockID, EncryptedDBURL: "encrypted_url"})
+			},
+			expectError: ports.WorkspaceNotNamedError,
+		},
+		{
+			name: "error new data source without URL",
+			definition: func() *domains.WorkspaceDefinition {
+				definition := mockDefinition()
+				definition.DataSources = []domains.DataSourceDefinition{{Name: "archive"}}
+				return definition
+			},
+			prepareMock: func() {
+				expectIdle(mockWorkspace())
+			},
+			expectError: missingURLErr,
+		},
+		{
+			name:       "error upsert workspace",
+			definition: mockDefinition,
+			prepareMock: func() {
+				expectIdle(mockWorkspace())
+				mockClientDatabaseAdapter.
+					EXPECT().
+					Connect(gomock.Any(), mockReportingURL).
+					Return(nil)
+				mockEncryptAdapter.
+					EXPECT().
+					Encrypt(mockReportingURL).
+					Return("encrypted_reporting")
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockUpdated).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockClientDatabaseAdapter = mocks.NewMockClientDatabasePort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+			mockEncryptAdapter = mocks.NewMockEncryptPort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockClientDatabaseAdapter,
+				mockInternalDatabaseAdapter,
+				mockEncryptAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.Update(context.Background(), tt.definition())
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
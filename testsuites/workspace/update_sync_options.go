@@ -0,0 +1,166 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_UpdateSyncOptions(t *testing.T) {
+//	    workspace.UnitTestUpdateSyncOptions(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
+//	}
+func UnitTestUpdateSyncOptions(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockOptions := &domains.SyncOptions{
+		ExcludeSchemas:   []string{"staging"},
+		ExcludeTables:    []string{"audit_*"},
+		SensitiveColumns: []string{"*password*", "customers.email"},
+	}
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockTenantID,
+			Status:   domains.StatusDone,
+			Checksum: "checksum_abc",
+		}
+	}
+	mockUpdated := mockWorkspace()
+	mockUpdated.SyncOptions = mockOptions
+
+	invalidErr := ports.WorkspaceSyncOptionsInvalidError
+	invalidErr.AddAdditionalErrorInfo(`invalid pattern "secret_[": syntax error in pattern`)
+
+	expectIdle := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(), nil)
+	}
+
+	tests := []struct {
+		name        string
+		options     *domains.SyncOptions
+		prepareMock func()
+		expectError error
+		expectData  *domains.Workspace
+	}{
+		{
+			name:    "success update sync options",
+			options: mockOptions,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockUpdated).
+					Return(nil)
+			},
+			expectData: mockUpdated,
+		},
+		{
+			name:    "success clear sync options",
+			options: nil,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockWorkspace()).
+					Return(nil)
+			},
+			expectData: mockWorkspace(),
+		},
+		{
+			name:        "error invalid pattern",
+			options:     &domains.SyncOptions{SensitiveColumns: []string{"secret_["}},
+			expectError: invalidErr,
+		},
+		{
+			name:    "error status in progress",
+			options: mockOptions,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:    "error workspace not found",
+			options: mockOptions,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:    "error upsert workspace",
+			options: mockOptions,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockUpdated).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.UpdateSyncOptions(context.Background(), mockTenantID, tt.options)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-nl2query-lib/domains"
+	"github.com/kamil5b/go-nl2query-lib/ports"
+	"github.com/kamil5b/go-nl2query-lib/testsuites/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// @example usage:
+//
+//	func TestWorkspaceService_UpdateSyncSchedule(t *testing.T) {
+//	    workspace.UnitTestUpdateSyncSchedule(t, NewWorkspaceService(config, statusAdapter, clientDatabaseAdapter, internalDatabaseAdapter, encryptAdapter, hashAdapter, taskQueueService, vectorStoreAdapter, llmAdapter))
+//	}
+func UnitTestUpdateSyncSchedule(
+	t *testing.T,
+	svcImp func(
+		statusAdapter ports.StatusPort,
+		internalDatabaseAdapter ports.InternalDatabasePort,
+	) ports.WorkspaceService,
+) {
+	var (
+		mockStatusAdapter           *mocks.MockStatusPort
+		mockInternalDatabaseAdapter *mocks.MockInternalDatabasePort
+	)
+
+	mockTenantID := "tenant_123"
+	mockSchedule := "*/30 * * * *"
+	mockWorkspace := func() *domains.Workspace {
+		return &domains.Workspace{
+			TenantID: mockTenantID,
+			Status:   domains.StatusDone,
+			Checksum: "checksum_abc",
+		}
+	}
+	mockUpdated := mockWorkspace()
+	mockUpdated.SyncSchedule = mockSchedule
+
+	invalidErr := ports.WorkspaceSyncScheduleInvalidError
+	invalidErr.AddAdditionalErrorInfo(`value "61" out of range 0-59 in minute field`)
+
+	expectIdle := func() {
+		mockStatusAdapter.
+			EXPECT().
+			GetStatus(gomock.Any(), mockTenantID).
+			Return(domains.StatusDone, nil, nil)
+		mockInternalDatabaseAdapter.
+			EXPECT().
+			GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+			Return(mockWorkspace(), nil)
+	}
+
+	tests := []struct {
+		name        string
+		schedule    string
+		prepareMock func()
+		expectError error
+		expectData  *domains.Workspace
+	}{
+		{
+			name:     "success update sync schedule",
+			schedule: mockSchedule,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockUpdated).
+					Return(nil)
+			},
+			expectData: mockUpdated,
+		},
+		{
+			name:     "success reset to the default schedule",
+			schedule: "",
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockWorkspace()).
+					Return(nil)
+			},
+			expectData: mockWorkspace(),
+		},
+		{
+			name:        "error invalid cron expression",
+			schedule:    "61 * * * *",
+			expectError: invalidErr,
+		},
+		{
+			name:     "error status in progress",
+			schedule: mockSchedule,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusInProgress, nil, nil)
+			},
+			expectError: ports.StatusInProgressError,
+		},
+		{
+			name:     "error workspace not found",
+			schedule: mockSchedule,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(nil, nil)
+			},
+			expectError: ports.WorkspaceNotFoundError,
+		},
+		{
+			name:     "error workspace deleted",
+			schedule: mockSchedule,
+			prepareMock: func() {
+				mockStatusAdapter.
+					EXPECT().
+					GetStatus(gomock.Any(), mockTenantID).
+					Return(domains.StatusDone, nil, nil)
+				deleted := mockWorkspace()
+				deleted.DeletedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					GetWorkspaceByTenantID(gomock.Any(), mockTenantID).
+					Return(deleted, nil)
+			},
+			expectError: ports.WorkspaceDeletedError,
+		},
+		{
+			name:     "error upsert workspace",
+			schedule: mockSchedule,
+			prepareMock: func() {
+				expectIdle()
+				mockInternalDatabaseAdapter.
+					EXPECT().
+					UpsertWorkspace(gomock.Any(), mockUpdated).
+					Return(errors.New("database error"))
+			},
+			expectError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStatusAdapter = mocks.NewMockStatusPort(ctrl)
+			mockInternalDatabaseAdapter = mocks.NewMockInternalDatabasePort(ctrl)
+
+			svc := svcImp(
+				mockStatusAdapter,
+				mockInternalDatabaseAdapter,
+			)
+
+			if tt.prepareMock != nil {
+				tt.prepareMock()
+			}
+
+			result, err := svc.UpdateSyncSchedule(context.Background(), mockTenantID, tt.schedule)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				require.Equal(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectData, result)
+			}
+		})
+	}
+}